@@ -19,19 +19,33 @@ type Reader struct {
 	reader            io.Reader
 	rootQueryResponse hypersynccapnp.QueryResponse
 	response          QueryResponseInterface
+	decoder           *types.Decoder
 }
 
-// NewQueryResponseReader creates a new Reader instance from an io.ReadCloser.
+// NewQueryResponseReader creates a new Reader instance from an io.ReadCloser, decoding leniently.
 func NewQueryResponseReader(bReader io.ReadCloser) (*Reader, error) {
+	return NewQueryResponseReaderWithMode(bReader, types.DecodeLenient)
+}
+
+// NewQueryResponseReaderWithMode creates a new Reader instance from an io.ReadCloser, decoding in the given mode.
+func NewQueryResponseReaderWithMode(bReader io.ReadCloser, mode types.DecodeMode) (*Reader, error) {
 	queryResponse := &types.QueryResponse{Data: types.DataResponse{}}
-	return NewReader(bReader, queryResponse)
+	return NewReaderWithMode(bReader, queryResponse, mode)
 }
 
-// NewReader initializes a Reader with a provided io.ReadCloser and QueryResponseInterface.
+// NewReader initializes a Reader with a provided io.ReadCloser and QueryResponseInterface, decoding leniently.
 func NewReader(bReader io.ReadCloser, response QueryResponseInterface) (*Reader, error) {
+	return NewReaderWithMode(bReader, response, types.DecodeLenient)
+}
+
+// NewReaderWithMode initializes a Reader with a provided io.ReadCloser and QueryResponseInterface, decoding
+// in the given mode. In strict mode, a column of an unexpected type or with an unknown name fails the read.
+// In lenient mode, such columns are skipped and reported in the decode report of the response.
+func NewReaderWithMode(bReader io.ReadCloser, response QueryResponseInterface, mode types.DecodeMode) (*Reader, error) {
 	toReturn := &Reader{
 		reader:   bReader,
 		response: response,
+		decoder:  types.NewDecoder(mode),
 	}
 
 	decoder := capnp.NewPackedDecoder(bReader)
@@ -83,6 +97,10 @@ func NewReader(bReader io.ReadCloser, response QueryResponseInterface) (*Reader,
 		return nil, errors.Wrap(pdErr, "failed to process query response data")
 	}
 
+	if reporter, ok := toReturn.response.(DecodeReporter); ok && !toReturn.decoder.Report().Empty() {
+		reporter.SetDecodeReport(toReturn.decoder.Report())
+	}
+
 	return toReturn, nil
 }
 
@@ -91,6 +109,11 @@ func (r *Reader) GetRootQueryResponse() hypersynccapnp.QueryResponse {
 	return r.rootQueryResponse
 }
 
+// GetDecodeReport returns the warnings recorded while decoding the response. It is empty in strict mode.
+func (r *Reader) GetDecodeReport() *types.DecodeReport {
+	return r.decoder.Report()
+}
+
 // GetQueryResponse returns the query response.
 func (r *Reader) GetQueryResponse() *types.QueryResponse {
 	return r.response.(*types.QueryResponse)
@@ -190,25 +213,25 @@ func (r *Reader) readChunks(data []byte, dt types.DataType) error {
 func (r *Reader) processRecord(record arrow.Record, schema *arrow.Schema, dt types.DataType) error {
 	switch dt {
 	case types.BlocksDataType:
-		if block, bErr := types.NewBlockFromRecord(schema, record); bErr != nil {
+		if block, bErr := r.decoder.Block(schema, record); bErr != nil {
 			return errors.Wrap(bErr, "failed to build block data from record")
 		} else if block != nil {
 			r.response.AppendBlockData(*block)
 		}
 	case types.TransactionsDataType:
-		if tx, bErr := types.NewTransactionFromRecord(schema, record); bErr != nil {
+		if tx, bErr := r.decoder.Transaction(schema, record); bErr != nil {
 			return errors.Wrap(bErr, "failed to build transaction data from record")
 		} else if tx != nil {
 			r.response.AppendTransactionData(*tx)
 		}
 	case types.LogsDataType:
-		if log, bErr := types.NewLogFromRecord(schema, record); bErr != nil {
+		if log, bErr := r.decoder.Log(schema, record); bErr != nil {
 			return errors.Wrap(bErr, "failed to build log data from record")
 		} else if log != nil {
 			r.response.AppendLogData(*log)
 		}
 	case types.TracesDataType:
-		if trace, bErr := types.NewTraceFromRecord(schema, record); bErr != nil {
+		if trace, bErr := r.decoder.Trace(schema, record); bErr != nil {
 			return errors.Wrap(bErr, "failed to build log data from record")
 		} else if trace != nil {
 			r.response.AppendTraceData(*trace)
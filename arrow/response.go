@@ -43,3 +43,10 @@ type QueryResponseInterface interface {
 	// GetRollbackGuard returns the rollback guard from the query response.
 	GetRollbackGuard() *types.RollbackGuard
 }
+
+// DecodeReporter is implemented by query responses that keep the warnings of lenient decoding. The reader sets
+// the report of responses implementing it, so QueryResponseInterface implementations are not required to.
+type DecodeReporter interface {
+	// SetDecodeReport sets the warnings recorded while decoding the query response.
+	SetDecodeReport(*types.DecodeReport)
+}
@@ -10,9 +10,11 @@ import (
 	"net"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	arrowhs "github.com/enviodev/hypersync-client-go/arrow"
+	errorshs "github.com/enviodev/hypersync-client-go/errors"
 	"github.com/enviodev/hypersync-client-go/options"
 	"github.com/enviodev/hypersync-client-go/types"
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -24,44 +26,101 @@ type Client struct {
 	ctx       context.Context
 	opts      options.Node
 	client    *http.Client
+	rpcMu     sync.Mutex
 	rpcClient *ethclient.Client
 	userAgent string
+	chainMu   sync.RWMutex
+	chainInfo *ChainInfo
+	pool      *endpointPool
 }
 
+// NewClient creates a client for the node. The RPC endpoint, when configured, is dialed on first use, or
+// at creation when the node enables the probe.
 func NewClient(ctx context.Context, opts options.Node) (*Client, error) {
+	return newClient(ctx, opts, nil)
+}
+
+// NewClientWithRPC creates a client for the node using the given RPC client instead of dialing the node
+// RPC endpoint, for example a client attached to an in-process server with rpc.DialInProc.
+func NewClientWithRPC(ctx context.Context, opts options.Node, rpcConn *rpc.Client) (*Client, error) {
+	if rpcConn == nil {
+		return nil, errors.New("rpc client is nil")
+	}
+	return newClient(ctx, opts, ethclient.NewClient(rpcConn))
+}
+
+func newClient(ctx context.Context, opts options.Node, rpcClient *ethclient.Client) (*Client, error) {
+	return newClientWithPool(ctx, opts, rpcClient, nil)
+}
+
+// newClientWithPool creates a client for the node. With a pool, requests fail over between its nodes.
+func newClientWithPool(ctx context.Context, opts options.Node, rpcClient *ethclient.Client, pool *endpointPool) (*Client, error) {
 	if err := opts.Validate(); err != nil {
 		return nil, errors.Wrap(err, "invalid node options")
 	}
 
-	rpcConn, err := rpc.DialOptions(ctx, opts.RpcEndpoint, rpc.WithHeader("Authorization", "Bearer "+opts.ApiToken))
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to connect to RPC client")
+	var transport http.RoundTripper = &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+	if pool != nil {
+		transport = &failoverTransport{pool: pool, base: transport}
 	}
-	rpcClient := ethclient.NewClient(rpcConn)
 
-	return &Client{
+	client := &Client{
 		ctx:  ctx,
 		opts: opts,
 		client: &http.Client{
-			Timeout: 2 * time.Minute,
-			Transport: &http.Transport{
-				DialContext: (&net.Dialer{
-					Timeout:   30 * time.Second,
-					KeepAlive: 30 * time.Second,
-				}).DialContext,
-				MaxIdleConns:          100,
-				IdleConnTimeout:       90 * time.Second,
-				TLSHandshakeTimeout:   10 * time.Second,
-				ExpectContinueTimeout: 1 * time.Second,
-			},
+			Timeout:   2 * time.Minute,
+			Transport: transport,
 		},
 		rpcClient: rpcClient,
 		userAgent: fmt.Sprintf("hscg/%s", version()),
-	}, nil
+		pool:      pool,
+	}
+
+	if err := client.probeOnCreate(ctx); err != nil {
+		return nil, err
+	}
+	return client, nil
 }
 
-func (c *Client) GetRPC() *ethclient.Client {
-	return c.rpcClient
+// GetRPC returns the RPC client, dialing the node RPC endpoint on first use. A failover client dials the
+// first of its nodes with an RPC endpoint and fails over between the nodes that have one. It returns
+// errorshs.ErrRPCNotConfigured when no node has an RPC endpoint.
+func (c *Client) GetRPC() (*ethclient.Client, error) {
+	c.rpcMu.Lock()
+	defer c.rpcMu.Unlock()
+
+	if c.rpcClient != nil {
+		return c.rpcClient, nil
+	}
+
+	endpoint := c.opts.RpcEndpoint
+	dialOpts := []rpc.ClientOption{rpc.WithHeader("Authorization", "Bearer "+c.opts.ApiToken)}
+	if c.pool != nil {
+		transport := &failoverTransport{pool: c.pool, base: http.DefaultTransport, rpc: true}
+		endpoint = transport.primary()
+		dialOpts = append(dialOpts, rpc.WithHTTPClient(&http.Client{Transport: transport}))
+	}
+
+	if endpoint == "" {
+		return nil, errorshs.ErrRPCNotConfigured
+	}
+
+	rpcConn, err := rpc.DialOptions(c.ctx, endpoint, dialOpts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to RPC client")
+	}
+	c.rpcClient = ethclient.NewClient(rpcConn)
+
+	return c.rpcClient, nil
 }
 
 // retryJitter returns a random duration in [0, max) using crypto/rand for use in retry backoff.
@@ -238,7 +297,7 @@ func DoArrow[R any](ctx context.Context, c *Client, url string, method string, p
 		return nil, fmt.Errorf("unexpected status code: %d, response: %s", resp.StatusCode, string(responseData))
 	}
 
-	arrowReader, err := arrowhs.NewQueryResponseReader(resp.Body)
+	arrowReader, err := arrowhs.NewQueryResponseReaderWithMode(resp.Body, c.opts.GetDecodeMode())
 	if err != nil {
 		return nil, errors.Wrap(err, "could not parse the ipc/arrow response while attempting to read")
 	}
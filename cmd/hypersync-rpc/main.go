@@ -0,0 +1,74 @@
+// Command hypersync-rpc serves eth_blockNumber, eth_getLogs, eth_getBlockByNumber, eth_getTransactionByHash
+// and eth_getTransactionReceipt over HTTP from HyperSync, for tools hardwired to JSON-RPC.
+//
+// Usage:
+//
+//	ENVIO_API_TOKEN=... hypersync-rpc -network 1 -listen 127.0.0.1:8545
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	hypersyncgo "github.com/enviodev/hypersync-client-go"
+	"github.com/enviodev/hypersync-client-go/options"
+	"github.com/enviodev/hypersync-client-go/rpcserver"
+	"github.com/enviodev/hypersync-client-go/utils"
+)
+
+func main() {
+	listen := flag.String("listen", "127.0.0.1:8545", "address to serve JSON-RPC on")
+	network := flag.String("network", utils.EthereumNetworkID.String(), "chain id or name of the network to serve")
+	endpoint := flag.String("endpoint", "", "HyperSync endpoint, defaults to the one of the network")
+	token := flag.String("token", os.Getenv("ENVIO_API_TOKEN"), "HyperSync API token, defaults to $ENVIO_API_TOKEN")
+	maxLogs := flag.Int("max-logs", rpcserver.DefaultMaxLogs, "maximum number of logs returned by eth_getLogs")
+	maxBlockRange := flag.Uint64("max-block-range", 0, "maximum block range of eth_getLogs, 0 for no limit")
+	flag.Parse()
+
+	networkID, err := utils.ParseNetworkID(*network)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *endpoint == "" {
+		*endpoint = utils.HyperSyncURL(networkID)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	client, err := hypersyncgo.NewClient(ctx, options.Node{
+		Type:      networkID.ToNetwork(),
+		NetworkId: networkID,
+		Endpoint:  *endpoint,
+		ApiToken:  *token,
+	})
+	if err != nil {
+		log.Fatalf("Failed to create hypersync client: %v", err)
+	}
+
+	server, err := rpcserver.NewServer(client, &rpcserver.Options{MaxLogs: *maxLogs, MaxBlockRange: *maxBlockRange})
+	if err != nil {
+		log.Fatalf("Failed to create rpc server: %v", err)
+	}
+	defer server.Stop()
+
+	httpServer := &http.Server{Addr: *listen, Handler: server, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = httpServer.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("Serving JSON-RPC for network %s from %s on %s", networkID, *endpoint, *listen)
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("Failed to serve: %v", err)
+	}
+}
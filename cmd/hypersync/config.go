@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	hypersyncgo "github.com/enviodev/hypersync-client-go"
+	"github.com/enviodev/hypersync-client-go/options"
+	"github.com/enviodev/hypersync-client-go/utils"
+)
+
+// apiTokenEnv is the environment variable holding the HyperSync API token.
+const apiTokenEnv = "ENVIO_API_TOKEN"
+
+// nodeFlags are the flags configuring the node to connect to.
+type nodeFlags struct {
+	config      string
+	network     string
+	endpoint    string
+	rpcEndpoint string
+	token       string
+}
+
+func (f *nodeFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.config, "config", "", "YAML or JSON config file of options with blockchains, as read by options.Load")
+	fs.StringVar(&f.network, "network", "", "chain id or name of the network, defaults to the config file network or 1")
+	fs.StringVar(&f.endpoint, "endpoint", "", "HyperSync endpoint, defaults to the one of the network")
+	fs.StringVar(&f.rpcEndpoint, "rpc-endpoint", "", "JSON-RPC endpoint, defaults to the HyperRPC one of the network")
+	fs.StringVar(&f.token, "token", "", "HyperSync API token, defaults to $"+apiTokenEnv)
+}
+
+// node resolves the node from the config file, the environment and the flags, in increasing precedence.
+func (f *nodeFlags) node() (options.Node, error) {
+	var node options.Node
+	var network utils.NetworkID
+	if f.network != "" {
+		id, err := utils.ParseNetworkID(f.network)
+		if err != nil {
+			return node, err
+		}
+		network = id
+	}
+
+	if f.config != "" {
+		loaded, err := loadNode(f.config, network)
+		if err != nil {
+			return node, err
+		}
+		node = *loaded
+	}
+
+	if network != 0 {
+		node.NetworkId = network
+	}
+	if node.NetworkId == 0 {
+		node.NetworkId = utils.EthereumNetworkID
+	}
+	if node.Type == "" {
+		if info, ok := utils.GetNetworkInfo(node.NetworkId); ok {
+			node.Type = info.Name
+		}
+	}
+
+	if f.endpoint != "" {
+		node.Endpoint = f.endpoint
+	}
+	if node.Endpoint == "" {
+		node.Endpoint = utils.HyperSyncURL(node.NetworkId)
+	}
+	if f.rpcEndpoint != "" {
+		node.RpcEndpoint = f.rpcEndpoint
+	}
+	if node.RpcEndpoint == "" {
+		node.RpcEndpoint = utils.HyperRPCURL(node.NetworkId)
+	}
+
+	if token := os.Getenv(apiTokenEnv); token != "" && node.ApiToken == "" {
+		node.ApiToken = token
+	}
+	if f.token != "" {
+		node.ApiToken = f.token
+	}
+
+	return node, nil
+}
+
+// client creates a client for the configured node.
+func (f *nodeFlags) client(ctx context.Context) (*hypersyncgo.Client, error) {
+	node, err := f.node()
+	if err != nil {
+		return nil, err
+	}
+	return hypersyncgo.NewClient(ctx, node)
+}
+
+// loadNode reads the options of a config file with options.Load and picks the node of the network, or the
+// first node when no network is given.
+func loadNode(path string, network utils.NetworkID) (*options.Node, error) {
+	opts, err := options.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	if network == 0 {
+		return &opts.Blockchains[0], nil
+	}
+	node, ok := opts.GetNodeByNetworkId(network)
+	if !ok {
+		return nil, fmt.Errorf("config file %s has no blockchain for network: %s", path, network)
+	}
+	return node, nil
+}
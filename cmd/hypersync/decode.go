@@ -0,0 +1,213 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/enviodev/hypersync-client-go/decoder"
+	"github.com/enviodev/hypersync-client-go/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// maxLineSize is the maximum size of an NDJSON input line.
+const maxLineSize = 16 * 1024 * 1024
+
+// decodedLog is an output line of the decode command.
+type decodedLog struct {
+	BlockNumber     *big.Int             `json:"block_number,omitempty"`
+	TransactionHash *common.Hash         `json:"transaction_hash,omitempty"`
+	LogIndex        *uint64              `json:"log_index,omitempty"`
+	Address         *common.Address      `json:"address,omitempty"`
+	Event           *decoder.EthereumLog `json:"event"`
+}
+
+// abiSet finds the ABI declaring the event of a log among several ABI files.
+type abiSet struct {
+	events map[common.Hash]string
+}
+
+func loadABIs(paths []string) (*abiSet, error) {
+	set := &abiSet{events: make(map[common.Hash]string)}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read abi file")
+		}
+		parsed, err := abi.JSON(bytes.NewReader(data))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse abi file: %s", path)
+		}
+		for _, event := range parsed.Events {
+			if _, ok := set.events[event.ID]; !ok {
+				set.events[event.ID] = string(data)
+			}
+		}
+	}
+	return set, nil
+}
+
+// decode decodes the log with the ABI declaring its event.
+func (s *abiSet) decode(log types.Log) (*decoder.EthereumLog, error) {
+	topics := log.Topics()
+	if len(topics) == 0 {
+		return nil, errors.New("log has no topics")
+	}
+	rawABI, ok := s.events[topics[0]]
+	if !ok {
+		return nil, fmt.Errorf("no abi declares event: %s", topics[0].Hex())
+	}
+	return decoder.DecodeEthereumLog(log, rawABI)
+}
+
+func runDecode(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error {
+	fs := flag.NewFlagSet("decode", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var node nodeFlags
+	var queryOpts queryFlags
+	var streamOpts streamFlags
+	node.register(fs)
+	queryOpts.register(fs)
+	streamOpts.register(fs)
+	abiFiles := fs.String("abi", "", "comma-separated ABI JSON files (required)")
+	input := fs.String("input", "", `NDJSON logs, as written by query and stream, to decode instead of fetching them; "-" reads stdin`)
+	strict := fs.Bool("strict", false, "fail on logs that cannot be decoded instead of skipping them")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	paths := splitList(*abiFiles)
+	if len(paths) == 0 {
+		return errors.New("at least one abi file is required, set -abi")
+	}
+	abis, err := loadABIs(paths)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(stdout)
+	decoded, skipped := 0, 0
+	handle := func(log types.Log) error {
+		event, dErr := abis.decode(log)
+		if dErr != nil {
+			if *strict {
+				return errors.Wrapf(dErr, "failed to decode log %d of block %v", indexOrZero(log.LogIndex), log.BlockNumber)
+			}
+			skipped++
+			return nil
+		}
+		decoded++
+		return encoder.Encode(decodedLog{
+			BlockNumber:     log.BlockNumber,
+			TransactionHash: log.TransactionHash,
+			LogIndex:        log.LogIndex,
+			Address:         log.Address,
+			Event:           event,
+		})
+	}
+
+	if *input != "" {
+		err = decodeInput(*input, handle)
+	} else {
+		err = decodeRange(ctx, &node, &queryOpts, &streamOpts, stderr, handle)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stderr, "decoded %d logs, skipped %d\n", decoded, skipped)
+	return nil
+}
+
+// decodeRange fetches the logs selected by the query flags and hands them to handle.
+func decodeRange(ctx context.Context, node *nodeFlags, queryOpts *queryFlags, streamOpts *streamFlags, stderr io.Writer, handle func(log types.Log) error) error {
+	query, err := queryOpts.query()
+	if err != nil {
+		return err
+	}
+	if len(query.FieldSelection.Log) == 0 {
+		query.FieldSelection.Log = types.LogSchemaFieldsAsString()
+	}
+
+	client, err := node.client(ctx)
+	if err != nil {
+		return err
+	}
+
+	return streamRange(ctx, client, query, streamOpts, stderr, func(response *types.QueryResponse) error {
+		for _, log := range response.Data.Logs {
+			if hErr := handle(log); hErr != nil {
+				return hErr
+			}
+		}
+		return nil
+	})
+}
+
+// decodeInput reads logs from an NDJSON file and hands them to handle. Lines hold either a log keyed by
+// "log", as written by the query and stream commands, or a bare log. Lines of other entities are skipped.
+func decodeInput(path string, handle func(log types.Log) error) error {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return errors.Wrap(err, "failed to open input file")
+		}
+		defer file.Close()
+		r = file
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var entities map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(text), &entities); err != nil {
+			return errors.Wrapf(err, "failed to parse input line %d", line)
+		}
+
+		raw := json.RawMessage(text)
+		if logJSON, ok := entities["log"]; ok {
+			raw = logJSON
+		} else if _, ok := entities["block"]; ok {
+			continue
+		} else if _, ok := entities["transaction"]; ok {
+			continue
+		} else if _, ok := entities["trace"]; ok {
+			continue
+		}
+
+		var log types.Log
+		if err := json.Unmarshal(raw, &log); err != nil {
+			return errors.Wrapf(err, "failed to parse log on input line %d", line)
+		}
+		if err := handle(log); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return errors.Wrap(err, "failed to read input")
+	}
+	return nil
+}
+
+// indexOrZero returns the index, or zero when it was not selected.
+func indexOrZero(index *uint64) uint64 {
+	if index == nil {
+		return 0
+	}
+	return *index
+}
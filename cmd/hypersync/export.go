@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/enviodev/hypersync-client-go/parquet"
+	"github.com/enviodev/hypersync-client-go/types"
+	"github.com/pkg/errors"
+)
+
+// rowWriter writes the entities of an export, a row per entity.
+type rowWriter interface {
+	Write(entity any) error
+	Close() error
+}
+
+// csvWriter writes entities as CSV rows formatted by parquet.Row, with a header of the columns. Missing values
+// are written as empty fields.
+type csvWriter struct {
+	file    *os.File
+	writer  *csv.Writer
+	columns []string
+}
+
+func newCSVWriter(file *os.File, columns []string) (*csvWriter, error) {
+	writer := csv.NewWriter(file)
+	if err := writer.Write(columns); err != nil {
+		return nil, errors.Wrap(err, "failed to write csv header")
+	}
+	return &csvWriter{file: file, writer: writer, columns: columns}, nil
+}
+
+func (w *csvWriter) Write(entity any) error {
+	row, err := parquet.Row(entity, w.columns)
+	if err != nil {
+		return err
+	}
+	record := make([]string, len(row))
+	for i, value := range row {
+		if value != nil {
+			record[i] = *value
+		}
+	}
+	return w.writer.Write(record)
+}
+
+func (w *csvWriter) Close() error {
+	w.writer.Flush()
+	if err := w.writer.Error(); err != nil {
+		return errors.Wrap(err, "failed to write csv")
+	}
+	return w.file.Close()
+}
+
+func runExport(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var node nodeFlags
+	var queryOpts queryFlags
+	var streamOpts streamFlags
+	node.register(fs)
+	queryOpts.register(fs)
+	streamOpts.register(fs)
+	output := fs.String("output", "", "output file (required)")
+	format := fs.String("format", "", "output format, parquet or csv, defaults to the output file extension")
+	entity := fs.String("entity", "logs", "exported entity: blocks, transactions, logs or traces")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *output == "" {
+		return errors.New("an output file is required, set -output")
+	}
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(*output)), ".")
+	}
+	if *format != "parquet" && *format != "csv" {
+		return fmt.Errorf("unsupported export format: %q, use parquet or csv", *format)
+	}
+
+	query, err := queryOpts.query()
+	if err != nil {
+		return err
+	}
+
+	rows, err := entityRows(*entity, query)
+	if err != nil {
+		return err
+	}
+	if len(rows.columns) == 0 {
+		return fmt.Errorf("no %s fields are selected", *entity)
+	}
+
+	client, err := node.client(ctx)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(*output)
+	if err != nil {
+		return errors.Wrap(err, "failed to create output file")
+	}
+
+	var writer rowWriter
+	if *format == "parquet" {
+		writer, err = parquet.NewWriter(file, rows.zero, rows.columns, 0)
+	} else {
+		writer, err = newCSVWriter(file, rows.columns)
+	}
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	count := 0
+	err = streamRange(ctx, client, query, &streamOpts, stderr, func(response *types.QueryResponse) error {
+		return rows.each(response, func(entity any) error {
+			count++
+			return writer.Write(entity)
+		})
+	})
+	if cErr := writer.Close(); cErr != nil && err == nil {
+		err = cErr
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stderr, "exported %d %s to %s\n", count, *entity, *output)
+	return nil
+}
+
+// exportRows lists the entities of one type of a response, exported with a column per field.
+type exportRows struct {
+	zero    any
+	columns []string
+	each    func(response *types.QueryResponse, write func(entity any) error) error
+}
+
+// entityRows returns the rows of the entity type, with a column per field the query selects.
+func entityRows(entity string, query *types.Query) (*exportRows, error) {
+	switch entity {
+	case "blocks":
+		return newExportRows(types.Block{}, query.FieldSelection.Block, func(data types.DataResponse) []types.Block { return data.Blocks }), nil
+	case "transactions":
+		return newExportRows(types.Transaction{}, query.FieldSelection.Transaction, func(data types.DataResponse) []types.Transaction { return data.Transactions }), nil
+	case "logs":
+		return newExportRows(types.Log{}, query.FieldSelection.Log, func(data types.DataResponse) []types.Log { return data.Logs }), nil
+	case "traces":
+		return newExportRows(types.Trace{}, query.FieldSelection.Trace, func(data types.DataResponse) []types.Trace { return data.Traces }), nil
+	default:
+		return nil, fmt.Errorf("unsupported entity: %s, use blocks, transactions, logs or traces", entity)
+	}
+}
+
+func newExportRows[T any](zero T, fields []string, entities func(data types.DataResponse) []T) *exportRows {
+	columns := parquet.Columns(zero, fields)
+	return &exportRows{
+		zero:    zero,
+		columns: columns,
+		each: func(response *types.QueryResponse, write func(entity any) error) error {
+			values := entities(response.Data)
+			for i := range values {
+				if err := write(&values[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+func runHeight(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error {
+	fs := flag.NewFlagSet("height", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var node nodeFlags
+	node.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	client, err := node.client(ctx)
+	if err != nil {
+		return err
+	}
+
+	height, err := client.GetHeight(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get archive height")
+	}
+
+	_, err = fmt.Fprintln(stdout, height)
+	return err
+}
@@ -0,0 +1,88 @@
+// Command hypersync queries, streams, exports and decodes HyperSync data from the command line.
+//
+// Usage:
+//
+//	hypersync <command> [flags]
+//
+// Commands:
+//
+//	height   print the archive height of the network
+//	query    run a single query and print the response as JSON or NDJSON
+//	stream   stream a block range as NDJSON, reporting progress
+//	export   export a block range to a Parquet or CSV file
+//	decode   decode logs with ABI files and print them as NDJSON
+//
+// The node is configured with the -network, -endpoint and -token flags, the ENVIO_API_TOKEN environment
+// variable or a YAML or JSON config file given with -config. Flags take precedence over the config file.
+// Run "hypersync <command> -h" for the flags of a command.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// command is a subcommand of the tool.
+type command struct {
+	name  string
+	usage string
+	run   func(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error
+}
+
+var commands = []command{
+	{name: "height", usage: "print the archive height of the network", run: runHeight},
+	{name: "query", usage: "run a single query and print the response as JSON or NDJSON", run: runQuery},
+	{name: "stream", usage: "stream a block range as NDJSON, reporting progress", run: runStream},
+	{name: "export", usage: "export a block range to a Parquet or CSV file", run: runExport},
+	{name: "decode", usage: "decode logs with ABI files and print them as NDJSON", run: runDecode},
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(os.Stderr, "hypersync: %v\n", err)
+		}
+		stop()
+		os.Exit(1)
+	}
+}
+
+// run dispatches the arguments to the subcommand they name.
+func run(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "-help" || args[0] == "help" {
+		usage(stderr)
+		if len(args) == 0 {
+			return errors.New("no command given")
+		}
+		return nil
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			return cmd.run(ctx, args[1:], stdout, stderr)
+		}
+	}
+
+	usage(stderr)
+	return fmt.Errorf("unknown command: %s", args[0])
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: hypersync <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-8s %s\n", cmd.name, cmd.usage)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `Run "hypersync <command> -h" for the flags of a command.`)
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/enviodev/hypersync-client-go/types"
+	"github.com/enviodev/hypersync-client-go/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+const testERC20ABI = `[{"anonymous":false,"inputs":[
+	{"indexed":true,"name":"from","type":"address"},
+	{"indexed":true,"name":"to","type":"address"},
+	{"indexed":false,"name":"value","type":"uint256"}
+],"name":"Transfer","type":"event"}]`
+
+var testTransferTopic = common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+
+func writeFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestNodeFlags(t *testing.T) {
+	config := writeFile(t, "config.yaml", `
+blockchains:
+  - type: ethereum
+    networkId: 1
+    endpoint: https://eth.hypersync.xyz
+    apiToken: config-token
+  - type: base
+    networkId: 8453
+    endpoint: https://base.hypersync.xyz
+    apiToken: ${ENVIO_API_TOKEN}
+    retryBaseMs: 350
+`)
+
+	t.Run("Config file node of the network", func(t *testing.T) {
+		t.Setenv(apiTokenEnv, "env-token")
+		flags := nodeFlags{config: config, network: "8453"}
+		node, err := flags.node()
+		require.NoError(t, err)
+		require.Equal(t, utils.NetworkID(8453), node.NetworkId)
+		require.Equal(t, "https://base.hypersync.xyz", node.Endpoint)
+		require.Equal(t, "env-token", node.ApiToken)
+		require.Equal(t, time.Duration(350), node.RetryBaseMs)
+	})
+
+	t.Run("Flags take precedence", func(t *testing.T) {
+		t.Setenv(apiTokenEnv, "env-token")
+		flags := nodeFlags{config: config, endpoint: "http://localhost:1131", token: "flag-token"}
+		node, err := flags.node()
+		require.NoError(t, err)
+		require.Equal(t, utils.EthereumNetworkID, node.NetworkId)
+		require.Equal(t, "http://localhost:1131", node.Endpoint)
+		require.Equal(t, "flag-token", node.ApiToken)
+	})
+
+	t.Run("Defaults", func(t *testing.T) {
+		t.Setenv(apiTokenEnv, "env-token")
+		flags := nodeFlags{network: "10"}
+		node, err := flags.node()
+		require.NoError(t, err)
+		require.Equal(t, "https://10.hypersync.xyz", node.Endpoint)
+		require.Equal(t, "https://10.rpc.hypersync.xyz", node.RpcEndpoint)
+		require.Equal(t, "env-token", node.ApiToken)
+	})
+
+	t.Run("Network name", func(t *testing.T) {
+		t.Setenv(apiTokenEnv, "env-token")
+		flags := nodeFlags{network: "arbitrum"}
+		node, err := flags.node()
+		require.NoError(t, err)
+		require.Equal(t, utils.ArbitrumOneNetworkID, node.NetworkId)
+		require.Equal(t, utils.ArbitrumOneNetwork, node.Type)
+		require.Equal(t, "https://42161.hypersync.xyz", node.Endpoint)
+
+		flags = nodeFlags{network: "nowhere"}
+		_, err = flags.node()
+		require.ErrorContains(t, err, "unknown network: nowhere")
+	})
+
+	t.Run("Unknown network", func(t *testing.T) {
+		t.Setenv(apiTokenEnv, "env-token")
+		flags := nodeFlags{config: config, network: "10"}
+		_, err := flags.node()
+		require.ErrorContains(t, err, "has no blockchain for network: 10")
+	})
+
+	t.Run("Invalid config file", func(t *testing.T) {
+		flags := nodeFlags{config: writeFile(t, "config.yaml", "blockchains:\n  - networkId: 1\n    apiToken: token\n    retries: 3\n")}
+		_, err := flags.node()
+		require.ErrorContains(t, err, "unknown field")
+	})
+}
+
+func TestQueryFlags(t *testing.T) {
+	flags := queryFlags{from: 100, to: 200, addresses: "0xdAC17F958D2ee523a2206206994597C13D831ec7", topic0: testTransferTopic.Hex()}
+	query, err := flags.query()
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(100), query.FromBlock)
+	require.Equal(t, big.NewInt(200), query.ToBlock)
+	require.Equal(t, []common.Address{common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7")}, query.Logs[0].Address)
+	require.Equal(t, [][]common.Hash{{testTransferTopic}}, query.Logs[0].Topics)
+	require.Equal(t, types.LogSchemaFieldsAsString(), query.FieldSelection.Log)
+
+	file := writeFile(t, "query.json", `{"from_block": 1, "to_block": 5, "transactions": [{}], "field_selection": {"transaction": ["hash"]}}`)
+	flags = queryFlags{file: file, from: -1, to: 10}
+	query, err = flags.query()
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1), query.FromBlock)
+	require.Equal(t, big.NewInt(10), query.ToBlock)
+	require.Equal(t, []string{"hash"}, query.FieldSelection.Transaction)
+
+	flags = queryFlags{from: -1, to: -1}
+	_, err = flags.query()
+	require.EqualError(t, err, "a from block is required, set -from or from_block in the query file")
+
+	flags = queryFlags{from: 10, to: 5, addresses: "0x01"}
+	_, err = flags.query()
+	require.EqualError(t, err, "invalid address: 0x01")
+}
+
+func TestDecodeInput(t *testing.T) {
+	from := common.HexToAddress("0x01")
+	to := common.HexToAddress("0x02")
+	token := common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7")
+	fromTopic, toTopic := common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())
+	unknownTopic := common.HexToHash("0xaa")
+	index := uint64(4)
+	data := common.LeftPadBytes(big.NewInt(1000).Bytes(), 32)
+
+	transfer := types.Log{BlockNumber: big.NewInt(42), LogIndex: &index, Address: &token, Data: &data, Topic0: &testTransferTopic, Topic1: &fromTopic, Topic2: &toTopic}
+	unknown := types.Log{BlockNumber: big.NewInt(43), Topic0: &unknownTopic}
+
+	var input bytes.Buffer
+	require.NoError(t, writeNDJSON(&input, &types.QueryResponse{Data: types.DataResponse{
+		Blocks: []types.Block{{Number: big.NewInt(42)}},
+		Logs:   []types.Log{transfer, unknown},
+	}}))
+	inputFile := writeFile(t, "logs.ndjson", input.String())
+	abiFile := writeFile(t, "erc20.json", testERC20ABI)
+
+	var stdout, stderr bytes.Buffer
+	err := run(context.Background(), []string{"decode", "-abi", abiFile, "-input", inputFile}, &stdout, &stderr)
+	require.NoError(t, err)
+	require.Equal(t, "decoded 1 logs, skipped 1\n", stderr.String())
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &decoded))
+	require.EqualValues(t, 42, decoded["block_number"])
+	event := decoded["event"].(map[string]any)
+	require.Equal(t, "Transfer", event["name"])
+	require.EqualValues(t, 1000, event["data"].(map[string]any)["value"])
+
+	stdout.Reset()
+	err = run(context.Background(), []string{"decode", "-abi", abiFile, "-input", inputFile, "-strict"}, &stdout, &stderr)
+	require.ErrorContains(t, err, "failed to decode log 0 of block 43")
+}
+
+func TestRunUnknownCommand(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	err := run(context.Background(), []string{"serve"}, &stdout, &stderr)
+	require.EqualError(t, err, "unknown command: serve")
+	require.Contains(t, stderr.String(), "export")
+}
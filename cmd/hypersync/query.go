@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/enviodev/hypersync-client-go/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// queryFlags are the flags building the query of a command.
+type queryFlags struct {
+	file              string
+	from              int64
+	to                int64
+	addresses         string
+	topic0            string
+	logFields         string
+	blockFields       string
+	transactionFields string
+}
+
+func (f *queryFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.file, "query", "", "JSON query file, overriding the selection flags")
+	fs.Int64Var(&f.from, "from", -1, "first block of the range")
+	fs.Int64Var(&f.to, "to", -1, "block to stop at (exclusive)")
+	fs.StringVar(&f.addresses, "address", "", "comma-separated contract addresses of the selected logs")
+	fs.StringVar(&f.topic0, "topic0", "", "comma-separated first topics (event signatures) of the selected logs")
+	fs.StringVar(&f.logFields, "log-fields", "", "comma-separated log fields, defaults to all fields")
+	fs.StringVar(&f.blockFields, "block-fields", "", "comma-separated block fields to join to the logs")
+	fs.StringVar(&f.transactionFields, "transaction-fields", "", "comma-separated transaction fields to join to the logs")
+}
+
+// query builds the query from the query file, or selects logs from the flags. The -from and -to flags
+// override the range of the query file.
+func (f *queryFlags) query() (*types.Query, error) {
+	query := &types.Query{}
+	if f.file != "" {
+		data, err := os.ReadFile(f.file)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read query file")
+		}
+		if err := json.Unmarshal(data, query); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse query file: %s", f.file)
+		}
+	} else {
+		selection := types.LogSelection{}
+		for _, address := range splitList(f.addresses) {
+			if !common.IsHexAddress(address) {
+				return nil, fmt.Errorf("invalid address: %s", address)
+			}
+			selection.Address = append(selection.Address, common.HexToAddress(address))
+		}
+		if topics := splitList(f.topic0); len(topics) > 0 {
+			hashes := make([]common.Hash, len(topics))
+			for i, topic := range topics {
+				hashes[i] = common.HexToHash(topic)
+			}
+			selection.Topics = [][]common.Hash{hashes}
+		}
+
+		query.Logs = []types.LogSelection{selection}
+		query.FieldSelection = types.FieldSelection{
+			Log:         splitList(f.logFields),
+			Block:       splitList(f.blockFields),
+			Transaction: splitList(f.transactionFields),
+		}
+		if len(query.FieldSelection.Log) == 0 {
+			query.FieldSelection.Log = types.LogSchemaFieldsAsString()
+		}
+	}
+
+	if f.from >= 0 {
+		query.FromBlock = big.NewInt(f.from)
+	}
+	if f.to >= 0 {
+		query.ToBlock = big.NewInt(f.to)
+	}
+	if query.FromBlock == nil {
+		return nil, errors.New("a from block is required, set -from or from_block in the query file")
+	}
+	if query.ToBlock != nil && query.ToBlock.Cmp(query.FromBlock) <= 0 {
+		return nil, fmt.Errorf("invalid block range: to block %s must be after from block %s", query.ToBlock, query.FromBlock)
+	}
+	return query, nil
+}
+
+// splitList splits a comma-separated list, dropping empty entries.
+func splitList(list string) []string {
+	toReturn := make([]string, 0)
+	for _, entry := range strings.Split(list, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			toReturn = append(toReturn, entry)
+		}
+	}
+	return toReturn
+}
+
+func runQuery(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error {
+	fs := flag.NewFlagSet("query", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var node nodeFlags
+	var queryOpts queryFlags
+	node.register(fs)
+	queryOpts.register(fs)
+	format := fs.String("format", "json", "output format: json prints the response, ndjson one entity per line")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	query, err := queryOpts.query()
+	if err != nil {
+		return err
+	}
+
+	client, err := node.client(ctx)
+	if err != nil {
+		return err
+	}
+
+	response, err := client.Get(ctx, query)
+	if err != nil {
+		return errors.Wrap(err, "failed to run query")
+	}
+
+	switch *format {
+	case "json":
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(response)
+	case "ndjson":
+		return writeNDJSON(stdout, response)
+	default:
+		return fmt.Errorf("unsupported output format: %s", *format)
+	}
+}
+
+// writeNDJSON writes every entity of the response on its own line, keyed by its type:
+// {"block": {...}}, {"transaction": {...}}, {"log": {...}} or {"trace": {...}}.
+func writeNDJSON(w io.Writer, response *types.QueryResponse) error {
+	encoder := json.NewEncoder(w)
+	for i := range response.Data.Blocks {
+		if err := encoder.Encode(map[string]any{"block": &response.Data.Blocks[i]}); err != nil {
+			return err
+		}
+	}
+	for i := range response.Data.Transactions {
+		if err := encoder.Encode(map[string]any{"transaction": &response.Data.Transactions[i]}); err != nil {
+			return err
+		}
+	}
+	for i := range response.Data.Logs {
+		if err := encoder.Encode(map[string]any{"log": &response.Data.Logs[i]}); err != nil {
+			return err
+		}
+	}
+	for i := range response.Data.Traces {
+		if err := encoder.Encode(map[string]any{"trace": &response.Data.Traces[i]}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
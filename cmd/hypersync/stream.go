@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"runtime"
+	"time"
+
+	hypersyncgo "github.com/enviodev/hypersync-client-go"
+	"github.com/enviodev/hypersync-client-go/options"
+	"github.com/enviodev/hypersync-client-go/types"
+	"github.com/pkg/errors"
+)
+
+// streamFlags are the flags configuring a stream.
+type streamFlags struct {
+	concurrency int64
+	batchSize   int64
+	quiet       bool
+}
+
+func (f *streamFlags) register(fs *flag.FlagSet) {
+	fs.Int64Var(&f.concurrency, "concurrency", int64(runtime.NumCPU()), "number of block ranges fetched concurrently")
+	fs.Int64Var(&f.batchSize, "batch-size", 4096, "initial number of blocks per request")
+	fs.BoolVar(&f.quiet, "quiet", false, "do not report progress")
+}
+
+func (f *streamFlags) options() *options.StreamOptions {
+	return &options.StreamOptions{
+		Concurrency: big.NewInt(f.concurrency),
+		BatchSize:   big.NewInt(f.batchSize),
+	}
+}
+
+func runStream(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error {
+	fs := flag.NewFlagSet("stream", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var node nodeFlags
+	var queryOpts queryFlags
+	var streamOpts streamFlags
+	node.register(fs)
+	queryOpts.register(fs)
+	streamOpts.register(fs)
+	output := fs.String("output", "", "NDJSON output file, defaults to stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	query, err := queryOpts.query()
+	if err != nil {
+		return err
+	}
+
+	client, err := node.client(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := stdout
+	if *output != "" {
+		file, fErr := os.Create(*output)
+		if fErr != nil {
+			return errors.Wrap(fErr, "failed to create output file")
+		}
+		defer file.Close()
+		w = file
+	}
+
+	return streamRange(ctx, client, query, &streamOpts, stderr, func(response *types.QueryResponse) error {
+		return writeNDJSON(w, response)
+	})
+}
+
+// streamRange streams the query, resolving a missing to block to the archive height, and hands every
+// response to handle. Unless quiet is set, progress is reported on stderr.
+func streamRange(ctx context.Context, client *hypersyncgo.Client, query *types.Query, opts *streamFlags, stderr io.Writer, handle func(response *types.QueryResponse) error) error {
+	if query.ToBlock == nil {
+		height, err := client.GetHeight(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to get archive height")
+		}
+		query.ToBlock = new(big.Int).Add(height, big.NewInt(1))
+		if query.ToBlock.Cmp(query.FromBlock) <= 0 {
+			return fmt.Errorf("from block %s is above the archive height %s", query.FromBlock, height)
+		}
+	}
+
+	stream, err := client.Stream(ctx, query, opts.options())
+	if err != nil {
+		return errors.Wrap(err, "failed to start stream")
+	}
+
+	progress := newProgress(query.FromBlock.Uint64(), query.ToBlock.Uint64(), stderr, opts.quiet)
+	defer progress.finish()
+
+	for {
+		select {
+		case sErr := <-stream.Err():
+			return errors.Wrap(sErr, "failed to stream")
+		case response := <-stream.Channel():
+			if hErr := handle(response); hErr != nil {
+				return hErr
+			}
+			progress.update(response)
+			stream.Ack()
+		case <-stream.Done():
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// progress reports the streamed blocks and entities on stderr.
+type progress struct {
+	w        io.Writer
+	quiet    bool
+	from     uint64
+	total    uint64
+	blocks   uint64
+	entities int
+	started  time.Time
+}
+
+func newProgress(from uint64, to uint64, w io.Writer, quiet bool) *progress {
+	return &progress{w: w, quiet: quiet, from: from, total: to - from, started: time.Now()}
+}
+
+func (p *progress) update(response *types.QueryResponse) {
+	data := response.Data
+	p.entities += len(data.Blocks) + len(data.Transactions) + len(data.Logs) + len(data.Traces)
+	if response.NextBlock != nil && response.NextBlock.Uint64() > p.from {
+		p.blocks = max(p.blocks, min(response.NextBlock.Uint64()-p.from, p.total))
+	}
+	if !p.quiet {
+		fmt.Fprintf(p.w, "\rblocks %d/%d (%.1f%%), entities %d, elapsed %s", p.blocks, p.total,
+			100*float64(p.blocks)/float64(p.total), p.entities, time.Since(p.started).Round(time.Second))
+	}
+}
+
+func (p *progress) finish() {
+	if !p.quiet {
+		fmt.Fprintln(p.w)
+	}
+}
@@ -44,6 +44,46 @@ func NewContract(id uint64, addr common.Address, name string, standard utils.Sta
 	return contract, nil
 }
 
+// NewContractFromHumanABI creates a new Contract instance from human-readable ABI fragments instead of a JSON ABI.
+//
+// Example:
+//
+//	contract, err := contracts.NewContractFromHumanABI(1, addr, "MyToken", utils.Erc20,
+//	    "event Transfer(address indexed from, address indexed to, uint256 value)",
+//	    "function transfer(address to, uint256 amount) returns (bool)",
+//	)
+//	if err != nil {
+//	    log.Fatalf("Failed to create contract: %v", err)
+//	}
+func NewContractFromHumanABI(id uint64, addr common.Address, name string, standard utils.Standard, fragments ...string) (*Contract, error) {
+	rawAbi, err := utils.HumanABIToJSON(fragments...)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid human-readable contract ABI")
+	}
+
+	return NewContract(id, addr, name, standard, rawAbi)
+}
+
+// WithAddress returns a copy of the contract under a new ID and address, sharing the same name, standard and ABI.
+// This is useful to register many deployments of the same contract, such as token pairs created by a factory.
+//
+// Example:
+//
+//	pair := pairTemplate.WithAddress(2, common.HexToAddress("0x123..."))
+//	if err := manager.Register(networkID, pair); err != nil {
+//	    log.Fatalf("Failed to register pair: %v", err)
+//	}
+func (c *Contract) WithAddress(id uint64, addr common.Address) *Contract {
+	return &Contract{
+		id:       id,
+		addr:     addr,
+		name:     c.name,
+		standard: c.standard,
+		rawAbi:   c.rawAbi,
+		abi:      c.abi,
+	}
+}
+
 // Validate checks if the contract has a valid address, standard, and ABI, and parses the ABI.
 //
 // Example:
@@ -0,0 +1,199 @@
+package contracts
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/enviodev/hypersync-client-go/types"
+	"github.com/enviodev/hypersync-client-go/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// DefaultMinConfidence is the confidence a detection needs to be registered by RegisterDetected.
+const DefaultMinConfidence = 0.5
+
+// Detection describes a standard a contract likely implements.
+type Detection struct {
+	// Standard is the detected contract standard.
+	Standard utils.Standard
+	// Template is the zero-address standard contract used for the detection. Its ABI decodes the contract logs.
+	Template *Contract
+	// Confidence is the likelihood of the detection, between 0 and 1.
+	Confidence float64
+	// EventCoverage is the share of the standard events observed in the logs with the expected indexed arity.
+	EventCoverage float64
+	// SelectorCoverage is the share of the standard function selectors found in the deployed bytecode.
+	SelectorCoverage float64
+	// MatchedLogs is the number of logs explained by the standard.
+	MatchedLogs int
+	// MatchedEvents is the number of distinct standard events observed in the logs.
+	MatchedEvents int
+}
+
+// String returns a short human-readable description of the detection.
+func (d Detection) String() string {
+	return fmt.Sprintf("%s (confidence: %.2f)", d.Standard, d.Confidence)
+}
+
+// DetectStandards classifies a contract against every zero-address standard registered for the network,
+// including the network-agnostic standards registered under AnyNetwork.
+//
+// Logs emitted by the contract are matched on topic0 and indexed arity, so an ERC-20 Transfer (three topics)
+// is not mistaken for an ERC-721 Transfer (four topics). When deployed bytecode is provided, the function
+// selectors pushed by its dispatcher are matched against the standard functions. Either source may be empty.
+// The confidence of a detection averages the available evidence: event evidence scores 0.6 for a single
+// matching event plus up to 0.4 for full event coverage, and selector evidence scores the selector coverage.
+// Detections are sorted by decreasing confidence, then by the number of distinct events observed;
+// standards without any evidence are omitted.
+//
+// Example:
+//
+//	detections := manager.DetectStandards(networkID, response.Data.Logs, bytecode)
+//	for _, detection := range detections {
+//	    fmt.Println(detection)
+//	}
+func (m *Manager) DetectStandards(network utils.NetworkID, logs []types.Log, bytecode []byte) []Detection {
+	templates := m.standardTemplates(network)
+	selectors := BytecodeSelectors(bytecode)
+
+	detections := make([]Detection, 0)
+	for _, template := range templates {
+		detection := Detection{
+			Standard: template.standard,
+			Template: template,
+		}
+
+		observed := make(map[common.Hash]bool)
+		for _, log := range logs {
+			topics := log.Topics()
+			if len(topics) == 0 || !declaresEvent(template, topics) {
+				continue
+			}
+			observed[topics[0]] = true
+			detection.MatchedLogs++
+		}
+
+		detection.MatchedEvents = len(observed)
+		if len(template.abi.Events) > 0 {
+			detection.EventCoverage = float64(len(observed)) / float64(len(template.abi.Events))
+		}
+
+		if len(template.abi.Methods) > 0 && len(selectors) > 0 {
+			present := 0
+			for _, method := range template.abi.Methods {
+				if selectors[Selector(method.ID)] {
+					present++
+				}
+			}
+			detection.SelectorCoverage = float64(present) / float64(len(template.abi.Methods))
+		}
+
+		evidence := make([]float64, 0, 2)
+		if len(logs) > 0 {
+			eventScore := 0.0
+			if detection.MatchedLogs > 0 {
+				eventScore = 0.6 + 0.4*detection.EventCoverage
+			}
+			evidence = append(evidence, eventScore)
+		}
+		if len(selectors) > 0 {
+			evidence = append(evidence, detection.SelectorCoverage)
+		}
+
+		for _, score := range evidence {
+			detection.Confidence += score / float64(len(evidence))
+		}
+
+		if detection.Confidence > 0 {
+			detections = append(detections, detection)
+		}
+	}
+
+	sort.SliceStable(detections, func(i, j int) bool {
+		if detections[i].Confidence != detections[j].Confidence {
+			return detections[i].Confidence > detections[j].Confidence
+		}
+		// Prefer the standard explaining more distinct events, e.g. WETH over ERC-20 when Deposit is observed.
+		if detections[i].MatchedEvents != detections[j].MatchedEvents {
+			return detections[i].MatchedEvents > detections[j].MatchedEvents
+		}
+		return detections[i].Standard < detections[j].Standard
+	})
+
+	return detections
+}
+
+// RegisterDetected registers the contract at addr using the ABI of the most confident detection, provided
+// its confidence reaches minConfidence. If a contract is already registered at the address it is returned as is.
+//
+// Example:
+//
+//	contract, err := manager.RegisterDetected(networkID, addr, detections, contracts.DefaultMinConfidence)
+//	if err != nil {
+//	    log.Fatalf("Failed to register detected contract: %v", err)
+//	}
+func (m *Manager) RegisterDetected(network utils.NetworkID, addr common.Address, detections []Detection, minConfidence float64) (*Contract, error) {
+	if addr == (common.Address{}) {
+		return nil, errors.New("cannot register a detected contract at the zero address")
+	}
+
+	if existing, err := m.GetByAddr(network, addr); err == nil {
+		return existing, nil
+	}
+
+	if len(detections) == 0 || detections[0].Confidence < minConfidence {
+		return nil, errors.Errorf("no standard detected for address: %s with confidence of at least %.2f", addr.Hex(), minConfidence)
+	}
+
+	contract, err := m.RegisterNew(network, detections[0].Template, addr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to register detected contract: %s", addr.Hex())
+	}
+
+	return contract, nil
+}
+
+// standardTemplates returns the zero-address contracts of known standards registered for the network.
+func (m *Manager) standardTemplates(network utils.NetworkID) []*Contract {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	templates := make([]*Contract, 0)
+	for _, index := range m.lookupIndexes(network) {
+		for _, contract := range index.ordered {
+			if contract.addr == (common.Address{}) && contract.standard != utils.Custom {
+				templates = append(templates, contract)
+			}
+		}
+	}
+	return templates
+}
+
+// BytecodeSelectors returns the 4-byte values pushed with PUSH4 in the bytecode, which include the function
+// selectors of the contract dispatcher. Push data is skipped while walking the code, so bytes that merely
+// look like PUSH4 opcodes inside other push arguments are not reported.
+func BytecodeSelectors(bytecode []byte) map[Selector]bool {
+	const (
+		push1  = 0x60
+		push4  = 0x63
+		push32 = 0x7f
+	)
+
+	selectors := make(map[Selector]bool)
+	for pc := 0; pc < len(bytecode); pc++ {
+		op := bytecode[pc]
+		if op < push1 || op > push32 {
+			continue
+		}
+
+		size := int(op-push1) + 1
+		if op == push4 && pc+size < len(bytecode) {
+			var selector Selector
+			copy(selector[:], bytecode[pc+1:pc+1+size])
+			selectors[selector] = true
+		}
+		pc += size
+	}
+	return selectors
+}
@@ -0,0 +1,112 @@
+package contracts
+
+import (
+	"testing"
+
+	"github.com/enviodev/hypersync-client-go/types"
+	"github.com/enviodev/hypersync-client-go/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectStandards(t *testing.T) {
+	manager, err := NewManagerWithDefaults()
+	require.NoError(t, err)
+
+	network := utils.EthereumNetworkID
+	erc20, err := manager.GetByStandard(network, utils.Erc20)
+	require.NoError(t, err)
+
+	addr := common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7")
+	holder := common.BytesToHash(addr.Bytes())
+	transferTopic := erc20.ABI().Events["Transfer"].ID
+	approvalTopic := erc20.ABI().Events["Approval"].ID
+
+	erc20Logs := []types.Log{
+		newManagerTestLog(&addr, transferTopic, holder, holder),
+		newManagerTestLog(&addr, approvalTopic, holder, holder),
+	}
+	erc721Logs := []types.Log{
+		newManagerTestLog(&addr, transferTopic, holder, holder, common.BigToHash(common.Big1)),
+	}
+
+	// Dispatcher fragment pushing the ERC-20 selectors, with a PUSH32 hiding a fake PUSH4 in its data.
+	bytecode := []byte{0x7f, 0x63, 0x70, 0xa0, 0x82, 0x31}
+	bytecode = append(bytecode, make([]byte, 27)...)
+	for _, method := range erc20.ABI().Methods {
+		bytecode = append(bytecode, 0x63)
+		bytecode = append(bytecode, method.ID...)
+		bytecode = append(bytecode, 0x14)
+	}
+
+	t.Run("ERC-20 from logs", func(t *testing.T) {
+		detections := manager.DetectStandards(network, erc20Logs, nil)
+		require.NotEmpty(t, detections)
+		require.Equal(t, utils.Erc20, detections[0].Standard)
+		require.Equal(t, 1.0, detections[0].Confidence)
+		require.Equal(t, 2, detections[0].MatchedLogs)
+	})
+
+	t.Run("ERC-721 from Transfer arity", func(t *testing.T) {
+		detections := manager.DetectStandards(network, erc721Logs, nil)
+		require.NotEmpty(t, detections)
+		require.Equal(t, utils.Erc721, detections[0].Standard)
+		for _, detection := range detections {
+			require.NotEqual(t, utils.Erc20, detection.Standard)
+		}
+	})
+
+	t.Run("WETH from Deposit and Withdrawal", func(t *testing.T) {
+		weth, wErr := manager.GetByStandard(network, utils.Weth)
+		require.NoError(t, wErr)
+		wethLogs := append([]types.Log{
+			newManagerTestLog(&addr, weth.ABI().Events["Deposit"].ID, holder),
+			newManagerTestLog(&addr, weth.ABI().Events["Withdrawal"].ID, holder),
+		}, erc20Logs...)
+
+		detections := manager.DetectStandards(network, wethLogs, nil)
+		require.NotEmpty(t, detections)
+		require.Equal(t, utils.Weth, detections[0].Standard)
+	})
+
+	t.Run("ERC-20 from bytecode", func(t *testing.T) {
+		detections := manager.DetectStandards(network, nil, bytecode)
+		require.NotEmpty(t, detections)
+		require.Equal(t, utils.Erc20, detections[0].Standard)
+		require.Equal(t, 1.0, detections[0].SelectorCoverage)
+	})
+
+	t.Run("No evidence", func(t *testing.T) {
+		require.Empty(t, manager.DetectStandards(network, nil, nil))
+		require.Empty(t, NewManager().DetectStandards(network, erc20Logs, bytecode))
+
+		// Default standards are network-agnostic.
+		detections := manager.DetectStandards(utils.PolygonNetworkID, erc20Logs, nil)
+		require.NotEmpty(t, detections)
+		require.Equal(t, utils.Erc20, detections[0].Standard)
+	})
+
+	t.Run("Register detected", func(t *testing.T) {
+		detections := manager.DetectStandards(network, erc20Logs, bytecode)
+		contract, rErr := manager.RegisterDetected(network, addr, detections, DefaultMinConfidence)
+		require.NoError(t, rErr)
+		require.Equal(t, utils.Erc20, contract.Standard())
+
+		resolved, rErr := manager.Resolve(network, erc20Logs[0])
+		require.NoError(t, rErr)
+		require.Equal(t, contract.ID(), resolved.ID())
+
+		again, rErr := manager.RegisterDetected(network, addr, detections, DefaultMinConfidence)
+		require.NoError(t, rErr)
+		require.Equal(t, contract.ID(), again.ID())
+
+		other := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+		_, rErr = manager.RegisterDetected(network, other, nil, DefaultMinConfidence)
+		require.Error(t, rErr)
+	})
+}
+
+func TestBytecodeSelectors(t *testing.T) {
+	selectors := BytecodeSelectors([]byte{0x63, 0xa9, 0x05, 0x9c, 0xbb, 0x61, 0x63, 0x00, 0x63, 0x01})
+	require.Equal(t, map[Selector]bool{{0xa9, 0x05, 0x9c, 0xbb}: true}, selectors)
+}
@@ -0,0 +1,137 @@
+package contracts
+
+import (
+	"fmt"
+
+	"github.com/enviodev/hypersync-client-go/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// ExtractFn extracts the child contract addresses announced by a factory log.
+type ExtractFn func(log types.Log) ([]common.Address, error)
+
+// Factory describes a factory contract whose events announce new child contracts, such as
+// Uniswap V2 PairCreated or Uniswap V3 PoolCreated.
+type Factory struct {
+	// Addresses are the factory deployments to watch. When empty, the event is matched on any address.
+	Addresses []common.Address
+	// Event is the event announcing a new child contract.
+	Event abi.Event
+	// Extract returns the child addresses announced by a factory log.
+	Extract ExtractFn
+	// Child is the template registered for every discovered child. It may be nil to skip registration.
+	Child *Contract
+	// Children selects the child logs to stream. Its Address set is extended with every discovered child,
+	// while its Topics, when set, restrict which child events are returned.
+	Children types.LogSelection
+}
+
+// NewFactory creates a Factory for the named event of the factory contract ABI, extracting child addresses
+// from the named event argument, which may be indexed or not and must be of type address.
+//
+// Example:
+//
+//	v2Factory, _ := manager.GetByStandard(networkID, utils.UniswapV2Factory)
+//	v2Pair, _ := manager.GetByStandard(networkID, utils.UniswapV2Pair)
+//	factory, err := contracts.NewFactory(v2Factory, "PairCreated", "pair", v2Pair,
+//	    common.HexToAddress("0x5C69bEe701ef814a2B6a3EDD4B1652CB9cc5aA6f"),
+//	)
+//	if err != nil {
+//	    log.Fatalf("Failed to create factory: %v", err)
+//	}
+func NewFactory(factory *Contract, eventName string, argument string, child *Contract, addresses ...common.Address) (*Factory, error) {
+	if factory == nil || factory.abi == nil {
+		return nil, errors.New("factory contract abi is nil")
+	}
+
+	event, ok := factory.abi.Events[eventName]
+	if !ok {
+		return nil, fmt.Errorf("event %q not found in factory abi", eventName)
+	}
+
+	extract, err := AddressArgumentExtractor(event, argument)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Factory{
+		Addresses: addresses,
+		Event:     event,
+		Extract:   extract,
+		Child:     child,
+	}, nil
+}
+
+// AddressArgumentExtractor returns an ExtractFn reading the named address argument of the event.
+func AddressArgumentExtractor(event abi.Event, argument string) (ExtractFn, error) {
+	topicIndex := 0
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			topicIndex++
+		}
+		if input.Name != argument {
+			continue
+		}
+
+		if input.Type.T != abi.AddressTy {
+			return nil, fmt.Errorf("argument %q of event %s is of type %s, expected address", argument, event.Sig, input.Type)
+		}
+
+		if input.Indexed {
+			position := topicIndex
+			return func(log types.Log) ([]common.Address, error) {
+				topics := log.Topics()
+				if len(topics) <= position {
+					return nil, fmt.Errorf("event %s expects topic %d, log has %d topics", event.Sig, position, len(topics))
+				}
+				return []common.Address{common.BytesToAddress(topics[position].Bytes())}, nil
+			}, nil
+		}
+
+		return func(log types.Log) ([]common.Address, error) {
+			values := make(map[string]any)
+			if err := event.Inputs.NonIndexed().UnpackIntoMap(values, log.GetData()); err != nil {
+				return nil, errors.Wrapf(err, "failed to unpack event %s", event.Sig)
+			}
+			addr, ok := values[argument].(common.Address)
+			if !ok {
+				return nil, fmt.Errorf("argument %q of event %s is not an address", argument, event.Sig)
+			}
+			return []common.Address{addr}, nil
+		}, nil
+	}
+
+	return nil, fmt.Errorf("event %s has no argument %q", event.Sig, argument)
+}
+
+// Matches reports whether the log was emitted by the factory event.
+func (f *Factory) Matches(log types.Log) bool {
+	if log.Topic0 == nil || *log.Topic0 != f.Event.ID {
+		return false
+	}
+
+	if len(f.Addresses) == 0 {
+		return true
+	}
+
+	if log.Address == nil {
+		return false
+	}
+
+	for _, addr := range f.Addresses {
+		if addr == *log.Address {
+			return true
+		}
+	}
+	return false
+}
+
+// LogSelection returns the selection matching the factory event.
+func (f *Factory) LogSelection() types.LogSelection {
+	return types.LogSelection{
+		Address: f.Addresses,
+		Topics:  [][]common.Hash{{f.Event.ID}},
+	}
+}
@@ -0,0 +1,39 @@
+package contracts
+
+import (
+	"testing"
+
+	"github.com/enviodev/hypersync-client-go/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFactory(t *testing.T) {
+	manager, err := NewManagerWithDefaults()
+	require.NoError(t, err)
+
+	v3Factory, err := manager.GetByStandard(AnyNetwork, utils.UniswapV3Factory)
+	require.NoError(t, err)
+
+	factoryAddr := common.HexToAddress("0x1F98431c8aD98523631AE4a59f267346ea31F984")
+	owner := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+	_, err = NewFactory(v3Factory, "PoolCreated", "fee", nil)
+	require.ErrorContains(t, err, "expected address")
+	_, err = NewFactory(v3Factory, "PoolCreated", "unknown", nil)
+	require.ErrorContains(t, err, "has no argument")
+
+	// Indexed address argument.
+	factory, err := NewFactory(v3Factory, "OwnerChanged", "newOwner", nil, factoryAddr)
+	require.NoError(t, err)
+
+	log := newManagerTestLog(&factoryAddr, v3Factory.ABI().Events["OwnerChanged"].ID, common.Hash{}, common.BytesToHash(owner.Bytes()))
+	require.True(t, factory.Matches(log))
+	addresses, err := factory.Extract(log)
+	require.NoError(t, err)
+	require.Equal(t, []common.Address{owner}, addresses)
+
+	other := common.HexToAddress("0x00000000000000000000000000000000000000bb")
+	require.False(t, factory.Matches(newManagerTestLog(&other, v3Factory.ABI().Events["OwnerChanged"].ID)))
+	require.Equal(t, [][]common.Hash{{v3Factory.ABI().Events["OwnerChanged"].ID}}, factory.LogSelection().Topics)
+}
@@ -0,0 +1,318 @@
+package contracts
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/enviodev/hypersync-client-go/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+// LogSelection builds a types.LogSelection matching the named event emitted by the contract.
+// The selection is restricted to the contract address unless the contract is a network-agnostic standard
+// registered at the zero address. See NewLogSelection for the accepted argument values.
+//
+// Example:
+//
+//	selection, err := contract.LogSelection("Transfer", map[string]any{
+//	    "to": common.HexToAddress("0x123..."),
+//	})
+//	if err != nil {
+//	    log.Fatalf("Failed to build log selection: %v", err)
+//	}
+func (c *Contract) LogSelection(eventName string, args map[string]any) (*types.LogSelection, error) {
+	contractAbi, err := c.ToABI()
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make([]common.Address, 0)
+	if c.addr != (common.Address{}) {
+		addresses = append(addresses, c.addr)
+	}
+
+	return NewLogSelection(addresses, &contractAbi, eventName, args)
+}
+
+// NewLogSelection builds a types.LogSelection for the named event of the provided ABI, filtering on
+// the given addresses and indexed argument values.
+//
+// Arguments are keyed by their ABI name and must reference indexed event inputs. Each value may be a single
+// value or a slice of values, in which case the topic matches any of them. Missing and nil arguments, including
+// typed nil pointers, match any value, while nil elements of a slice are rejected. An empty slice is rejected
+// too, as it would match no value and a topic can only be left out to match any value.
+// Accepted values per argument type are:
+//   - address: common.Address, *common.Address or a hex string
+//   - intN/uintN: *big.Int, big.Int, any Go integer, or a decimal/0x-prefixed hex string
+//   - bool: bool or "true"/"false"
+//   - string: string, hashed as Solidity does for indexed dynamic values
+//   - bytes: []byte or a 0x-prefixed hex string, hashed as Solidity does for indexed dynamic values
+//   - bytesN: [N]byte, []byte or a 0x-prefixed hex string
+//
+// A common.Hash value is always used verbatim as the topic.
+//
+// Example:
+//
+//	selection, err := contracts.NewLogSelection(nil, contractAbi, "Transfer", map[string]any{
+//	    "from": []common.Address{alice, bob},
+//	})
+//	if err != nil {
+//	    log.Fatalf("Failed to build log selection: %v", err)
+//	}
+func NewLogSelection(addresses []common.Address, contractAbi *abi.ABI, eventName string, args map[string]any) (*types.LogSelection, error) {
+	if contractAbi == nil {
+		return nil, errors.New("abi is nil")
+	}
+
+	event, ok := contractAbi.Events[eventName]
+	if !ok {
+		return nil, fmt.Errorf("event %q not found in abi", eventName)
+	}
+
+	indexed := make([]abi.Argument, 0)
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+
+	for name := range args {
+		if !hasArgument(indexed, name) {
+			return nil, fmt.Errorf(
+				"event %s has no indexed argument %q (indexed arguments: %s)", event.Sig, name, argumentNames(indexed),
+			)
+		}
+	}
+
+	topics := make([][]common.Hash, 0, len(indexed)+1)
+	if !event.Anonymous {
+		topics = append(topics, []common.Hash{event.ID})
+	}
+
+	for _, input := range indexed {
+		value, ok := args[input.Name]
+		if !ok || isNil(value) {
+			topics = append(topics, []common.Hash{})
+			continue
+		}
+
+		values := topicValues(value)
+		if len(values) == 0 {
+			return nil, fmt.Errorf("empty value list for argument %q of event %s, leave the argument out to match any value", input.Name, event.Sig)
+		}
+
+		encoded := make([]common.Hash, 0, len(values))
+		for _, v := range values {
+			topic, err := encodeTopic(input, v)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid value for argument %q of event %s", input.Name, event.Sig)
+			}
+			encoded = append(encoded, topic)
+		}
+		topics = append(topics, encoded)
+	}
+
+	// Trailing wildcard positions carry no filtering information.
+	for len(topics) > 0 && len(topics[len(topics)-1]) == 0 {
+		topics = topics[:len(topics)-1]
+	}
+
+	return &types.LogSelection{
+		Address: addresses,
+		Topics:  topics,
+	}, nil
+}
+
+// topicValues expands slices into their elements so that they form an OR-set, keeping byte slices intact.
+func topicValues(value any) []any {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice || v.Type().Elem().Kind() == reflect.Uint8 {
+		return []any{value}
+	}
+
+	toReturn := make([]any, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		toReturn[i] = v.Index(i).Interface()
+	}
+	return toReturn
+}
+
+// isNil reports whether the value is nil or a nil pointer, slice or map.
+func isNil(value any) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
+
+// encodeTopic encodes a single indexed argument value into its 32-byte topic representation.
+func encodeTopic(input abi.Argument, value any) (common.Hash, error) {
+	if isNil(value) {
+		return common.Hash{}, fmt.Errorf("nil value for %s, leave the argument out to match any value", input.Type)
+	}
+	if hash, ok := value.(common.Hash); ok {
+		return hash, nil
+	}
+
+	switch input.Type.T {
+	case abi.AddressTy:
+		switch v := value.(type) {
+		case common.Address:
+			return common.BytesToHash(v.Bytes()), nil
+		case *common.Address:
+			return common.BytesToHash(v.Bytes()), nil
+		case string:
+			if !common.IsHexAddress(v) {
+				return common.Hash{}, fmt.Errorf("invalid address: %q", v)
+			}
+			return common.BytesToHash(common.HexToAddress(v).Bytes()), nil
+		}
+	case abi.BoolTy:
+		switch v := value.(type) {
+		case bool:
+			if v {
+				return common.BigToHash(big.NewInt(1)), nil
+			}
+			return common.Hash{}, nil
+		case string:
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return common.Hash{}, fmt.Errorf("invalid bool: %q", v)
+			}
+			return encodeTopic(input, b)
+		}
+	case abi.IntTy, abi.UintTy:
+		integer, err := toBigInt(value)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		if err := checkIntegerRange(integer, input.Type); err != nil {
+			return common.Hash{}, err
+		}
+		return common.BytesToHash(math.U256Bytes(new(big.Int).Set(integer))), nil
+	case abi.StringTy:
+		if v, ok := value.(string); ok {
+			return crypto.Keccak256Hash([]byte(v)), nil
+		}
+	case abi.BytesTy:
+		raw, err := toBytes(value)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		return crypto.Keccak256Hash(raw), nil
+	case abi.FixedBytesTy:
+		raw, err := toBytes(value)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		if len(raw) > input.Type.Size {
+			return common.Hash{}, fmt.Errorf("value of %d bytes exceeds %s", len(raw), input.Type)
+		}
+		var topic common.Hash
+		copy(topic[:], raw)
+		return topic, nil
+	default:
+		return common.Hash{}, fmt.Errorf("unsupported indexed type %s, provide the topic as a common.Hash", input.Type)
+	}
+
+	return common.Hash{}, fmt.Errorf("unsupported value of type %T for %s", value, input.Type)
+}
+
+// toBigInt converts supported integer representations into a big.Int.
+func toBigInt(value any) (*big.Int, error) {
+	switch v := value.(type) {
+	case *big.Int:
+		if v == nil {
+			return nil, errors.New("nil integer")
+		}
+		return v, nil
+	case big.Int:
+		return &v, nil
+	case string:
+		integer, ok := new(big.Int).SetString(v, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer: %q", v)
+		}
+		return integer, nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Int).SetUint64(rv.Uint()), nil
+	}
+
+	return nil, fmt.Errorf("unsupported integer value of type %T", value)
+}
+
+// checkIntegerRange validates that the integer fits into the ABI integer type.
+func checkIntegerRange(integer *big.Int, typ abi.Type) error {
+	if typ.T == abi.UintTy {
+		if integer.Sign() < 0 || integer.BitLen() > typ.Size {
+			return fmt.Errorf("value %s out of range for %s", integer, typ)
+		}
+		return nil
+	}
+
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(typ.Size-1))
+	if integer.Cmp(limit) >= 0 || integer.Cmp(new(big.Int).Neg(limit)) < 0 {
+		return fmt.Errorf("value %s out of range for %s", integer, typ)
+	}
+	return nil
+}
+
+// toBytes converts supported byte representations into a byte slice.
+func toBytes(value any) ([]byte, error) {
+	if v, ok := value.(string); ok {
+		raw, err := hexutil.Decode(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex bytes %q: %w", v, err)
+		}
+		return raw, nil
+	}
+
+	rv := reflect.ValueOf(value)
+	if (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array) && rv.Type().Elem().Kind() == reflect.Uint8 {
+		raw := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(raw), rv)
+		return raw, nil
+	}
+
+	return nil, fmt.Errorf("unsupported bytes value of type %T", value)
+}
+
+// hasArgument reports whether an argument with the given name exists in the list.
+func hasArgument(arguments []abi.Argument, name string) bool {
+	for _, argument := range arguments {
+		if argument.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// argumentNames returns the sorted, comma separated names of the arguments for error reporting.
+func argumentNames(arguments []abi.Argument) string {
+	names := make([]string, 0, len(arguments))
+	for _, argument := range arguments {
+		names = append(names, argument.Name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,144 @@
+package contracts
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/enviodev/hypersync-client-go/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogSelection(t *testing.T) {
+	contractAddr := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	contract, err := NewContractFromHumanABI(1, contractAddr, "Token", utils.Erc20,
+		"event Transfer(address indexed from, address indexed to, uint256 value)",
+		"event Named(string indexed name, bytes indexed blob, bytes4 indexed tag)",
+		"event Signed(int24 indexed tick, bool indexed active)",
+	)
+	require.NoError(t, err)
+
+	transferTopic := contract.ABI().Events["Transfer"].ID
+	alice := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	bob := common.HexToAddress("0x00000000000000000000000000000000000000bb")
+
+	tests := []struct {
+		name      string
+		eventName string
+		args      map[string]any
+		expected  [][]common.Hash
+		expectErr string
+	}{
+		{
+			name:      "Event without arguments",
+			eventName: "Transfer",
+			expected:  [][]common.Hash{{transferTopic}},
+		},
+		{
+			name:      "Recipient filter with wildcard sender",
+			eventName: "Transfer",
+			args:      map[string]any{"to": alice},
+			expected: [][]common.Hash{
+				{transferTopic},
+				{},
+				{common.BytesToHash(alice.Bytes())},
+			},
+		},
+		{
+			name:      "Sender OR-set from hex strings",
+			eventName: "Transfer",
+			args:      map[string]any{"from": []string{alice.Hex(), bob.Hex()}},
+			expected: [][]common.Hash{
+				{transferTopic},
+				{common.BytesToHash(alice.Bytes()), common.BytesToHash(bob.Bytes())},
+			},
+		},
+		{
+			name:      "Hashed dynamic and fixed bytes values",
+			eventName: "Named",
+			args:      map[string]any{"name": "envio", "blob": []byte{0x01, 0x02}, "tag": "0xa9059cbb"},
+			expected: [][]common.Hash{
+				{contract.ABI().Events["Named"].ID},
+				{crypto.Keccak256Hash([]byte("envio"))},
+				{crypto.Keccak256Hash([]byte{0x01, 0x02})},
+				{common.HexToHash("0xa9059cbb00000000000000000000000000000000000000000000000000000000")},
+			},
+		},
+		{
+			name:      "Negative signed integer and bool",
+			eventName: "Signed",
+			args:      map[string]any{"tick": -1, "active": true},
+			expected: [][]common.Hash{
+				{contract.ABI().Events["Signed"].ID},
+				{common.HexToHash("0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")},
+				{common.BigToHash(big.NewInt(1))},
+			},
+		},
+		{
+			name:      "Typed nil pointers as wildcards",
+			eventName: "Transfer",
+			args:      map[string]any{"from": (*common.Address)(nil), "to": &alice},
+			expected: [][]common.Hash{
+				{transferTopic},
+				{},
+				{common.BytesToHash(alice.Bytes())},
+			},
+		},
+		{
+			name:      "Nil integer pointer as wildcard",
+			eventName: "Signed",
+			args:      map[string]any{"tick": (*big.Int)(nil)},
+			expected:  [][]common.Hash{{contract.ABI().Events["Signed"].ID}},
+		},
+		{
+			name:      "Nil element of an OR-set",
+			eventName: "Transfer",
+			args:      map[string]any{"from": []*common.Address{&alice, nil}},
+			expectErr: "nil value for address",
+		},
+		{
+			name:      "Empty OR-set",
+			eventName: "Transfer",
+			args:      map[string]any{"from": []common.Address{}},
+			expectErr: "empty value list",
+		},
+		{
+			name:      "Non-indexed argument",
+			eventName: "Transfer",
+			args:      map[string]any{"value": 1},
+			expectErr: "no indexed argument",
+		},
+		{
+			name:      "Invalid address",
+			eventName: "Transfer",
+			args:      map[string]any{"from": "not-an-address"},
+			expectErr: "invalid address",
+		},
+		{
+			name:      "Out of range integer",
+			eventName: "Signed",
+			args:      map[string]any{"tick": 1 << 23},
+			expectErr: "out of range",
+		},
+		{
+			name:      "Unknown event",
+			eventName: "Unknown",
+			expectErr: "not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selection, sErr := contract.LogSelection(tt.eventName, tt.args)
+			if tt.expectErr != "" {
+				require.ErrorContains(t, sErr, tt.expectErr)
+				return
+			}
+
+			require.NoError(t, sErr)
+			require.Equal(t, []common.Address{contractAddr}, selection.Address)
+			require.Equal(t, tt.expected, selection.Topics)
+		})
+	}
+}
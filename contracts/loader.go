@@ -0,0 +1,408 @@
+package contracts
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/enviodev/hypersync-client-go/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/goccy/go-json"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Artifact represents a compiled contract ABI read from disk, either a plain ABI file or a
+// Hardhat or Foundry build artifact.
+type Artifact struct {
+	Name             string // Contract name, taken from the artifact or derived from the file name.
+	Path             string // Path of the file the artifact was read from.
+	RawABI           string // Raw JSON ABI.
+	Bytecode         []byte // Creation bytecode, empty for plain ABI files.
+	DeployedBytecode []byte // Runtime bytecode, empty for plain ABI files.
+}
+
+// LoadError describes a file or manifest entry that failed to load.
+type LoadError struct {
+	Path string
+	Err  error
+}
+
+// Error implements the error interface.
+func (e *LoadError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Path, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *LoadError) Unwrap() error {
+	return e.Err
+}
+
+// LoadErrors collects the per-file errors of a loader. Loaders register every valid file
+// and return the failures as LoadErrors, so a single broken artifact does not abort the load.
+type LoadErrors []*LoadError
+
+// Error implements the error interface.
+func (e LoadErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("failed to load %d file(s): %s", len(e), strings.Join(messages, "; "))
+}
+
+// orNil returns nil when no errors were collected, avoiding a non-nil interface holding an empty slice.
+func (e LoadErrors) orNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// artifactFile mirrors the fields shared by Hardhat and Foundry artifacts.
+type artifactFile struct {
+	ContractName     string          `json:"contractName"`
+	ABI              json.RawMessage `json:"abi"`
+	Bytecode         json.RawMessage `json:"bytecode"`
+	DeployedBytecode json.RawMessage `json:"deployedBytecode"`
+}
+
+// ReadArtifact reads a contract ABI from a file. Plain ABI files (a JSON array), Hardhat artifacts
+// and Foundry artifacts are detected automatically.
+//
+// Example:
+//
+//	artifact, err := contracts.ReadArtifact("artifacts/contracts/Token.sol/Token.json")
+//	if err != nil {
+//	    log.Fatalf("Failed to read artifact: %v", err)
+//	}
+func ReadArtifact(path string) (*Artifact, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseArtifact(path, data)
+}
+
+// parseArtifact parses the contents of an ABI or artifact file.
+func parseArtifact(path string, data []byte) (*Artifact, error) {
+	artifact := &Artifact{
+		Name: artifactName(path),
+		Path: path,
+	}
+
+	trimmed := strings.TrimSpace(string(data))
+	if strings.HasPrefix(trimmed, "[") {
+		artifact.RawABI = trimmed
+		return artifact, nil
+	}
+
+	var file artifactFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, errors.Wrap(err, "invalid artifact json")
+	}
+
+	if len(file.ABI) == 0 || string(file.ABI) == "null" {
+		return nil, errors.New("artifact has no abi")
+	}
+
+	if file.ContractName != "" {
+		artifact.Name = file.ContractName
+	}
+	artifact.RawABI = string(file.ABI)
+
+	var err error
+	if artifact.Bytecode, err = decodeArtifactBytecode(file.Bytecode); err != nil {
+		return nil, errors.Wrap(err, "invalid bytecode")
+	}
+	if artifact.DeployedBytecode, err = decodeArtifactBytecode(file.DeployedBytecode); err != nil {
+		return nil, errors.Wrap(err, "invalid deployed bytecode")
+	}
+
+	return artifact, nil
+}
+
+// decodeArtifactBytecode decodes bytecode stored either as a hex string (Hardhat) or as an
+// object with an "object" hex field (Foundry). Unlinked bytecode containing library placeholders is ignored.
+func decodeArtifactBytecode(raw json.RawMessage) ([]byte, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+
+	var code string
+	if err := json.Unmarshal(raw, &code); err != nil {
+		var object struct {
+			Object string `json:"object"`
+		}
+		if err := json.Unmarshal(raw, &object); err != nil {
+			return nil, err
+		}
+		code = object.Object
+	}
+
+	if code == "" || code == "0x" || strings.Contains(code, "__") {
+		return nil, nil
+	}
+	if !strings.HasPrefix(code, "0x") {
+		code = "0x" + code
+	}
+	return hexutil.Decode(code)
+}
+
+// isEmptyABI reports whether the raw ABI is a valid but empty JSON array.
+func isEmptyABI(rawAbi string) bool {
+	var entries []json.RawMessage
+	return json.Unmarshal([]byte(rawAbi), &entries) == nil && len(entries) == 0
+}
+
+// artifactName derives a contract name from the file name, stripping ".abi.json" or ".json".
+func artifactName(path string) string {
+	name := filepath.Base(path)
+	for _, suffix := range []string{".abi.json", ".json", ".abi"} {
+		if strings.HasSuffix(name, suffix) {
+			return strings.TrimSuffix(name, suffix)
+		}
+	}
+	return name
+}
+
+// LoadABIDir registers every "*.abi.json" file found under dir, recursively, as a contract of the given network.
+// Contracts are registered at the zero address under the Custom standard, so they are resolved by topic0 and selector.
+// Files that fail to parse or register are reported in the returned LoadErrors while the remaining files are loaded.
+//
+// Example:
+//
+//	loaded, err := manager.LoadABIDir(utils.EthereumNetworkID, "./abis")
+//	if err != nil {
+//	    log.Printf("Some ABIs failed to load: %v", err)
+//	}
+func (m *Manager) LoadABIDir(network utils.NetworkID, dir string) ([]*Contract, error) {
+	return m.loadDir(network, dir, func(path string) bool {
+		return strings.HasSuffix(path, ".abi.json")
+	}, false)
+}
+
+// LoadHardhatArtifacts registers every contract artifact found under a Hardhat "artifacts" directory.
+// Debug files ("*.dbg.json") and the "build-info" directory are skipped, as are artifacts with an empty ABI.
+// Contracts are registered at the zero address under the Custom standard.
+//
+// Example:
+//
+//	loaded, err := manager.LoadHardhatArtifacts(utils.EthereumNetworkID, "./artifacts")
+//	if err != nil {
+//	    log.Printf("Some artifacts failed to load: %v", err)
+//	}
+func (m *Manager) LoadHardhatArtifacts(network utils.NetworkID, dir string) ([]*Contract, error) {
+	return m.loadDir(network, dir, func(path string) bool {
+		return strings.HasSuffix(path, ".json") && !strings.HasSuffix(path, ".dbg.json")
+	}, true)
+}
+
+// LoadFoundryArtifacts registers every contract artifact found under a Foundry "out" directory.
+// The "build-info" directory is skipped, as are artifacts with an empty ABI.
+// Contracts are registered at the zero address under the Custom standard.
+//
+// Example:
+//
+//	loaded, err := manager.LoadFoundryArtifacts(utils.EthereumNetworkID, "./out")
+//	if err != nil {
+//	    log.Printf("Some artifacts failed to load: %v", err)
+//	}
+func (m *Manager) LoadFoundryArtifacts(network utils.NetworkID, dir string) ([]*Contract, error) {
+	return m.loadDir(network, dir, func(path string) bool {
+		return strings.HasSuffix(path, ".json")
+	}, true)
+}
+
+// loadDir walks dir and registers every matching file. When skipEmpty is set, artifacts without any
+// ABI entries (libraries, scripts) are skipped instead of being reported as errors.
+func (m *Manager) loadDir(network utils.NetworkID, dir string, match func(path string) bool, skipEmpty bool) ([]*Contract, error) {
+	loaded := make([]*Contract, 0)
+	var loadErrs LoadErrors
+
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			loadErrs = append(loadErrs, &LoadError{Path: path, Err: err})
+			return nil
+		}
+
+		if entry.IsDir() {
+			if entry.Name() == "build-info" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !match(path) {
+			return nil
+		}
+
+		artifact, aErr := ReadArtifact(path)
+		if aErr != nil {
+			loadErrs = append(loadErrs, &LoadError{Path: path, Err: aErr})
+			return nil
+		}
+
+		if isEmptyABI(artifact.RawABI) {
+			if !skipEmpty {
+				loadErrs = append(loadErrs, &LoadError{Path: path, Err: errors.New("abi is empty")})
+			}
+			return nil
+		}
+
+		contract, cErr := m.registerArtifact(network, artifact, common.Address{}, 0, artifact.Name, utils.Custom)
+		if cErr != nil {
+			loadErrs = append(loadErrs, &LoadError{Path: path, Err: cErr})
+			return nil
+		}
+
+		loaded = append(loaded, contract)
+		return nil
+	})
+	if err != nil {
+		return loaded, errors.Wrapf(err, "failed to scan directory: %s", dir)
+	}
+
+	return loaded, loadErrs.orNil()
+}
+
+// registerArtifact creates a contract from the artifact and registers it, assigning the next free ID when id is zero.
+func (m *Manager) registerArtifact(network utils.NetworkID, artifact *Artifact, addr common.Address, id uint64, name string, standard utils.Standard) (*Contract, error) {
+	if id == 0 {
+		template := &Contract{name: name, standard: standard, rawAbi: artifact.RawABI}
+		return m.RegisterNew(network, template, addr)
+	}
+
+	contract, err := NewContract(id, addr, name, standard, artifact.RawABI)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.Register(network, contract); err != nil {
+		return nil, err
+	}
+
+	return contract, nil
+}
+
+// Manifest describes a set of deployed contracts and the ABI files used to decode them.
+//
+// Example (YAML):
+//
+//	contracts:
+//	  - network: 1
+//	    address: "0xdAC17F958D2ee523a2206206994597C13D831ec7"
+//	    name: Tether USD
+//	    standard: erc20
+//	    abi: abis/ERC20.abi.json
+//	  - network: 1
+//	    name: Vault
+//	    abi: out/Vault.sol/Vault.json
+type Manifest struct {
+	Contracts []ManifestContract `json:"contracts" yaml:"contracts"`
+}
+
+// ManifestContract is a single contract entry of a Manifest.
+type ManifestContract struct {
+	Network  utils.NetworkID `json:"network" yaml:"network"`                       // Network ID the contract is deployed on.
+	ID       uint64          `json:"id,omitempty" yaml:"id,omitempty"`             // Optional contract ID, the next free ID when zero.
+	Address  string          `json:"address,omitempty" yaml:"address,omitempty"`   // Contract address, the zero address for standard ABIs when empty.
+	Name     string          `json:"name,omitempty" yaml:"name,omitempty"`         // Optional name, the artifact name when empty.
+	Standard string          `json:"standard,omitempty" yaml:"standard,omitempty"` // Standard name such as "erc20", "custom" when empty.
+	ABI      string          `json:"abi" yaml:"abi"`                               // ABI or artifact file, relative to the manifest.
+}
+
+// LoadManifest reads a YAML or JSON manifest, chosen by file extension, and registers every contract it lists.
+// ABI paths are resolved relative to the manifest directory. Entries that fail validation are reported in the
+// returned LoadErrors, keyed by the manifest path and entry index, while the remaining entries are loaded.
+//
+// Example:
+//
+//	loaded, err := manager.LoadManifest("contracts.yaml")
+//	if err != nil {
+//	    log.Printf("Some contracts failed to load: %v", err)
+//	}
+func (m *Manager) LoadManifest(path string) ([]*Contract, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read manifest")
+	}
+
+	var manifest Manifest
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &manifest)
+	case ".json":
+		err = json.Unmarshal(data, &manifest)
+	default:
+		return nil, fmt.Errorf("unsupported manifest format: %s", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse manifest: %s", path)
+	}
+
+	baseDir := filepath.Dir(path)
+	loaded := make([]*Contract, 0, len(manifest.Contracts))
+	var loadErrs LoadErrors
+
+	for i, entry := range manifest.Contracts {
+		contract, eErr := m.loadManifestContract(baseDir, entry)
+		if eErr != nil {
+			loadErrs = append(loadErrs, &LoadError{
+				Path: fmt.Sprintf("%s: contracts[%d]", path, i),
+				Err:  eErr,
+			})
+			continue
+		}
+		loaded = append(loaded, contract)
+	}
+
+	return loaded, loadErrs.orNil()
+}
+
+// loadManifestContract validates and registers a single manifest entry.
+func (m *Manager) loadManifestContract(baseDir string, entry ManifestContract) (*Contract, error) {
+	if !entry.Network.IsValid() {
+		return nil, errors.New("network is not set")
+	}
+
+	if entry.ABI == "" {
+		return nil, errors.New("abi is not set")
+	}
+
+	addr := common.Address{}
+	if entry.Address != "" {
+		if !common.IsHexAddress(entry.Address) {
+			return nil, fmt.Errorf("invalid address: %q", entry.Address)
+		}
+		addr = common.HexToAddress(entry.Address)
+	}
+
+	standard := utils.Custom
+	if entry.Standard != "" {
+		var err error
+		if standard, err = utils.ParseStandard(entry.Standard); err != nil {
+			return nil, err
+		}
+	}
+
+	abiPath := entry.ABI
+	if !filepath.IsAbs(abiPath) {
+		abiPath = filepath.Join(baseDir, abiPath)
+	}
+
+	artifact, err := ReadArtifact(abiPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read abi: %s", abiPath)
+	}
+
+	name := entry.Name
+	if name == "" {
+		name = artifact.Name
+	}
+
+	return m.registerArtifact(entry.Network, artifact, addr, entry.ID, name, standard)
+}
@@ -0,0 +1,141 @@
+package contracts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/enviodev/hypersync-client-go/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+const loaderTestABI = `[{"anonymous":false,"inputs":[{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"value","type":"uint256"}],"name":"Transfer","type":"event"}]`
+
+func writeLoaderTestFile(t *testing.T, path string, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestLoadABIDir(t *testing.T) {
+	dir := t.TempDir()
+	writeLoaderTestFile(t, filepath.Join(dir, "Token.abi.json"), loaderTestABI)
+	writeLoaderTestFile(t, filepath.Join(dir, "nested", "Vault.abi.json"), loaderTestABI)
+	writeLoaderTestFile(t, filepath.Join(dir, "Broken.abi.json"), `[{"type":`)
+	writeLoaderTestFile(t, filepath.Join(dir, "README.md"), "ignored")
+
+	manager := NewManager()
+	loaded, err := manager.LoadABIDir(utils.EthereumNetworkID, dir)
+	require.Len(t, loaded, 2)
+
+	var loadErrs LoadErrors
+	require.ErrorAs(t, err, &loadErrs)
+	require.Len(t, loadErrs, 1)
+	require.Equal(t, filepath.Join(dir, "Broken.abi.json"), loadErrs[0].Path)
+
+	names := []string{loaded[0].Name(), loaded[1].Name()}
+	require.ElementsMatch(t, []string{"Token", "Vault"}, names)
+	require.Equal(t, utils.Custom, loaded[0].Standard())
+	require.NotEqual(t, loaded[0].ID(), loaded[1].ID())
+}
+
+func TestLoadBuildArtifacts(t *testing.T) {
+	t.Run("Hardhat", func(t *testing.T) {
+		dir := t.TempDir()
+		writeLoaderTestFile(t, filepath.Join(dir, "contracts", "Token.sol", "Token.json"),
+			`{"_format":"hh-sol-artifact-1","contractName":"Token","sourceName":"contracts/Token.sol","abi":`+loaderTestABI+`,"bytecode":"0x6001","deployedBytecode":"0x6002"}`)
+		writeLoaderTestFile(t, filepath.Join(dir, "contracts", "Token.sol", "Token.dbg.json"), `{"_format":"hh-sol-dbg-1","buildInfo":"x"}`)
+		writeLoaderTestFile(t, filepath.Join(dir, "contracts", "Lib.sol", "Lib.json"), `{"contractName":"Lib","abi":[],"bytecode":"0x"}`)
+		writeLoaderTestFile(t, filepath.Join(dir, "build-info", "abc.json"), `{"id":"abc"}`)
+
+		loaded, err := NewManager().LoadHardhatArtifacts(utils.EthereumNetworkID, dir)
+		require.NoError(t, err)
+		require.Len(t, loaded, 1)
+		require.Equal(t, "Token", loaded[0].Name())
+		require.Contains(t, loaded[0].ABI().Events, "Transfer")
+
+		artifact, err := ReadArtifact(filepath.Join(dir, "contracts", "Token.sol", "Token.json"))
+		require.NoError(t, err)
+		require.Equal(t, []byte{0x60, 0x01}, artifact.Bytecode)
+		require.Equal(t, []byte{0x60, 0x02}, artifact.DeployedBytecode)
+	})
+
+	t.Run("Foundry", func(t *testing.T) {
+		dir := t.TempDir()
+		writeLoaderTestFile(t, filepath.Join(dir, "Vault.sol", "Vault.json"),
+			`{"abi":`+loaderTestABI+`,"bytecode":{"object":"0x6001","linkReferences":{}},"deployedBytecode":{"object":"0x6002"},"methodIdentifiers":{}}`)
+		writeLoaderTestFile(t, filepath.Join(dir, "Vault.sol", "Broken.json"), `{"bytecode":{"object":"0x"}}`)
+		writeLoaderTestFile(t, filepath.Join(dir, "build-info", "abc.json"), `{"id":"abc"}`)
+
+		loaded, err := NewManager().LoadFoundryArtifacts(utils.EthereumNetworkID, dir)
+		require.Len(t, loaded, 1)
+		require.Equal(t, "Vault", loaded[0].Name())
+		require.ErrorContains(t, err, "artifact has no abi")
+	})
+}
+
+func TestLoadManifest(t *testing.T) {
+	dir := t.TempDir()
+	writeLoaderTestFile(t, filepath.Join(dir, "abis", "ERC20.abi.json"), loaderTestABI)
+	token := common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7")
+
+	t.Run("YAML", func(t *testing.T) {
+		manifestPath := filepath.Join(dir, "contracts.yaml")
+		writeLoaderTestFile(t, manifestPath, `
+contracts:
+  - network: 1
+    address: "`+token.Hex()+`"
+    name: Tether USD
+    standard: ERC-20
+    abi: abis/ERC20.abi.json
+  - network: 137
+    id: 42
+    abi: abis/ERC20.abi.json
+  - network: 1
+    address: "not-an-address"
+    abi: abis/ERC20.abi.json
+  - network: 1
+    standard: erc9999
+    abi: abis/ERC20.abi.json
+  - network: 1
+    abi: abis/Missing.abi.json
+`)
+
+		manager := NewManager()
+		loaded, err := manager.LoadManifest(manifestPath)
+		require.Len(t, loaded, 2)
+
+		var loadErrs LoadErrors
+		require.ErrorAs(t, err, &loadErrs)
+		require.Len(t, loadErrs, 3)
+		require.ErrorContains(t, loadErrs[0], "contracts[2]: invalid address")
+		require.ErrorContains(t, loadErrs[1], "unknown contract standard")
+		require.ErrorContains(t, loadErrs[2], "failed to read abi")
+
+		contract, gErr := manager.GetByAddr(utils.EthereumNetworkID, token)
+		require.NoError(t, gErr)
+		require.Equal(t, "Tether USD", contract.Name())
+		require.Equal(t, utils.Erc20, contract.Standard())
+
+		contract, gErr = manager.GetByID(utils.PolygonNetworkID, 42)
+		require.NoError(t, gErr)
+		require.Equal(t, "ERC20", contract.Name())
+		require.Equal(t, utils.Custom, contract.Standard())
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		manifestPath := filepath.Join(dir, "contracts.json")
+		writeLoaderTestFile(t, manifestPath, `{"contracts":[{"network":1,"address":"`+token.Hex()+`","standard":"erc20","abi":"abis/ERC20.abi.json"}]}`)
+
+		loaded, err := NewManager().LoadManifest(manifestPath)
+		require.NoError(t, err)
+		require.Len(t, loaded, 1)
+		require.Equal(t, token, loaded[0].Addr())
+	})
+
+	t.Run("Unsupported format", func(t *testing.T) {
+		_, err := NewManager().LoadManifest(filepath.Join(dir, "contracts.toml"))
+		require.Error(t, err)
+	})
+}
@@ -2,27 +2,109 @@ package contracts
 
 import (
 	"fmt"
-	"github.com/ethereum/go-ethereum/common"
 	"sync"
 
+	errorshs "github.com/enviodev/hypersync-client-go/errors"
+	"github.com/enviodev/hypersync-client-go/types"
 	"github.com/enviodev/hypersync-client-go/utils"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/pkg/errors"
 )
 
+// AnyNetwork is the network under which network-agnostic contracts, such as the default standard ABIs, are registered.
+// Lookups for a specific network fall back to the contracts registered under AnyNetwork, with contracts registered
+// for the network itself taking precedence.
+const AnyNetwork utils.NetworkID = 0
+
+// Selector represents a 4-byte function selector.
+type Selector [4]byte
+
+// networkIndex holds the contracts registered for a single network, indexed for constant time lookups.
+type networkIndex struct {
+	ordered    []*Contract                    // Contracts in registration order.
+	byID       map[uint64]*Contract           // Contracts by internal ID.
+	byAddr     map[common.Address]*Contract   // Deployed contracts by address. Zero-address standards are not indexed.
+	byTopic0   map[common.Hash][]*Contract    // Contracts by the topic0 of every event in their ABI.
+	bySelector map[Selector][]*Contract       // Contracts by the selector of every function in their ABI.
+	byStandard map[utils.Standard][]*Contract // Contracts by their standard.
+}
+
+// newNetworkIndex creates an empty networkIndex.
+func newNetworkIndex() *networkIndex {
+	return &networkIndex{
+		ordered:    make([]*Contract, 0),
+		byID:       make(map[uint64]*Contract),
+		byAddr:     make(map[common.Address]*Contract),
+		byTopic0:   make(map[common.Hash][]*Contract),
+		bySelector: make(map[Selector][]*Contract),
+		byStandard: make(map[utils.Standard][]*Contract),
+	}
+}
+
+// add indexes the contract. The caller must ensure the contract ID and address are not yet taken.
+func (n *networkIndex) add(contract *Contract) {
+	n.ordered = append(n.ordered, contract)
+	n.byID[contract.id] = contract
+	if contract.addr != (common.Address{}) {
+		n.byAddr[contract.addr] = contract
+	}
+	for _, event := range contract.abi.Events {
+		n.byTopic0[event.ID] = append(n.byTopic0[event.ID], contract)
+	}
+	for _, method := range contract.abi.Methods {
+		selector := Selector(method.ID)
+		n.bySelector[selector] = append(n.bySelector[selector], contract)
+	}
+	n.byStandard[contract.standard] = append(n.byStandard[contract.standard], contract)
+}
+
+// remove drops the contract from every index.
+func (n *networkIndex) remove(contract *Contract) {
+	n.ordered = withoutContract(n.ordered, contract)
+	delete(n.byID, contract.id)
+	if existing, ok := n.byAddr[contract.addr]; ok && existing == contract {
+		delete(n.byAddr, contract.addr)
+	}
+	for _, event := range contract.abi.Events {
+		n.byTopic0[event.ID] = withoutContract(n.byTopic0[event.ID], contract)
+		if len(n.byTopic0[event.ID]) == 0 {
+			delete(n.byTopic0, event.ID)
+		}
+	}
+	for _, method := range contract.abi.Methods {
+		selector := Selector(method.ID)
+		n.bySelector[selector] = withoutContract(n.bySelector[selector], contract)
+		if len(n.bySelector[selector]) == 0 {
+			delete(n.bySelector, selector)
+		}
+	}
+	n.byStandard[contract.standard] = withoutContract(n.byStandard[contract.standard], contract)
+	if len(n.byStandard[contract.standard]) == 0 {
+		delete(n.byStandard, contract.standard)
+	}
+}
+
 // Manager manages a registry of contracts, allowing for thread-safe operations.
+// Contracts are indexed per network by ID, address, event topic0, function selector and standard.
+// Contracts sharing the same raw ABI share a single parsed ABI instance.
 type Manager struct {
 	mu       sync.RWMutex
-	registry map[utils.NetworkID][]*Contract // Registry now holds a slice of contracts for each network.
+	registry map[utils.NetworkID]*networkIndex
+	abis     map[common.Hash]*abi.ABI // Parsed ABIs keyed by the keccak hash of their raw ABI.
 }
 
 // NewManager creates and returns a new Manager with an initialized registry.
 func NewManager() *Manager {
 	return &Manager{
-		registry: make(map[utils.NetworkID][]*Contract),
+		registry: make(map[utils.NetworkID]*networkIndex),
+		abis:     make(map[common.Hash]*abi.ABI),
 	}
 }
 
-// NewManagerWithDefaults creates and returns a new Manager with an initialized registry and loaded default contracts.
+// NewManagerWithDefaults creates and returns a new Manager with an initialized registry and the default standard
+// contracts loaded under AnyNetwork.
 func NewManagerWithDefaults() (*Manager, error) {
 	m := NewManager()
 	if err := m.LoadDefaults(); err != nil {
@@ -32,7 +114,8 @@ func NewManagerWithDefaults() (*Manager, error) {
 }
 
 // Register adds a new contract to the registry for the given network ID.
-// It returns an error if the contract is already registered or if the contract is invalid.
+// It returns an error if the contract ID or address is already registered or if the contract is invalid.
+// Contracts registered at the zero address act as standard ABIs and are only resolved by topic0 or selector.
 //
 // Example:
 //
@@ -44,23 +127,138 @@ func (m *Manager) Register(network utils.NetworkID, contract *Contract) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	// Check if the contract with the same address is already registered.
-	for _, existingContract := range m.registry[network] {
-		if existingContract.id == contract.id {
-			return errors.Errorf("contract already registered with id: %d for network: %d", contract.id, network)
-		}
+	return m.register(network, contract)
+}
+
+// RegisterNew registers a copy of the template at the given address under the next free ID of the network
+// and returns it. The ID is assigned and the contract registered under the same lock, so concurrent callers
+// never get the same ID, unlike with NextID followed by Register.
+//
+// Example:
+//
+//	pair, err := manager.RegisterNew(networkID, pairTemplate, common.HexToAddress("0x123..."))
+//	if err != nil {
+//	    log.Fatalf("Failed to register pair: %v", err)
+//	}
+func (m *Manager) RegisterNew(network utils.NetworkID, template *Contract, addr common.Address) (*Contract, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	contract := template.WithAddress(m.nextID(network), addr)
+	if err := m.register(network, contract); err != nil {
+		return nil, err
 	}
+	return contract, nil
+}
 
-	// Validate the contract before adding it to the registry.
-	if err := contract.Validate(); err != nil {
-		return errors.Wrap(err, "contract validation failed")
+// register adds the contract to the registry of the network. The caller must hold the write lock.
+func (m *Manager) register(network utils.NetworkID, contract *Contract) error {
+	index := m.networkIndex(network)
+
+	if _, exists := index.byID[contract.id]; exists {
+		return errors.Errorf("contract already registered with id: %d for network: %d", contract.id, network)
 	}
 
-	// Append the contract to the list for the given network ID.
-	m.registry[network] = append(m.registry[network], contract)
+	if existing, exists := index.byAddr[contract.addr]; exists {
+		return errors.Errorf(
+			"contract already registered with address: %s for network: %d (id: %d)", contract.addr.Hex(), network, existing.id,
+		)
+	}
+
+	if err := m.prepare(contract); err != nil {
+		return err
+	}
+
+	index.add(contract)
 	return nil
 }
 
+// Update replaces the registered contract that has the same ID as the provided contract, re-indexing it.
+// It returns an error if the contract is not registered, is invalid, or its new address is taken by another contract.
+//
+// Example:
+//
+//	err := manager.Update(networkID, updatedContract)
+//	if err != nil {
+//	    log.Fatalf("Failed to update contract: %v", err)
+//	}
+func (m *Manager) Update(network utils.NetworkID, contract *Contract) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	index, exists := m.registry[network]
+	if !exists {
+		return errors.Wrapf(errorshs.ErrContractNotFound, "network: %d, id: %d", network, contract.id)
+	}
+
+	current, exists := index.byID[contract.id]
+	if !exists {
+		return errors.Wrapf(errorshs.ErrContractNotFound, "network: %d, id: %d", network, contract.id)
+	}
+
+	if existing, taken := index.byAddr[contract.addr]; taken && existing.id != contract.id {
+		return errors.Errorf(
+			"contract already registered with address: %s for network: %d (id: %d)", contract.addr.Hex(), network, existing.id,
+		)
+	}
+
+	if err := m.prepare(contract); err != nil {
+		return err
+	}
+
+	index.remove(current)
+	index.add(contract)
+	return nil
+}
+
+// Remove deletes the contract with the given ID from the registry of the given network.
+//
+// Example:
+//
+//	if err := manager.Remove(networkID, 1); err != nil {
+//	    log.Fatalf("Failed to remove contract: %v", err)
+//	}
+func (m *Manager) Remove(network utils.NetworkID, id uint64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	index, exists := m.registry[network]
+	if !exists {
+		return errors.Wrapf(errorshs.ErrContractNotFound, "network: %d, id: %d", network, id)
+	}
+
+	contract, exists := index.byID[id]
+	if !exists {
+		return errors.Wrapf(errorshs.ErrContractNotFound, "network: %d, id: %d", network, id)
+	}
+
+	index.remove(contract)
+	return nil
+}
+
+// NextID returns an unused contract ID for the given network, one above the highest ID registered for the
+// network or under AnyNetwork. The ID is not reserved, so concurrent callers may get the same one; use
+// RegisterNew to assign an ID and register a contract at once.
+func (m *Manager) NextID(network utils.NetworkID) uint64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.nextID(network)
+}
+
+// nextID returns an unused contract ID for the given network. The caller must hold the read lock.
+func (m *Manager) nextID(network utils.NetworkID) uint64 {
+	next := uint64(1)
+	for _, index := range m.lookupIndexes(network) {
+		for id := range index.byID {
+			if id >= next {
+				next = id + 1
+			}
+		}
+	}
+	return next
+}
+
 // GetByAddr retrieves the contract for the given network ID and associated address.
 // It returns an error if no contract is registered for the given network.
 //
@@ -74,18 +272,18 @@ func (m *Manager) GetByAddr(network utils.NetworkID, address common.Address) (*C
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	contracts, exists := m.registry[network]
-	if !exists || len(contracts) == 0 {
-		return nil, errors.Errorf("no contracts registered for network: %d", network)
+	indexes, err := m.getNetworkIndexes(network)
+	if err != nil {
+		return nil, err
 	}
 
-	for _, contract := range contracts {
-		if contract.addr == address {
+	for _, index := range indexes {
+		if contract, ok := index.byAddr[address]; ok {
 			return contract, nil
 		}
 	}
 
-	return nil, fmt.Errorf("requested contract could not be found for network: %d, address: %s", network, address.Hex())
+	return nil, errors.Wrapf(errorshs.ErrContractNotFound, "network: %d, address: %s", network, address.Hex())
 }
 
 // GetByID retrieves the contract for the given network ID and associated contract ID.
@@ -93,7 +291,7 @@ func (m *Manager) GetByAddr(network utils.NetworkID, address common.Address) (*C
 //
 // Example:
 //
-//	contract, err := manager.GetByAddr(networkID, 1)
+//	contract, err := manager.GetByID(networkID, 1)
 //	if err != nil {
 //	    log.Fatalf("Failed to get contract: %v", err)
 //	}
@@ -101,21 +299,21 @@ func (m *Manager) GetByID(network utils.NetworkID, id uint64) (*Contract, error)
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	contracts, exists := m.registry[network]
-	if !exists || len(contracts) == 0 {
-		return nil, errors.Errorf("no contracts registered for network: %d", network)
+	indexes, err := m.getNetworkIndexes(network)
+	if err != nil {
+		return nil, err
 	}
 
-	for _, contract := range contracts {
-		if contract.id == id {
+	for _, index := range indexes {
+		if contract, ok := index.byID[id]; ok {
 			return contract, nil
 		}
 	}
 
-	return nil, fmt.Errorf("requested contract could not be found for network: %d, id: %d", network, id)
+	return nil, errors.Wrapf(errorshs.ErrContractNotFound, "network: %d, id: %d", network, id)
 }
 
-// GetByStandard retrieves the contract for the given network ID and associated contract standard.
+// GetByStandard retrieves the first registered contract for the given network ID and associated contract standard.
 // It returns an error if no contract is registered for the given network.
 //
 // Example:
@@ -128,18 +326,95 @@ func (m *Manager) GetByStandard(network utils.NetworkID, standard utils.Standard
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	contracts, exists := m.registry[network]
-	if !exists || len(contracts) == 0 {
-		return nil, errors.Errorf("no contracts registered for network: %d", network)
+	indexes, err := m.getNetworkIndexes(network)
+	if err != nil {
+		return nil, err
 	}
 
-	for _, contract := range contracts {
-		if contract.standard == standard {
-			return contract, nil
+	for _, index := range indexes {
+		if contracts := index.byStandard[standard]; len(contracts) > 0 {
+			return contracts[0], nil
+		}
+	}
+
+	return nil, errors.Wrapf(errorshs.ErrContractNotFound, "network: %d, standard: %d", network, standard)
+}
+
+// GetByTopic0 returns every contract registered for the given network whose ABI declares an event with the given topic0.
+//
+// Example:
+//
+//	contracts := manager.GetByTopic0(networkID, transferTopic)
+func (m *Manager) GetByTopic0(network utils.NetworkID, topic0 common.Hash) []*Contract {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	toReturn := make([]*Contract, 0)
+	for _, index := range m.lookupIndexes(network) {
+		toReturn = append(toReturn, index.byTopic0[topic0]...)
+	}
+	return toReturn
+}
+
+// GetBySelector returns every contract registered for the given network whose ABI declares a function with the given selector.
+//
+// Example:
+//
+//	contracts := manager.GetBySelector(networkID, contracts.Selector{0xa9, 0x05, 0x9c, 0xbb})
+func (m *Manager) GetBySelector(network utils.NetworkID, selector Selector) []*Contract {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	toReturn := make([]*Contract, 0)
+	for _, index := range m.lookupIndexes(network) {
+		toReturn = append(toReturn, index.bySelector[selector]...)
+	}
+	return toReturn
+}
+
+// Resolve finds the contract able to decode the given log. A contract registered at the log's address is
+// preferred when its ABI declares the log's event; otherwise the first zero-address standard ABI declaring
+// an event with the log's topic0 and the same number of indexed arguments is returned. The indexed arity
+// check distinguishes events sharing a topic0, such as ERC-20 and ERC-721 Transfer.
+//
+// Example:
+//
+//	contract, err := manager.Resolve(networkID, log)
+//	if err != nil {
+//	    log.Fatalf("Failed to resolve contract: %v", err)
+//	}
+//	decoded, err := decoder.DecodeEthereumLogWithContract(log, contract)
+func (m *Manager) Resolve(network utils.NetworkID, log types.Log) (*Contract, error) {
+	topics := log.Topics()
+	if len(topics) == 0 {
+		return nil, errors.New("log has no topics")
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	indexes, err := m.getNetworkIndexes(network)
+	if err != nil {
+		return nil, err
+	}
+
+	if log.Address != nil {
+		for _, index := range indexes {
+			if contract, ok := index.byAddr[*log.Address]; ok && declaresEvent(contract, topics) {
+				return contract, nil
+			}
+		}
+	}
+
+	for _, index := range indexes {
+		for _, contract := range index.byTopic0[topics[0]] {
+			if contract.addr == (common.Address{}) && declaresEvent(contract, topics) {
+				return contract, nil
+			}
 		}
 	}
 
-	return nil, fmt.Errorf("requested contract could not be found for network: %d, standard: %d", network, standard)
+	return nil, errors.Wrapf(errorshs.ErrContractNotFound, "network: %d, topic0: %s", network, topics[0].Hex())
 }
 
 // List returns a copy of all registered contracts in the registry.
@@ -156,30 +431,118 @@ func (m *Manager) List() map[utils.NetworkID][]*Contract {
 
 	// Return a copy of the map to avoid potential concurrent access issues.
 	toReturn := make(map[utils.NetworkID][]*Contract)
-	for id, contract := range m.registry {
-		toReturn[id] = contract
+	for id, index := range m.registry {
+		toReturn[id] = append([]*Contract{}, index.ordered...)
 	}
 
 	return toReturn
 }
 
 // ListByNetworkId returns a copy of all registered contracts in the registry under specified network id.
+// Network-agnostic contracts are only listed under AnyNetwork.
 //
 // Example:
 //
 //	contracts := manager.ListByNetworkId(utils.EthereumNetworkId)
-//	for id, contract := range contracts {
-//	    fmt.Printf("Network: %d, Contract: %v\n", id, contract)
+//	for _, contract := range contracts {
+//	    fmt.Printf("Contract: %v\n", contract)
 //	}
 func (m *Manager) ListByNetworkId(networkId utils.NetworkID) []*Contract {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	// Return a copy of the map to avoid potential concurrent access issues.
-	toReturn := make([]*Contract, 0)
-	for _, contract := range m.registry {
-		toReturn = append(toReturn, contract...)
+	index, exists := m.registry[networkId]
+	if !exists {
+		return []*Contract{}
+	}
+
+	return append([]*Contract{}, index.ordered...)
+}
+
+// networkIndex returns the index for the network, creating it when missing. The caller must hold the write lock.
+func (m *Manager) networkIndex(network utils.NetworkID) *networkIndex {
+	index, exists := m.registry[network]
+	if !exists {
+		index = newNetworkIndex()
+		m.registry[network] = index
+	}
+	return index
+}
+
+// lookupIndexes returns the non-empty indexes consulted for the network: its own index followed by the
+// AnyNetwork index. The caller must hold the read lock.
+func (m *Manager) lookupIndexes(network utils.NetworkID) []*networkIndex {
+	networks := []utils.NetworkID{network}
+	if network != AnyNetwork {
+		networks = append(networks, AnyNetwork)
 	}
 
+	indexes := make([]*networkIndex, 0, len(networks))
+	for _, id := range networks {
+		if index, exists := m.registry[id]; exists && len(index.ordered) > 0 {
+			indexes = append(indexes, index)
+		}
+	}
+	return indexes
+}
+
+// getNetworkIndexes returns the indexes consulted for the network or an error if nothing is registered for it.
+// The caller must hold the read lock.
+func (m *Manager) getNetworkIndexes(network utils.NetworkID) ([]*networkIndex, error) {
+	indexes := m.lookupIndexes(network)
+	if len(indexes) == 0 {
+		return nil, errors.Errorf("no contracts registered for network: %d", network)
+	}
+	return indexes, nil
+}
+
+// prepare validates the contract and swaps its parsed ABI for the shared instance of an identical raw ABI.
+// The caller must hold the write lock.
+func (m *Manager) prepare(contract *Contract) error {
+	if err := contract.Validate(); err != nil {
+		return errors.Wrap(err, "contract validation failed")
+	}
+
+	abiHash := crypto.Keccak256Hash([]byte(contract.rawAbi))
+	if shared, ok := m.abis[abiHash]; ok {
+		contract.abi = shared
+	} else {
+		m.abis[abiHash] = contract.abi
+	}
+
+	return nil
+}
+
+// declaresEvent reports whether the contract ABI declares the event identified by topics[0]
+// with an indexed argument count matching the remaining topics.
+func declaresEvent(contract *Contract, topics []common.Hash) bool {
+	event, err := contract.abi.EventByID(topics[0])
+	if err != nil {
+		return false
+	}
+
+	indexed := 0
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed++
+		}
+	}
+
+	return indexed == len(topics)-1
+}
+
+// withoutContract returns the slice without the given contract, preserving order.
+func withoutContract(contracts []*Contract, contract *Contract) []*Contract {
+	toReturn := make([]*Contract, 0, len(contracts))
+	for _, c := range contracts {
+		if c != contract {
+			toReturn = append(toReturn, c)
+		}
+	}
 	return toReturn
 }
+
+// String returns the selector as a 0x-prefixed hex string.
+func (s Selector) String() string {
+	return fmt.Sprintf("0x%x", s[:])
+}
@@ -1,9 +1,14 @@
 package contracts
 
 import (
-	"github.com/enviodev/hypersync-client-go/utils"
+	"math/big"
+	"sync"
 	"testing"
 
+	errorshs "github.com/enviodev/hypersync-client-go/errors"
+	"github.com/enviodev/hypersync-client-go/types"
+	"github.com/enviodev/hypersync-client-go/utils"
+
 	"github.com/enviodev/hypersync-client-go/logger"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
@@ -17,7 +22,7 @@ func TestBasicManager(t *testing.T) {
 	require.NotNil(t, zLog)
 	logger.SetGlobalLogger(zLog)
 
-	contract := defaultContracts[utils.EthereumNetworkID][0]
+	contract := defaultContracts[0]
 	contractAbi := contract.RawABI()
 
 	// Define table-driven test cases
@@ -101,12 +106,12 @@ func TestNewManagerWithDefaults(t *testing.T) {
 	// Define table-driven test cases
 	tests := []struct {
 		name      string
-		setupFn   func() map[utils.NetworkID][]*Contract
+		setupFn   func() []*Contract
 		expectErr bool
 	}{
 		{
 			name: "Successful manager creation with defaults",
-			setupFn: func() map[utils.NetworkID][]*Contract {
+			setupFn: func() []*Contract {
 				// Set up a valid default contracts map
 				return defaultContracts
 			},
@@ -130,9 +135,9 @@ func TestNewManagerWithDefaults(t *testing.T) {
 				require.NoError(t, err, "unexpected error during manager creation")
 				require.NotNil(t, manager, "manager should not be nil")
 
-				// Check if all the expected default contracts were loaded correctly
-				for networkID, expectedContracts := range dContracts {
-					for _, expectedContract := range expectedContracts {
+				// Check if all the expected default contracts were loaded correctly and resolve on any network
+				for _, networkID := range []utils.NetworkID{AnyNetwork, utils.EthereumNetworkID, utils.PolygonNetworkID} {
+					for _, expectedContract := range dContracts {
 						loadedContract, err := manager.GetByID(networkID, expectedContract.ID())
 						require.NoError(t, err)
 
@@ -147,3 +152,193 @@ func TestNewManagerWithDefaults(t *testing.T) {
 		})
 	}
 }
+
+func TestNewContractFromHumanABI(t *testing.T) {
+	contract, err := NewContractFromHumanABI(
+		1,
+		common.HexToAddress("0x1234567890abcdef1234567890abcdef12345678"),
+		"HumanToken",
+		utils.Erc20,
+		"event Transfer(address indexed from, address indexed to, uint256 value)",
+		"function transfer(address to, uint256 amount) returns (bool)",
+	)
+	require.NoError(t, err)
+	require.NotNil(t, contract.ABI())
+	require.Contains(t, contract.ABI().Events, "Transfer")
+	require.Contains(t, contract.ABI().Methods, "transfer")
+
+	_, err = NewContractFromHumanABI(2, common.Address{}, "Broken", utils.Erc20, "event Transfer(address indexed")
+	require.Error(t, err)
+}
+
+func TestManagerIndexes(t *testing.T) {
+	manager, err := NewManagerWithDefaults()
+	require.NoError(t, err)
+
+	network := utils.EthereumNetworkID
+	erc20, err := manager.GetByStandard(network, utils.Erc20)
+	require.NoError(t, err)
+	erc721, err := manager.GetByStandard(network, utils.Erc721)
+	require.NoError(t, err)
+
+	tokenAddr := common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7")
+	otherAddr := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+
+	token := erc20.WithAddress(manager.NextID(network), tokenAddr)
+	require.NoError(t, manager.Register(network, token))
+	require.Error(t, manager.Register(network, token.WithAddress(manager.NextID(network), tokenAddr)), "duplicate address")
+
+	other := erc20.WithAddress(manager.NextID(network), otherAddr)
+	require.NoError(t, manager.Register(network, other))
+	require.Same(t, token.ABI(), other.ABI(), "contracts sharing a raw ABI should share the parsed ABI")
+
+	found, err := manager.GetByAddr(network, tokenAddr)
+	require.NoError(t, err)
+	require.Equal(t, token.ID(), found.ID())
+
+	transferTopic := erc20.ABI().Events["Transfer"].ID
+	transferSelector := Selector(erc20.ABI().Methods["transfer"].ID)
+	standardTransfers := len(manager.GetByTopic0(AnyNetwork, transferTopic))
+	require.Len(t, manager.GetByTopic0(network, transferTopic), standardTransfers+2)
+	require.Len(t, manager.GetBySelector(network, transferSelector), len(manager.GetBySelector(AnyNetwork, transferSelector))+2)
+	require.Len(t, manager.GetByTopic0(utils.PolygonNetworkID, transferTopic), standardTransfers)
+
+	holder := common.BytesToHash(otherAddr.Bytes())
+	erc20Log := newManagerTestLog(&otherAddr, transferTopic, holder, holder)
+	unknownAddr := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	erc721Log := newManagerTestLog(&unknownAddr, transferTopic, holder, holder, common.BigToHash(common.Big1))
+
+	resolved, err := manager.Resolve(network, erc20Log)
+	require.NoError(t, err)
+	require.Equal(t, other.ID(), resolved.ID(), "address match should take precedence")
+
+	resolved, err = manager.Resolve(network, erc721Log)
+	require.NoError(t, err)
+	require.Equal(t, erc721.ID(), resolved.ID(), "indexed arity should select the ERC-721 standard")
+
+	_, err = manager.Resolve(network, newManagerTestLog(&unknownAddr, common.HexToHash("0x01")))
+	require.ErrorIs(t, err, errorshs.ErrContractNotFound)
+
+	moved := other.WithAddress(other.ID(), unknownAddr)
+	require.NoError(t, manager.Update(network, moved))
+	_, err = manager.GetByAddr(network, otherAddr)
+	require.ErrorIs(t, err, errorshs.ErrContractNotFound)
+	found, err = manager.GetByAddr(network, unknownAddr)
+	require.NoError(t, err)
+	require.Equal(t, other.ID(), found.ID())
+
+	require.NoError(t, manager.Remove(network, token.ID()))
+	require.ErrorIs(t, manager.Remove(network, token.ID()), errorshs.ErrContractNotFound)
+	require.Len(t, manager.GetByTopic0(network, transferTopic), standardTransfers+1)
+	require.Len(t, manager.ListByNetworkId(network), 1)
+	require.Len(t, manager.ListByNetworkId(AnyNetwork), len(defaultContracts))
+	require.Empty(t, manager.ListByNetworkId(utils.PolygonNetworkID))
+}
+
+func TestManagersDoNotShareDefaults(t *testing.T) {
+	first, err := NewManagerWithDefaults()
+	require.NoError(t, err)
+	second, err := NewManagerWithDefaults()
+	require.NoError(t, err)
+
+	for _, contract := range defaultContracts {
+		fromFirst, fErr := first.GetByID(AnyNetwork, contract.ID())
+		require.NoError(t, fErr)
+		fromSecond, fErr := second.GetByID(AnyNetwork, contract.ID())
+		require.NoError(t, fErr)
+
+		require.NotSame(t, contract, fromFirst)
+		require.NotSame(t, fromFirst, fromSecond)
+		require.Nil(t, contract.abi, "loading defaults should not mutate the package-level contracts")
+	}
+}
+
+func TestManagerAnyNetworkFallback(t *testing.T) {
+	manager := NewManager()
+	network := utils.EthereumNetworkID
+
+	_, err := manager.GetByID(network, 1)
+	require.ErrorContains(t, err, "no contracts registered for network")
+
+	standard, err := NewContractFromHumanABI(1, common.Address{}, "Standard", utils.Erc20,
+		"event Transfer(address indexed from, address indexed to, uint256 value)",
+	)
+	require.NoError(t, err)
+	require.NoError(t, manager.Register(AnyNetwork, standard))
+
+	for _, id := range []utils.NetworkID{AnyNetwork, network, utils.PolygonNetworkID} {
+		found, fErr := manager.GetByStandard(id, utils.Erc20)
+		require.NoError(t, fErr)
+		require.Same(t, standard, found)
+	}
+	require.Equal(t, uint64(2), manager.NextID(network))
+
+	tokenAddr := common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7")
+	token := standard.WithAddress(1, tokenAddr)
+	require.NoError(t, manager.Register(network, token), "network contracts may reuse the ids of AnyNetwork")
+
+	found, err := manager.GetByID(network, 1)
+	require.NoError(t, err)
+	require.Same(t, token, found, "network contracts should take precedence")
+	found, err = manager.GetByID(utils.PolygonNetworkID, 1)
+	require.NoError(t, err)
+	require.Same(t, standard, found)
+
+	transferTopic := standard.ABI().Events["Transfer"].ID
+	holder := common.BytesToHash(tokenAddr.Bytes())
+	resolved, err := manager.Resolve(utils.PolygonNetworkID, newManagerTestLog(&tokenAddr, transferTopic, holder, holder))
+	require.NoError(t, err)
+	require.Same(t, standard, resolved, "contracts of another network should not be resolved")
+
+	require.Len(t, manager.GetByTopic0(network, transferTopic), 2)
+	require.Len(t, manager.ListByNetworkId(network), 1)
+}
+
+func TestManagerRegisterNewConcurrently(t *testing.T) {
+	manager := NewManager()
+	network := utils.EthereumNetworkID
+
+	template, err := NewContractFromHumanABI(1, common.Address{}, "Pair", utils.Custom,
+		"event Sync(uint112 reserve0, uint112 reserve1)",
+	)
+	require.NoError(t, err)
+
+	const count = 64
+	registered := make([]*Contract, count)
+	errs := make([]error, count)
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			registered[i], errs[i] = manager.RegisterNew(network, template, common.BigToAddress(big.NewInt(int64(i+1))))
+		}(i)
+	}
+	wg.Wait()
+
+	ids := make(map[uint64]bool, count)
+	for i, contract := range registered {
+		require.NoError(t, errs[i])
+		require.False(t, ids[contract.ID()], "id %d assigned twice", contract.ID())
+		ids[contract.ID()] = true
+
+		found, fErr := manager.GetByID(network, contract.ID())
+		require.NoError(t, fErr)
+		require.Same(t, contract, found)
+	}
+	require.Equal(t, uint64(count+1), manager.NextID(network))
+
+	_, err = manager.RegisterNew(network, template, common.BigToAddress(big.NewInt(1)))
+	require.ErrorContains(t, err, "contract already registered with address")
+	require.Equal(t, uint64(count+1), manager.NextID(network), "a failed registration should not use up an id")
+}
+
+func newManagerTestLog(addr *common.Address, topics ...common.Hash) types.Log {
+	log := types.Log{Address: addr}
+	targets := []**common.Hash{&log.Topic0, &log.Topic1, &log.Topic2, &log.Topic3}
+	for i := range topics {
+		topic := topics[i]
+		*targets[i] = &topic
+	}
+	return log
+}
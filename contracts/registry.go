@@ -1,49 +1,256 @@
 package contracts
 
 import (
+	"fmt"
+
 	"github.com/enviodev/hypersync-client-go/utils"
 	"github.com/pkg/errors"
 )
 
-var defaultContracts = map[utils.NetworkID][]*Contract{
-	utils.EthereumNetworkID: {
-		{
-			id:       1,
-			name:     "ERC-20 Token Standard",
-			addr:     utils.EthZeroAddr,
-			standard: utils.Erc20,
-			rawAbi:   `[{"constant":true,"inputs":[],"name":"name","outputs":[{"name":"","type":"string"}],"payable":false,"stateMutability":"view","type":"function"},{"constant":false,"inputs":[{"name":"_spender","type":"address"},{"name":"_value","type":"uint256"}],"name":"approve","outputs":[{"name":"","type":"bool"}],"payable":false,"stateMutability":"nonpayable","type":"function"},{"constant":true,"inputs":[],"name":"totalSupply","outputs":[{"name":"","type":"uint256"}],"payable":false,"stateMutability":"view","type":"function"},{"constant":false,"inputs":[{"name":"_from","type":"address"},{"name":"_to","type":"address"},{"name":"_value","type":"uint256"}],"name":"transferFrom","outputs":[{"name":"","type":"bool"}],"payable":false,"stateMutability":"nonpayable","type":"function"},{"constant":true,"inputs":[],"name":"decimals","outputs":[{"name":"","type":"uint8"}],"payable":false,"stateMutability":"view","type":"function"},{"constant":true,"inputs":[{"name":"_owner","type":"address"}],"name":"balanceOf","outputs":[{"name":"balance","type":"uint256"}],"payable":false,"stateMutability":"view","type":"function"},{"constant":true,"inputs":[],"name":"symbol","outputs":[{"name":"","type":"string"}],"payable":false,"stateMutability":"view","type":"function"},{"constant":false,"inputs":[{"name":"_to","type":"address"},{"name":"_value","type":"uint256"}],"name":"transfer","outputs":[{"name":"","type":"bool"}],"payable":false,"stateMutability":"nonpayable","type":"function"},{"constant":true,"inputs":[{"name":"_owner","type":"address"},{"name":"_spender","type":"address"}],"name":"allowance","outputs":[{"name":"","type":"uint256"}],"payable":false,"stateMutability":"view","type":"function"},{"payable":true,"stateMutability":"payable","type":"fallback"},{"anonymous":false,"inputs":[{"indexed":true,"name":"owner","type":"address"},{"indexed":true,"name":"spender","type":"address"},{"indexed":false,"name":"value","type":"uint256"}],"name":"Approval","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"value","type":"uint256"}],"name":"Transfer","type":"event"}]`,
-		},
-		{
-			id:       2,
-			name:     "ERC-721 Non-Fungible Token Standard",
-			addr:     utils.EthZeroAddr,
-			standard: utils.Erc721,
-			rawAbi:   `[{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"owner","type":"address"},{"indexed":true,"internalType":"address","name":"approved","type":"address"},{"indexed":true,"internalType":"uint256","name":"tokenId","type":"uint256"}],"name":"Approval","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"owner","type":"address"},{"indexed":true,"internalType":"address","name":"operator","type":"address"},{"indexed":false,"internalType":"bool","name":"approved","type":"bool"}],"name":"ApprovalForAll","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"from","type":"address"},{"indexed":true,"internalType":"address","name":"to","type":"address"},{"indexed":true,"internalType":"uint256","name":"tokenId","type":"uint256"}],"name":"Transfer","type":"event"},{"inputs":[{"internalType":"address","name":"to","type":"address"},{"internalType":"uint256","name":"tokenId","type":"uint256"}],"name":"approve","outputs":[],"stateMutability":"nonpayable","type":"function"},{"constant":true,"inputs":[],"name":"totalSupply","outputs":[{"name":"","type":"uint256"}],"payable":false,"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"owner","type":"address"}],"name":"balanceOf","outputs":[{"internalType":"uint256","name":"balance","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"uint256","name":"tokenId","type":"uint256"}],"name":"getApproved","outputs":[{"internalType":"address","name":"operator","type":"address"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"owner","type":"address"},{"internalType":"address","name":"operator","type":"address"}],"name":"isApprovedForAll","outputs":[{"internalType":"bool","name":"","type":"bool"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"name","outputs":[{"internalType":"string","name":"","type":"string"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"uint256","name":"tokenId","type":"uint256"}],"name":"ownerOf","outputs":[{"internalType":"address","name":"owner","type":"address"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"from","type":"address"},{"internalType":"address","name":"to","type":"address"},{"internalType":"uint256","name":"tokenId","type":"uint256"}],"name":"safeTransferFrom","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"internalType":"address","name":"from","type":"address"},{"internalType":"address","name":"to","type":"address"},{"internalType":"uint256","name":"tokenId","type":"uint256"},{"internalType":"bytes","name":"data","type":"bytes"}],"name":"safeTransferFrom","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"internalType":"address","name":"operator","type":"address"},{"internalType":"bool","name":"_approved","type":"bool"}],"name":"setApprovalForAll","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"internalType":"bytes4","name":"interfaceId","type":"bytes4"}],"name":"supportsInterface","outputs":[{"internalType":"bool","name":"","type":"bool"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"symbol","outputs":[{"internalType":"string","name":"","type":"string"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"uint256","name":"tokenId","type":"uint256"}],"name":"tokenURI","outputs":[{"internalType":"string","name":"","type":"string"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"from","type":"address"},{"internalType":"address","name":"to","type":"address"},{"internalType":"uint256","name":"tokenId","type":"uint256"}],"name":"transferFrom","outputs":[],"stateMutability":"nonpayable","type":"function"}]`,
-		},
-		{
-			id:       3,
-			name:     "ERC-1155 Multi Token Standard",
-			addr:     utils.EthZeroAddr,
-			standard: utils.Erc1155,
-			rawAbi:   `[{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"account","type":"address"},{"indexed":true,"internalType":"address","name":"operator","type":"address"},{"indexed":false,"internalType":"bool","name":"approved","type":"bool"}],"name":"ApprovalForAll","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"operator","type":"address"},{"indexed":true,"internalType":"address","name":"from","type":"address"},{"indexed":true,"internalType":"address","name":"to","type":"address"},{"indexed":false,"internalType":"uint256[]","name":"ids","type":"uint256[]"},{"indexed":false,"internalType":"uint256[]","name":"values","type":"uint256[]"}],"name":"TransferBatch","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"operator","type":"address"},{"indexed":true,"internalType":"address","name":"from","type":"address"},{"indexed":true,"internalType":"address","name":"to","type":"address"},{"indexed":false,"internalType":"uint256","name":"id","type":"uint256"},{"indexed":false,"internalType":"uint256","name":"value","type":"uint256"}],"name":"TransferSingle","type":"event"},{"anonymous":false,"inputs":[{"indexed":false,"internalType":"string","name":"value","type":"string"},{"indexed":true,"internalType":"uint256","name":"id","type":"uint256"}],"name":"URI","type":"event"},{"inputs":[{"internalType":"address","name":"account","type":"address"},{"internalType":"uint256","name":"id","type":"uint256"}],"name":"balanceOf","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address[]","name":"accounts","type":"address[]"},{"internalType":"uint256[]","name":"ids","type":"uint256[]"}],"name":"balanceOfBatch","outputs":[{"internalType":"uint256[]","name":"","type":"uint256[]"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"account","type":"address"},{"internalType":"address","name":"operator","type":"address"}],"name":"isApprovedForAll","outputs":[{"internalType":"bool","name":"","type":"bool"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"from","type":"address"},{"internalType":"address","name":"to","type":"address"},{"internalType":"uint256[]","name":"ids","type":"uint256[]"},{"internalType":"uint256[]","name":"amounts","type":"uint256[]"},{"internalType":"bytes","name":"data","type":"bytes"}],"name":"safeBatchTransferFrom","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"internalType":"address","name":"from","type":"address"},{"internalType":"address","name":"to","type":"address"},{"internalType":"uint256","name":"id","type":"uint256"},{"internalType":"uint256","name":"amount","type":"uint256"},{"internalType":"bytes","name":"data","type":"bytes"}],"name":"safeTransferFrom","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"internalType":"address","name":"operator","type":"address"},{"internalType":"bool","name":"approved","type":"bool"}],"name":"setApprovalForAll","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"internalType":"bytes4","name":"interfaceId","type":"bytes4"}],"name":"supportsInterface","outputs":[{"internalType":"bool","name":"","type":"bool"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"uint256","name":"id","type":"uint256"}],"name":"uri","outputs":[{"internalType":"string","name":"","type":"string"}],"stateMutability":"view","type":"function"}]`,
-		},
+// defaultContracts are the standard ABIs. LoadDefaults registers a copy of each at the zero address under
+// AnyNetwork, so they apply to every network and are resolved by topic0 and selector.
+var defaultContracts = []*Contract{
+	{
+		id:       1,
+		name:     "ERC-20 Token Standard",
+		addr:     utils.EthZeroAddr,
+		standard: utils.Erc20,
+		rawAbi:   `[{"constant":true,"inputs":[],"name":"name","outputs":[{"name":"","type":"string"}],"payable":false,"stateMutability":"view","type":"function"},{"constant":false,"inputs":[{"name":"_spender","type":"address"},{"name":"_value","type":"uint256"}],"name":"approve","outputs":[{"name":"","type":"bool"}],"payable":false,"stateMutability":"nonpayable","type":"function"},{"constant":true,"inputs":[],"name":"totalSupply","outputs":[{"name":"","type":"uint256"}],"payable":false,"stateMutability":"view","type":"function"},{"constant":false,"inputs":[{"name":"_from","type":"address"},{"name":"_to","type":"address"},{"name":"_value","type":"uint256"}],"name":"transferFrom","outputs":[{"name":"","type":"bool"}],"payable":false,"stateMutability":"nonpayable","type":"function"},{"constant":true,"inputs":[],"name":"decimals","outputs":[{"name":"","type":"uint8"}],"payable":false,"stateMutability":"view","type":"function"},{"constant":true,"inputs":[{"name":"_owner","type":"address"}],"name":"balanceOf","outputs":[{"name":"balance","type":"uint256"}],"payable":false,"stateMutability":"view","type":"function"},{"constant":true,"inputs":[],"name":"symbol","outputs":[{"name":"","type":"string"}],"payable":false,"stateMutability":"view","type":"function"},{"constant":false,"inputs":[{"name":"_to","type":"address"},{"name":"_value","type":"uint256"}],"name":"transfer","outputs":[{"name":"","type":"bool"}],"payable":false,"stateMutability":"nonpayable","type":"function"},{"constant":true,"inputs":[{"name":"_owner","type":"address"},{"name":"_spender","type":"address"}],"name":"allowance","outputs":[{"name":"","type":"uint256"}],"payable":false,"stateMutability":"view","type":"function"},{"payable":true,"stateMutability":"payable","type":"fallback"},{"anonymous":false,"inputs":[{"indexed":true,"name":"owner","type":"address"},{"indexed":true,"name":"spender","type":"address"},{"indexed":false,"name":"value","type":"uint256"}],"name":"Approval","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"value","type":"uint256"}],"name":"Transfer","type":"event"}]`,
+	},
+	{
+		id:       2,
+		name:     "ERC-721 Non-Fungible Token Standard",
+		addr:     utils.EthZeroAddr,
+		standard: utils.Erc721,
+		rawAbi:   `[{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"owner","type":"address"},{"indexed":true,"internalType":"address","name":"approved","type":"address"},{"indexed":true,"internalType":"uint256","name":"tokenId","type":"uint256"}],"name":"Approval","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"owner","type":"address"},{"indexed":true,"internalType":"address","name":"operator","type":"address"},{"indexed":false,"internalType":"bool","name":"approved","type":"bool"}],"name":"ApprovalForAll","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"from","type":"address"},{"indexed":true,"internalType":"address","name":"to","type":"address"},{"indexed":true,"internalType":"uint256","name":"tokenId","type":"uint256"}],"name":"Transfer","type":"event"},{"inputs":[{"internalType":"address","name":"to","type":"address"},{"internalType":"uint256","name":"tokenId","type":"uint256"}],"name":"approve","outputs":[],"stateMutability":"nonpayable","type":"function"},{"constant":true,"inputs":[],"name":"totalSupply","outputs":[{"name":"","type":"uint256"}],"payable":false,"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"owner","type":"address"}],"name":"balanceOf","outputs":[{"internalType":"uint256","name":"balance","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"uint256","name":"tokenId","type":"uint256"}],"name":"getApproved","outputs":[{"internalType":"address","name":"operator","type":"address"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"owner","type":"address"},{"internalType":"address","name":"operator","type":"address"}],"name":"isApprovedForAll","outputs":[{"internalType":"bool","name":"","type":"bool"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"name","outputs":[{"internalType":"string","name":"","type":"string"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"uint256","name":"tokenId","type":"uint256"}],"name":"ownerOf","outputs":[{"internalType":"address","name":"owner","type":"address"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"from","type":"address"},{"internalType":"address","name":"to","type":"address"},{"internalType":"uint256","name":"tokenId","type":"uint256"}],"name":"safeTransferFrom","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"internalType":"address","name":"from","type":"address"},{"internalType":"address","name":"to","type":"address"},{"internalType":"uint256","name":"tokenId","type":"uint256"},{"internalType":"bytes","name":"data","type":"bytes"}],"name":"safeTransferFrom","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"internalType":"address","name":"operator","type":"address"},{"internalType":"bool","name":"_approved","type":"bool"}],"name":"setApprovalForAll","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"internalType":"bytes4","name":"interfaceId","type":"bytes4"}],"name":"supportsInterface","outputs":[{"internalType":"bool","name":"","type":"bool"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"symbol","outputs":[{"internalType":"string","name":"","type":"string"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"uint256","name":"tokenId","type":"uint256"}],"name":"tokenURI","outputs":[{"internalType":"string","name":"","type":"string"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"from","type":"address"},{"internalType":"address","name":"to","type":"address"},{"internalType":"uint256","name":"tokenId","type":"uint256"}],"name":"transferFrom","outputs":[],"stateMutability":"nonpayable","type":"function"}]`,
+	},
+	{
+		id:       3,
+		name:     "ERC-1155 Multi Token Standard",
+		addr:     utils.EthZeroAddr,
+		standard: utils.Erc1155,
+		rawAbi:   `[{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"account","type":"address"},{"indexed":true,"internalType":"address","name":"operator","type":"address"},{"indexed":false,"internalType":"bool","name":"approved","type":"bool"}],"name":"ApprovalForAll","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"operator","type":"address"},{"indexed":true,"internalType":"address","name":"from","type":"address"},{"indexed":true,"internalType":"address","name":"to","type":"address"},{"indexed":false,"internalType":"uint256[]","name":"ids","type":"uint256[]"},{"indexed":false,"internalType":"uint256[]","name":"values","type":"uint256[]"}],"name":"TransferBatch","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"operator","type":"address"},{"indexed":true,"internalType":"address","name":"from","type":"address"},{"indexed":true,"internalType":"address","name":"to","type":"address"},{"indexed":false,"internalType":"uint256","name":"id","type":"uint256"},{"indexed":false,"internalType":"uint256","name":"value","type":"uint256"}],"name":"TransferSingle","type":"event"},{"anonymous":false,"inputs":[{"indexed":false,"internalType":"string","name":"value","type":"string"},{"indexed":true,"internalType":"uint256","name":"id","type":"uint256"}],"name":"URI","type":"event"},{"inputs":[{"internalType":"address","name":"account","type":"address"},{"internalType":"uint256","name":"id","type":"uint256"}],"name":"balanceOf","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address[]","name":"accounts","type":"address[]"},{"internalType":"uint256[]","name":"ids","type":"uint256[]"}],"name":"balanceOfBatch","outputs":[{"internalType":"uint256[]","name":"","type":"uint256[]"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"account","type":"address"},{"internalType":"address","name":"operator","type":"address"}],"name":"isApprovedForAll","outputs":[{"internalType":"bool","name":"","type":"bool"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"from","type":"address"},{"internalType":"address","name":"to","type":"address"},{"internalType":"uint256[]","name":"ids","type":"uint256[]"},{"internalType":"uint256[]","name":"amounts","type":"uint256[]"},{"internalType":"bytes","name":"data","type":"bytes"}],"name":"safeBatchTransferFrom","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"internalType":"address","name":"from","type":"address"},{"internalType":"address","name":"to","type":"address"},{"internalType":"uint256","name":"id","type":"uint256"},{"internalType":"uint256","name":"amount","type":"uint256"},{"internalType":"bytes","name":"data","type":"bytes"}],"name":"safeTransferFrom","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"internalType":"address","name":"operator","type":"address"},{"internalType":"bool","name":"approved","type":"bool"}],"name":"setApprovalForAll","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"internalType":"bytes4","name":"interfaceId","type":"bytes4"}],"name":"supportsInterface","outputs":[{"internalType":"bool","name":"","type":"bool"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"uint256","name":"id","type":"uint256"}],"name":"uri","outputs":[{"internalType":"string","name":"","type":"string"}],"stateMutability":"view","type":"function"}]`,
+	},
+	{
+		id:       4,
+		name:     "ERC-4626 Tokenized Vault Standard",
+		addr:     utils.EthZeroAddr,
+		standard: utils.Erc4626,
+		rawAbi: mustHumanABI(
+			"event Transfer(address indexed from, address indexed to, uint256 value)",
+			"event Approval(address indexed owner, address indexed spender, uint256 value)",
+			"event Deposit(address indexed sender, address indexed owner, uint256 assets, uint256 shares)",
+			"event Withdraw(address indexed sender, address indexed receiver, address indexed owner, uint256 assets, uint256 shares)",
+			"function name() view returns (string)",
+			"function symbol() view returns (string)",
+			"function decimals() view returns (uint8)",
+			"function totalSupply() view returns (uint256)",
+			"function balanceOf(address owner) view returns (uint256)",
+			"function allowance(address owner, address spender) view returns (uint256)",
+			"function approve(address spender, uint256 value) returns (bool)",
+			"function transfer(address to, uint256 value) returns (bool)",
+			"function transferFrom(address from, address to, uint256 value) returns (bool)",
+			"function asset() view returns (address assetTokenAddress)",
+			"function totalAssets() view returns (uint256 totalManagedAssets)",
+			"function convertToShares(uint256 assets) view returns (uint256 shares)",
+			"function convertToAssets(uint256 shares) view returns (uint256 assets)",
+			"function maxDeposit(address receiver) view returns (uint256 maxAssets)",
+			"function previewDeposit(uint256 assets) view returns (uint256 shares)",
+			"function deposit(uint256 assets, address receiver) returns (uint256 shares)",
+			"function maxMint(address receiver) view returns (uint256 maxShares)",
+			"function previewMint(uint256 shares) view returns (uint256 assets)",
+			"function mint(uint256 shares, address receiver) returns (uint256 assets)",
+			"function maxWithdraw(address owner) view returns (uint256 maxAssets)",
+			"function previewWithdraw(uint256 assets) view returns (uint256 shares)",
+			"function withdraw(uint256 assets, address receiver, address owner) returns (uint256 shares)",
+			"function maxRedeem(address owner) view returns (uint256 maxShares)",
+			"function previewRedeem(uint256 shares) view returns (uint256 assets)",
+			"function redeem(uint256 shares, address receiver, address owner) returns (uint256 assets)",
+		),
+	},
+	{
+		id:       5,
+		name:     "ERC-777 Token Standard",
+		addr:     utils.EthZeroAddr,
+		standard: utils.Erc777,
+		rawAbi: mustHumanABI(
+			"event Sent(address indexed operator, address indexed from, address indexed to, uint256 amount, bytes data, bytes operatorData)",
+			"event Minted(address indexed operator, address indexed to, uint256 amount, bytes data, bytes operatorData)",
+			"event Burned(address indexed operator, address indexed from, uint256 amount, bytes data, bytes operatorData)",
+			"event AuthorizedOperator(address indexed operator, address indexed tokenHolder)",
+			"event RevokedOperator(address indexed operator, address indexed tokenHolder)",
+			"function name() view returns (string)",
+			"function symbol() view returns (string)",
+			"function granularity() view returns (uint256)",
+			"function totalSupply() view returns (uint256)",
+			"function balanceOf(address holder) view returns (uint256)",
+			"function send(address recipient, uint256 amount, bytes data)",
+			"function burn(uint256 amount, bytes data)",
+			"function isOperatorFor(address operator, address tokenHolder) view returns (bool)",
+			"function authorizeOperator(address operator)",
+			"function revokeOperator(address operator)",
+			"function defaultOperators() view returns (address[])",
+			"function operatorSend(address sender, address recipient, uint256 amount, bytes data, bytes operatorData)",
+			"function operatorBurn(address account, uint256 amount, bytes data, bytes operatorData)",
+		),
+	},
+	{
+		// ERC-2981 defines no events; royalties are read through royaltyInfo.
+		id:       6,
+		name:     "ERC-2981 NFT Royalty Standard",
+		addr:     utils.EthZeroAddr,
+		standard: utils.Erc2981,
+		rawAbi: mustHumanABI(
+			"function royaltyInfo(uint256 tokenId, uint256 salePrice) view returns (address receiver, uint256 royaltyAmount)",
+			"function supportsInterface(bytes4 interfaceId) view returns (bool)",
+		),
+	},
+	{
+		id:       7,
+		name:     "Wrapped Native Currency (WETH9)",
+		addr:     utils.EthZeroAddr,
+		standard: utils.Weth,
+		rawAbi: mustHumanABI(
+			"event Approval(address indexed src, address indexed guy, uint256 wad)",
+			"event Transfer(address indexed src, address indexed dst, uint256 wad)",
+			"event Deposit(address indexed dst, uint256 wad)",
+			"event Withdrawal(address indexed src, uint256 wad)",
+			"function name() view returns (string)",
+			"function symbol() view returns (string)",
+			"function decimals() view returns (uint8)",
+			"function totalSupply() view returns (uint256)",
+			"function balanceOf(address) view returns (uint256)",
+			"function allowance(address, address) view returns (uint256)",
+			"function deposit() payable",
+			"function withdraw(uint256 wad)",
+			"function approve(address guy, uint256 wad) returns (bool)",
+			"function transfer(address dst, uint256 wad) returns (bool)",
+			"function transferFrom(address src, address dst, uint256 wad) returns (bool)",
+		),
 	},
+	{
+		id:       8,
+		name:     "Uniswap V2 Pair",
+		addr:     utils.EthZeroAddr,
+		standard: utils.UniswapV2Pair,
+		rawAbi: mustHumanABI(
+			"event Approval(address indexed owner, address indexed spender, uint256 value)",
+			"event Transfer(address indexed from, address indexed to, uint256 value)",
+			"event Mint(address indexed sender, uint256 amount0, uint256 amount1)",
+			"event Burn(address indexed sender, uint256 amount0, uint256 amount1, address indexed to)",
+			"event Swap(address indexed sender, uint256 amount0In, uint256 amount1In, uint256 amount0Out, uint256 amount1Out, address indexed to)",
+			"event Sync(uint112 reserve0, uint112 reserve1)",
+			"function name() view returns (string)",
+			"function symbol() view returns (string)",
+			"function decimals() view returns (uint8)",
+			"function totalSupply() view returns (uint256)",
+			"function balanceOf(address owner) view returns (uint256)",
+			"function allowance(address owner, address spender) view returns (uint256)",
+			"function approve(address spender, uint256 value) returns (bool)",
+			"function transfer(address to, uint256 value) returns (bool)",
+			"function transferFrom(address from, address to, uint256 value) returns (bool)",
+			"function factory() view returns (address)",
+			"function token0() view returns (address)",
+			"function token1() view returns (address)",
+			"function getReserves() view returns (uint112 reserve0, uint112 reserve1, uint32 blockTimestampLast)",
+			"function price0CumulativeLast() view returns (uint256)",
+			"function price1CumulativeLast() view returns (uint256)",
+			"function kLast() view returns (uint256)",
+			"function mint(address to) returns (uint256 liquidity)",
+			"function burn(address to) returns (uint256 amount0, uint256 amount1)",
+			"function swap(uint256 amount0Out, uint256 amount1Out, address to, bytes data)",
+			"function skim(address to)",
+			"function sync()",
+		),
+	},
+	{
+		id:       9,
+		name:     "Uniswap V2 Factory",
+		addr:     utils.EthZeroAddr,
+		standard: utils.UniswapV2Factory,
+		rawAbi: mustHumanABI(
+			"event PairCreated(address indexed token0, address indexed token1, address pair, uint256)",
+			"function feeTo() view returns (address)",
+			"function feeToSetter() view returns (address)",
+			"function getPair(address tokenA, address tokenB) view returns (address pair)",
+			"function allPairs(uint256) view returns (address pair)",
+			"function allPairsLength() view returns (uint256)",
+			"function createPair(address tokenA, address tokenB) returns (address pair)",
+			"function setFeeTo(address)",
+			"function setFeeToSetter(address)",
+		),
+	},
+	{
+		id:       10,
+		name:     "Uniswap V3 Pool",
+		addr:     utils.EthZeroAddr,
+		standard: utils.UniswapV3Pool,
+		rawAbi: mustHumanABI(
+			"event Initialize(uint160 sqrtPriceX96, int24 tick)",
+			"event Mint(address sender, address indexed owner, int24 indexed tickLower, int24 indexed tickUpper, uint128 amount, uint256 amount0, uint256 amount1)",
+			"event Collect(address indexed owner, address recipient, int24 indexed tickLower, int24 indexed tickUpper, uint128 amount0, uint128 amount1)",
+			"event Burn(address indexed owner, int24 indexed tickLower, int24 indexed tickUpper, uint128 amount, uint256 amount0, uint256 amount1)",
+			"event Swap(address indexed sender, address indexed recipient, int256 amount0, int256 amount1, uint160 sqrtPriceX96, uint128 liquidity, int24 tick)",
+			"event Flash(address indexed sender, address indexed recipient, uint256 amount0, uint256 amount1, uint256 paid0, uint256 paid1)",
+			"event IncreaseObservationCardinalityNext(uint16 observationCardinalityNextOld, uint16 observationCardinalityNextNew)",
+			"event SetFeeProtocol(uint8 feeProtocol0Old, uint8 feeProtocol1Old, uint8 feeProtocol0New, uint8 feeProtocol1New)",
+			"event CollectProtocol(address indexed sender, address indexed recipient, uint128 amount0, uint128 amount1)",
+			"function factory() view returns (address)",
+			"function token0() view returns (address)",
+			"function token1() view returns (address)",
+			"function fee() view returns (uint24)",
+			"function tickSpacing() view returns (int24)",
+			"function liquidity() view returns (uint128)",
+			"function slot0() view returns (uint160 sqrtPriceX96, int24 tick, uint16 observationIndex, uint16 observationCardinality, uint16 observationCardinalityNext, uint8 feeProtocol, bool unlocked)",
+			"function initialize(uint160 sqrtPriceX96)",
+			"function mint(address recipient, int24 tickLower, int24 tickUpper, uint128 amount, bytes data) returns (uint256 amount0, uint256 amount1)",
+			"function collect(address recipient, int24 tickLower, int24 tickUpper, uint128 amount0Requested, uint128 amount1Requested) returns (uint128 amount0, uint128 amount1)",
+			"function burn(int24 tickLower, int24 tickUpper, uint128 amount) returns (uint256 amount0, uint256 amount1)",
+			"function swap(address recipient, bool zeroForOne, int256 amountSpecified, uint160 sqrtPriceLimitX96, bytes data) returns (int256 amount0, int256 amount1)",
+			"function flash(address recipient, uint256 amount0, uint256 amount1, bytes data)",
+		),
+	},
+	{
+		id:       11,
+		name:     "Uniswap V3 Factory",
+		addr:     utils.EthZeroAddr,
+		standard: utils.UniswapV3Factory,
+		rawAbi: mustHumanABI(
+			"event OwnerChanged(address indexed oldOwner, address indexed newOwner)",
+			"event PoolCreated(address indexed token0, address indexed token1, uint24 indexed fee, int24 tickSpacing, address pool)",
+			"event FeeAmountEnabled(uint24 indexed fee, int24 indexed tickSpacing)",
+			"function owner() view returns (address)",
+			"function feeAmountTickSpacing(uint24 fee) view returns (int24)",
+			"function getPool(address tokenA, address tokenB, uint24 fee) view returns (address pool)",
+			"function createPool(address tokenA, address tokenB, uint24 fee) returns (address pool)",
+			"function setOwner(address _owner)",
+			"function enableFeeAmount(uint24 fee, int24 tickSpacing)",
+		),
+	},
+}
+
+// mustHumanABI converts human-readable ABI fragments into a JSON ABI, panicking on invalid fragments.
+// It is only meant for the static default contracts.
+func mustHumanABI(fragments ...string) string {
+	rawAbi, err := utils.HumanABIToJSON(fragments...)
+	if err != nil {
+		panic(fmt.Sprintf("invalid default contract abi: %v", err))
+	}
+	return rawAbi
 }
 
-// LoadDefaults loads default contracts for different networks into the manager.
+// LoadDefaults loads copies of the default standard contracts into the manager under AnyNetwork, so managers
+// never share the contracts they validate and index.
 func (m *Manager) LoadDefaults() error {
 	var combinedErr error
 
-	for networkId, contracts := range defaultContracts {
-		for _, contract := range contracts {
-			if err := m.Register(networkId, contract); err != nil {
-				// Instead of wrapping a potentially nil error, check for err and combine it
-				if combinedErr == nil {
-					combinedErr = errors.Wrapf(err, "failed to register contract: %s on network: %d", contract.name, networkId)
-				} else {
-					combinedErr = errors.Wrapf(combinedErr, "failed to register contract: %s on network: %d", contract.name, networkId)
-				}
+	for _, contract := range defaultContracts {
+		if err := m.Register(AnyNetwork, contract.WithAddress(contract.id, contract.addr)); err != nil {
+			// Instead of wrapping a potentially nil error, check for err and combine it
+			if combinedErr == nil {
+				combinedErr = errors.Wrapf(err, "failed to register contract: %s", contract.name)
+			} else {
+				combinedErr = errors.Wrapf(combinedErr, "failed to register contract: %s", contract.name)
 			}
 		}
 	}
@@ -146,7 +146,7 @@ func decodeTopic(topic common.Hash, argument abi.Argument) (interface{}, error)
 			return nil, fmt.Errorf("unsupported integer size: %d", size)
 		}
 		integer := new(big.Int).SetBytes(topic[:])
-		if argument.Type.T == abi.IntTy && size < 256 {
+		if argument.Type.T == abi.IntTy {
 			integer = adjustIntSize(integer, size)
 		}
 		return integer, nil
@@ -173,15 +173,12 @@ func GetEthereumTopicByName(name string, topics []EthereumTopic) *EthereumTopic
 	return nil
 }
 
-// adjustIntSize adjusts the size of an integer to match its ABI-specified size, which is relevant
-// for signed integers smaller than 256 bits. This function ensures the integer is correctly
-// interpreted according to its defined bit size in the ABI.
+// adjustIntSize interprets a signed integer topic according to its ABI-specified size. Indexed signed
+// integers are sign-extended to 256 bits, so negative values of any size carry the sign in the topic's top bit
+// and are recovered by subtracting 2^256.
 func adjustIntSize(integer *big.Int, size int) *big.Int {
-	if size == 256 || integer.Bit(size-1) == 0 {
+	if size <= 0 || size > 256 || integer.Bit(255) == 0 {
 		return integer
 	}
-	if size <= 0 || size > 256 {
-		return integer
-	}
-	return new(big.Int).Sub(integer, new(big.Int).Lsh(big.NewInt(1), uint(size)))
+	return new(big.Int).Sub(integer, new(big.Int).Lsh(big.NewInt(1), 256))
 }
@@ -1 +1,39 @@
 package decoder
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeTopicIntegers(t *testing.T) {
+	tests := []struct {
+		name     string
+		typ      string
+		value    *big.Int
+		expected *big.Int
+	}{
+		{name: "Negative int24", typ: "int24", value: big.NewInt(-887272), expected: big.NewInt(-887272)},
+		{name: "Positive int24", typ: "int24", value: big.NewInt(887272), expected: big.NewInt(887272)},
+		{name: "Minimum int8", typ: "int8", value: big.NewInt(-128), expected: big.NewInt(-128)},
+		{name: "Negative int256", typ: "int256", value: big.NewInt(-1), expected: big.NewInt(-1)},
+		{name: "Large uint256", typ: "uint256", value: math.MaxBig256, expected: math.MaxBig256},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			typ, err := abi.NewType(tt.typ, "", nil)
+			require.NoError(t, err)
+
+			// Indexed integers are sign-extended to 32 bytes, as abi.encode does.
+			topic := common.BytesToHash(math.U256Bytes(new(big.Int).Set(tt.value)))
+			decoded, err := decodeTopic(topic, abi.Argument{Name: "value", Type: typ, Indexed: true})
+			require.NoError(t, err)
+			require.Equal(t, 0, tt.expected.Cmp(decoded.(*big.Int)), "decoded %s", decoded)
+		})
+	}
+}
@@ -0,0 +1,263 @@
+package decoder
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/enviodev/hypersync-client-go/contracts"
+	"github.com/enviodev/hypersync-client-go/types"
+	"github.com/enviodev/hypersync-client-go/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/stretchr/testify/require"
+)
+
+// newStandardLog builds a log the way HyperSync returns it: topic0, the indexed topics and the ABI encoded data.
+func newStandardLog(t *testing.T, contract *contracts.Contract, eventName string, address common.Address, indexed []common.Hash, values ...any) types.Log {
+	t.Helper()
+
+	event, ok := contract.ABI().Events[eventName]
+	require.True(t, ok, "event %s not found in %s", eventName, contract.Name())
+
+	data, err := event.Inputs.NonIndexed().Pack(values...)
+	require.NoError(t, err)
+
+	log := newTestLog(append([]common.Hash{event.ID}, indexed...), data)
+	log.Address = &address
+	return log
+}
+
+func addrTopic(addr common.Address) common.Hash {
+	return common.BytesToHash(addr.Bytes())
+}
+
+func intTopic(value int64) common.Hash {
+	return common.BytesToHash(math.U256Bytes(big.NewInt(value)))
+}
+
+func TestDecodeStandardLogs(t *testing.T) {
+	manager, err := contracts.NewManagerWithDefaults()
+	require.NoError(t, err)
+
+	standard := func(s utils.Standard) *contracts.Contract {
+		contract, sErr := manager.GetByStandard(contracts.AnyNetwork, s)
+		require.NoError(t, sErr)
+		return contract
+	}
+
+	sender := common.HexToAddress("0x3fC91A3afd70395Cd496C647d5a6CC9D4B2b7FAD")
+	owner := common.HexToAddress("0x28C6c06298d514Db089934071355E5743bf21d60")
+	weth := common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+	usdc := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	vault := common.HexToAddress("0x83F20F44975D03b1b09e64809B757c47f942BEeA")
+	erc777 := common.HexToAddress("0x1985365e9f78359a9B6AD760e32412f4a445E862")
+	v2Pair := common.HexToAddress("0xB4e16d0168e52d35CaCD2c6185b44281Ec28C9Dc")
+	v2Factory := common.HexToAddress("0x5C69bEe701ef814a2B6a3EDD4B1652CB9cc5aA6f")
+	v3Pool := common.HexToAddress("0x88e6A0c2dDD26FEEb64F039a2c41296FcB3f5640")
+	v3Factory := common.HexToAddress("0x1F98431c8aD98523631AE4a59f267346ea31F984")
+
+	tests := []struct {
+		name     string
+		standard utils.Standard
+		log      types.Log
+		data     map[string]any
+		topics   map[string]any
+	}{
+		{
+			name:     "ERC-4626 Deposit",
+			standard: utils.Erc4626,
+			log: newStandardLog(t, standard(utils.Erc4626), "Deposit", vault,
+				[]common.Hash{addrTopic(sender), addrTopic(owner)},
+				big.NewInt(5_000_000_000_000_000_000), big.NewInt(4_712_345_678_901_234_567),
+			),
+			data:   map[string]any{"assets": big.NewInt(5_000_000_000_000_000_000), "shares": big.NewInt(4_712_345_678_901_234_567)},
+			topics: map[string]any{"sender": sender, "owner": owner},
+		},
+		{
+			name:     "ERC-4626 Withdraw",
+			standard: utils.Erc4626,
+			log: newStandardLog(t, standard(utils.Erc4626), "Withdraw", vault,
+				[]common.Hash{addrTopic(sender), addrTopic(owner), addrTopic(owner)},
+				big.NewInt(1_000_000), big.NewInt(942_000),
+			),
+			data:   map[string]any{"assets": big.NewInt(1_000_000), "shares": big.NewInt(942_000)},
+			topics: map[string]any{"receiver": owner, "owner": owner},
+		},
+		{
+			name:     "ERC-777 Sent",
+			standard: utils.Erc777,
+			log: newStandardLog(t, standard(utils.Erc777), "Sent", erc777,
+				[]common.Hash{addrTopic(sender), addrTopic(sender), addrTopic(owner)},
+				big.NewInt(250_000_000_000_000_000), []byte{0xca, 0xfe}, []byte{},
+			),
+			data:   map[string]any{"amount": big.NewInt(250_000_000_000_000_000), "data": []byte{0xca, 0xfe}, "operatorData": []byte{}},
+			topics: map[string]any{"operator": sender, "from": sender, "to": owner},
+		},
+		{
+			name:     "ERC-777 Minted",
+			standard: utils.Erc777,
+			log: newStandardLog(t, standard(utils.Erc777), "Minted", erc777,
+				[]common.Hash{addrTopic(sender), addrTopic(owner)},
+				big.NewInt(1_000), []byte{}, []byte{},
+			),
+			data:   map[string]any{"amount": big.NewInt(1_000)},
+			topics: map[string]any{"operator": sender, "to": owner},
+		},
+		{
+			name:     "ERC-777 Burned",
+			standard: utils.Erc777,
+			log: newStandardLog(t, standard(utils.Erc777), "Burned", erc777,
+				[]common.Hash{addrTopic(sender), addrTopic(owner)},
+				big.NewInt(42), []byte{}, []byte{0x01},
+			),
+			data:   map[string]any{"amount": big.NewInt(42), "operatorData": []byte{0x01}},
+			topics: map[string]any{"operator": sender, "from": owner},
+		},
+		{
+			name:     "WETH Deposit",
+			standard: utils.Weth,
+			log: newStandardLog(t, standard(utils.Weth), "Deposit", weth,
+				[]common.Hash{addrTopic(sender)},
+				big.NewInt(1_500_000_000_000_000_000),
+			),
+			data:   map[string]any{"wad": big.NewInt(1_500_000_000_000_000_000)},
+			topics: map[string]any{"dst": sender},
+		},
+		{
+			name:     "WETH Withdrawal",
+			standard: utils.Weth,
+			log: newStandardLog(t, standard(utils.Weth), "Withdrawal", weth,
+				[]common.Hash{addrTopic(sender)},
+				big.NewInt(300_000_000_000_000_000),
+			),
+			data:   map[string]any{"wad": big.NewInt(300_000_000_000_000_000)},
+			topics: map[string]any{"src": sender},
+		},
+		{
+			name:     "Uniswap V2 Swap",
+			standard: utils.UniswapV2Pair,
+			log: newStandardLog(t, standard(utils.UniswapV2Pair), "Swap", v2Pair,
+				[]common.Hash{addrTopic(sender), addrTopic(owner)},
+				big.NewInt(2_500_000_000), big.NewInt(0), big.NewInt(0), big.NewInt(1_019_735_842_315_431_213),
+			),
+			data:   map[string]any{"amount0In": big.NewInt(2_500_000_000), "amount1Out": big.NewInt(1_019_735_842_315_431_213)},
+			topics: map[string]any{"sender": sender, "to": owner},
+		},
+		{
+			name:     "Uniswap V2 Sync",
+			standard: utils.UniswapV2Pair,
+			log: newStandardLog(t, standard(utils.UniswapV2Pair), "Sync", v2Pair, nil,
+				big.NewInt(28_543_219_876_543), new(big.Int).Mul(big.NewInt(11_652), big.NewInt(1_000_000_000_000_000_000)),
+			),
+			data: map[string]any{"reserve0": big.NewInt(28_543_219_876_543), "reserve1": new(big.Int).Mul(big.NewInt(11_652), big.NewInt(1_000_000_000_000_000_000))},
+		},
+		{
+			name:     "Uniswap V2 Mint",
+			standard: utils.UniswapV2Pair,
+			log: newStandardLog(t, standard(utils.UniswapV2Pair), "Mint", v2Pair,
+				[]common.Hash{addrTopic(sender)},
+				big.NewInt(10_000_000), big.NewInt(4_000_000_000_000_000),
+			),
+			data:   map[string]any{"amount0": big.NewInt(10_000_000), "amount1": big.NewInt(4_000_000_000_000_000)},
+			topics: map[string]any{"sender": sender},
+		},
+		{
+			name:     "Uniswap V2 Burn",
+			standard: utils.UniswapV2Pair,
+			log: newStandardLog(t, standard(utils.UniswapV2Pair), "Burn", v2Pair,
+				[]common.Hash{addrTopic(sender), addrTopic(owner)},
+				big.NewInt(10_000_000), big.NewInt(4_000_000_000_000_000),
+			),
+			data:   map[string]any{"amount0": big.NewInt(10_000_000)},
+			topics: map[string]any{"sender": sender, "to": owner},
+		},
+		{
+			name:     "Uniswap V2 PairCreated",
+			standard: utils.UniswapV2Factory,
+			log: newStandardLog(t, standard(utils.UniswapV2Factory), "PairCreated", v2Factory,
+				[]common.Hash{addrTopic(usdc), addrTopic(weth)},
+				v2Pair, big.NewInt(12),
+			),
+			data:   map[string]any{"pair": v2Pair},
+			topics: map[string]any{"token0": usdc, "token1": weth},
+		},
+		{
+			name:     "Uniswap V3 Swap",
+			standard: utils.UniswapV3Pool,
+			log: newStandardLog(t, standard(utils.UniswapV3Pool), "Swap", v3Pool,
+				[]common.Hash{addrTopic(sender), addrTopic(owner)},
+				big.NewInt(-3_000_000_000), big.NewInt(1_234_567_890_123_456_789),
+				new(big.Int).Lsh(big.NewInt(1), 96), big.NewInt(17_000_000_000_000_000), big.NewInt(-201_234),
+			),
+			data: map[string]any{
+				"amount0": big.NewInt(-3_000_000_000),
+				"amount1": big.NewInt(1_234_567_890_123_456_789),
+				"tick":    big.NewInt(-201_234),
+			},
+			topics: map[string]any{"sender": sender, "recipient": owner},
+		},
+		{
+			name:     "Uniswap V3 Mint",
+			standard: utils.UniswapV3Pool,
+			log: newStandardLog(t, standard(utils.UniswapV3Pool), "Mint", v3Pool,
+				[]common.Hash{addrTopic(owner), intTopic(-887_220), intTopic(887_220)},
+				sender, big.NewInt(1_000_000), big.NewInt(500_000), big.NewInt(250_000),
+			),
+			data:   map[string]any{"sender": sender, "amount": big.NewInt(1_000_000)},
+			topics: map[string]any{"owner": owner, "tickLower": big.NewInt(-887_220), "tickUpper": big.NewInt(887_220)},
+		},
+		{
+			name:     "Uniswap V3 Burn",
+			standard: utils.UniswapV3Pool,
+			log: newStandardLog(t, standard(utils.UniswapV3Pool), "Burn", v3Pool,
+				[]common.Hash{addrTopic(owner), intTopic(-60), intTopic(60)},
+				big.NewInt(1_000_000), big.NewInt(500_000), big.NewInt(250_000),
+			),
+			data:   map[string]any{"amount": big.NewInt(1_000_000)},
+			topics: map[string]any{"tickLower": big.NewInt(-60), "tickUpper": big.NewInt(60)},
+		},
+		{
+			name:     "Uniswap V3 PoolCreated",
+			standard: utils.UniswapV3Factory,
+			log: newStandardLog(t, standard(utils.UniswapV3Factory), "PoolCreated", v3Factory,
+				[]common.Hash{addrTopic(usdc), addrTopic(weth), intTopic(500)},
+				big.NewInt(10), v3Pool,
+			),
+			data:   map[string]any{"tickSpacing": big.NewInt(10), "pool": v3Pool},
+			topics: map[string]any{"token0": usdc, "token1": weth, "fee": big.NewInt(500)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Defaults are network-agnostic, so resolution works on any network.
+			contract, rErr := manager.Resolve(utils.BaseNetworkID, tt.log)
+			require.NoError(t, rErr)
+			require.Equal(t, tt.standard, contract.Standard())
+
+			decoded, dErr := DecodeEthereumLogWithContract(tt.log, contract)
+			require.NoError(t, dErr)
+
+			for name, expected := range tt.data {
+				require.Equal(t, expected, decoded.Data[name], "data argument %s", name)
+			}
+			for name, expected := range tt.topics {
+				topic := GetEthereumTopicByName(name, decoded.Topics)
+				require.NotNil(t, topic, "topic %s", name)
+				require.Equal(t, expected, topic.Value, "topic %s", name)
+			}
+		})
+	}
+
+	t.Run("ERC-2981 royaltyInfo", func(t *testing.T) {
+		// ERC-2981 defines no events, royalties are read through the royaltyInfo call.
+		royaltyInfo := standard(utils.Erc2981).ABI().Methods["royaltyInfo"]
+		output, pErr := royaltyInfo.Outputs.Pack(owner, big.NewInt(75_000_000_000_000_000))
+		require.NoError(t, pErr)
+
+		values, uErr := royaltyInfo.Outputs.Unpack(output)
+		require.NoError(t, uErr)
+		require.Equal(t, owner, values[0])
+		require.Equal(t, big.NewInt(75_000_000_000_000_000), values[1])
+	})
+}
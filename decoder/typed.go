@@ -0,0 +1,277 @@
+package decoder
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/enviodev/hypersync-client-go/contracts"
+	"github.com/enviodev/hypersync-client-go/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/pkg/errors"
+)
+
+// abiTag is the struct tag used to map ABI argument names onto struct fields, e.g. `abi:"from"`.
+const abiTag = "abi"
+
+// bigIntType is the reflected type of big.Int, used to convert 256-bit values into fixed size integers.
+var bigIntType = reflect.TypeOf(big.Int{})
+
+// DecodeInto decodes an Ethereum event log into a new value of struct type T using the provided ABI.
+// The event is selected by the log's topic0. Indexed and non-indexed arguments are mapped onto struct
+// fields either by their `abi:"name"` tag or, when untagged, by a case-insensitive match of the field name.
+//
+// Example:
+//
+//	type Transfer struct {
+//	    From  common.Address `abi:"from"`
+//	    To    common.Address `abi:"to"`
+//	    Value *big.Int       `abi:"value"`
+//	}
+//
+//	transfer, err := decoder.DecodeInto[Transfer](log, contractAbi)
+//	if err != nil {
+//	    log.Fatalf("Failed to decode log: %v", err)
+//	}
+func DecodeInto[T any](log types.Log, contractAbi *abi.ABI) (*T, error) {
+	if contractAbi == nil {
+		return nil, errors.New("abi is nil")
+	}
+
+	topics := log.Topics()
+	if len(topics) < 1 {
+		return nil, errors.New("log is nil or has no topics")
+	}
+
+	event, err := contractAbi.EventByID(topics[0])
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get event by topic0: %s", topics[0].Hex())
+	}
+
+	return decodeEventInto[T](log, event)
+}
+
+// DecodeIntoWithContract decodes an Ethereum event log into a new value of struct type T using the contract's ABI.
+func DecodeIntoWithContract[T any](log types.Log, contract *contracts.Contract) (*T, error) {
+	contractAbi, err := contract.ToABI()
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodeInto[T](log, &contractAbi)
+}
+
+// DecodeLogsInto decodes every log emitted for the named event into a slice of T.
+// Logs belonging to any other event are skipped, while logs of the named event that fail to decode return an error.
+//
+// Example:
+//
+//	transfers, err := decoder.DecodeLogsInto[Transfer](response.GetLogs(), contractAbi, "Transfer")
+//	if err != nil {
+//	    log.Fatalf("Failed to decode logs: %v", err)
+//	}
+func DecodeLogsInto[T any](logs []types.Log, contractAbi *abi.ABI, eventName string) ([]T, error) {
+	if contractAbi == nil {
+		return nil, errors.New("abi is nil")
+	}
+
+	event, ok := contractAbi.Events[eventName]
+	if !ok {
+		return nil, fmt.Errorf("event %q not found in abi", eventName)
+	}
+
+	toReturn := make([]T, 0)
+	for i, log := range logs {
+		if log.Topic0 == nil || *log.Topic0 != event.ID {
+			continue
+		}
+
+		decoded, err := decodeEventInto[T](log, &event)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode log [%d]", i)
+		}
+		toReturn = append(toReturn, *decoded)
+	}
+
+	return toReturn, nil
+}
+
+// DecodeResponseInto decodes every log in the query response emitted for the named event into a slice of T.
+func DecodeResponseInto[T any](response *types.QueryResponse, contractAbi *abi.ABI, eventName string) ([]T, error) {
+	if response == nil {
+		return nil, errors.New("query response is nil")
+	}
+
+	return DecodeLogsInto[T](response.GetLogs(), contractAbi, eventName)
+}
+
+// decodeEventInto unpacks the log topics and data for the given event and assigns them onto a new T.
+func decodeEventInto[T any](log types.Log, event *abi.Event) (*T, error) {
+	toReturn := new(T)
+	target := reflect.ValueOf(toReturn).Elem()
+	if target.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("decode target must be a struct, got %s", target.Type())
+	}
+
+	indexed := make(abi.Arguments, 0)
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+
+	topics := log.Topics()
+	if len(topics) != len(indexed)+1 {
+		return nil, fmt.Errorf(
+			"event %s expects %d topics, log has %d", event.Sig, len(indexed)+1, len(topics),
+		)
+	}
+
+	values := make(map[string]any)
+	if err := abi.ParseTopicsIntoMap(values, indexed, topics[1:]); err != nil {
+		return nil, errors.Wrap(err, "failed to parse indexed topics")
+	}
+
+	// Events without non-indexed inputs carry no data. Otherwise missing or truncated data fails to unpack
+	// instead of leaving the fields zero.
+	if len(event.Inputs.NonIndexed()) > 0 {
+		if err := event.Inputs.UnpackIntoMap(values, log.GetData()); err != nil {
+			return nil, errors.Wrap(err, "failed to unpack inputs into map")
+		}
+	}
+
+	if err := assignArguments(event, values, target); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode event %s into %s", event.Name, target.Type())
+	}
+
+	return toReturn, nil
+}
+
+// assignArguments maps decoded argument values onto the exported fields of the target struct.
+func assignArguments(event *abi.Event, values map[string]any, target reflect.Value) error {
+	targetType := target.Type()
+
+	for i := 0; i < targetType.NumField(); i++ {
+		field := targetType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag, tagged := field.Tag.Lookup(abiTag)
+		if tag == "-" {
+			continue
+		}
+
+		argName, found := lookupArgument(event, field.Name, tag, tagged)
+		if !found {
+			if tagged {
+				return fmt.Errorf("field %s references unknown argument %q", field.Name, tag)
+			}
+			continue
+		}
+
+		value, ok := values[argName]
+		if !ok || value == nil {
+			continue
+		}
+
+		if err := setValue(target.Field(i), reflect.ValueOf(value)); err != nil {
+			return fmt.Errorf("argument %q cannot be assigned to field %s: %w", argName, field.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// lookupArgument finds the event argument backing a struct field, by tag when present or by field name otherwise.
+func lookupArgument(event *abi.Event, fieldName string, tag string, tagged bool) (string, bool) {
+	for _, input := range event.Inputs {
+		if tagged {
+			if input.Name == tag {
+				return input.Name, true
+			}
+			continue
+		}
+
+		if strings.EqualFold(input.Name, fieldName) || abi.ToCamelCase(input.Name) == fieldName {
+			return input.Name, true
+		}
+	}
+	return "", false
+}
+
+// setValue assigns src onto dst, converting between pointer, big.Int and fixed size integer representations.
+func setValue(dst reflect.Value, src reflect.Value) error {
+	if src.Type().AssignableTo(dst.Type()) {
+		dst.Set(src)
+		return nil
+	}
+
+	switch {
+	case dst.Kind() == reflect.Pointer:
+		elem := reflect.New(dst.Type().Elem())
+		if err := setValue(elem.Elem(), src); err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+	case src.Kind() == reflect.Pointer:
+		if src.IsNil() {
+			return nil
+		}
+		if src.Type().Elem() == bigIntType && isIntegerKind(dst.Kind()) {
+			return setInteger(dst, src.Interface().(*big.Int))
+		}
+		return setValue(dst, src.Elem())
+	case isIntegerKind(src.Kind()) && dst.Type() == bigIntType:
+		dst.Set(reflect.ValueOf(*integerToBig(src)))
+		return nil
+	case isIntegerKind(src.Kind()) && isIntegerKind(dst.Kind()):
+		return setInteger(dst, integerToBig(src))
+	case src.Kind() == dst.Kind() && (src.Kind() == reflect.Array || src.Kind() == reflect.Struct || src.Kind() == reflect.Slice):
+		if src.Type().ConvertibleTo(dst.Type()) {
+			dst.Set(src.Convert(dst.Type()))
+			return nil
+		}
+	}
+
+	return fmt.Errorf("type mismatch: cannot use %s as %s", src.Type(), dst.Type())
+}
+
+// setInteger assigns a big integer onto a fixed size integer value, failing when the value overflows.
+func setInteger(dst reflect.Value, value *big.Int) error {
+	switch dst.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if !value.IsInt64() || dst.OverflowInt(value.Int64()) {
+			return fmt.Errorf("value %s overflows %s", value, dst.Type())
+		}
+		dst.SetInt(value.Int64())
+	default:
+		if !value.IsUint64() || dst.OverflowUint(value.Uint64()) {
+			return fmt.Errorf("value %s overflows %s", value, dst.Type())
+		}
+		dst.SetUint(value.Uint64())
+	}
+	return nil
+}
+
+// integerToBig converts any reflected integer value into a big.Int.
+func integerToBig(value reflect.Value) *big.Int {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(value.Int())
+	default:
+		return new(big.Int).SetUint64(value.Uint())
+	}
+}
+
+// isIntegerKind reports whether the kind is a signed or unsigned fixed size integer.
+func isIntegerKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
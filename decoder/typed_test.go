@@ -0,0 +1,141 @@
+package decoder
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/enviodev/hypersync-client-go/types"
+	"github.com/enviodev/hypersync-client-go/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+type erc20Transfer struct {
+	From  common.Address `abi:"from"`
+	To    common.Address `abi:"to"`
+	Value *big.Int       `abi:"value"`
+}
+
+type erc20TransferUntagged struct {
+	From   common.Address
+	To     *common.Address
+	Value  uint64
+	Ignore string `abi:"-"`
+}
+
+type erc20TransferWrongType struct {
+	From  string   `abi:"from"`
+	Value *big.Int `abi:"value"`
+}
+
+type erc20TransferUnknownArg struct {
+	Amount *big.Int `abi:"amount"`
+}
+
+type paused struct {
+	From common.Address `abi:"from"`
+}
+
+func newTestLog(topics []common.Hash, data []byte) types.Log {
+	log := types.Log{Data: &data}
+	targets := []**common.Hash{&log.Topic0, &log.Topic1, &log.Topic2, &log.Topic3}
+	for i := range topics {
+		topic := topics[i]
+		*targets[i] = &topic
+	}
+	return log
+}
+
+func TestDecodeInto(t *testing.T) {
+	contractAbi, err := utils.ParseHumanABI(
+		"event Transfer(address indexed from, address indexed to, uint256 value)",
+		"event Approval(address indexed owner, address indexed spender, uint256 value)",
+		"event Paused(address indexed from)",
+	)
+	require.NoError(t, err)
+
+	from := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	to := common.HexToAddress("0x00000000000000000000000000000000000000bb")
+	data, err := contractAbi.Events["Transfer"].Inputs.NonIndexed().Pack(big.NewInt(1000))
+	require.NoError(t, err)
+
+	transferLog := newTestLog([]common.Hash{
+		contractAbi.Events["Transfer"].ID,
+		common.BytesToHash(from.Bytes()),
+		common.BytesToHash(to.Bytes()),
+	}, data)
+
+	t.Run("Tagged struct", func(t *testing.T) {
+		decoded, dErr := DecodeInto[erc20Transfer](transferLog, contractAbi)
+		require.NoError(t, dErr)
+		require.Equal(t, from, decoded.From)
+		require.Equal(t, to, decoded.To)
+		require.Equal(t, big.NewInt(1000), decoded.Value)
+	})
+
+	t.Run("Untagged struct with conversions", func(t *testing.T) {
+		decoded, dErr := DecodeInto[erc20TransferUntagged](transferLog, contractAbi)
+		require.NoError(t, dErr)
+		require.Equal(t, from, decoded.From)
+		require.Equal(t, to, *decoded.To)
+		require.Equal(t, uint64(1000), decoded.Value)
+		require.Empty(t, decoded.Ignore)
+	})
+
+	t.Run("Type mismatch", func(t *testing.T) {
+		_, dErr := DecodeInto[erc20TransferWrongType](transferLog, contractAbi)
+		require.ErrorContains(t, dErr, "type mismatch")
+	})
+
+	t.Run("Unknown tagged argument", func(t *testing.T) {
+		_, dErr := DecodeInto[erc20TransferUnknownArg](transferLog, contractAbi)
+		require.ErrorContains(t, dErr, "unknown argument")
+	})
+
+	t.Run("Topic arity mismatch", func(t *testing.T) {
+		erc721Log := newTestLog([]common.Hash{
+			contractAbi.Events["Transfer"].ID,
+			common.BytesToHash(from.Bytes()),
+			common.BytesToHash(to.Bytes()),
+			common.BigToHash(big.NewInt(1)),
+		}, nil)
+		_, dErr := DecodeInto[erc20Transfer](erc721Log, contractAbi)
+		require.ErrorContains(t, dErr, "expects 3 topics")
+	})
+
+	t.Run("Missing or truncated data", func(t *testing.T) {
+		for _, logData := range [][]byte{nil, data[:16]} {
+			log := newTestLog([]common.Hash{
+				contractAbi.Events["Transfer"].ID,
+				common.BytesToHash(from.Bytes()),
+				common.BytesToHash(to.Bytes()),
+			}, logData)
+			_, dErr := DecodeInto[erc20Transfer](log, contractAbi)
+			require.ErrorContains(t, dErr, "failed to unpack inputs")
+		}
+	})
+
+	t.Run("Indexed inputs only", func(t *testing.T) {
+		pausedLog := newTestLog([]common.Hash{contractAbi.Events["Paused"].ID, common.BytesToHash(from.Bytes())}, nil)
+		decoded, dErr := DecodeInto[paused](pausedLog, contractAbi)
+		require.NoError(t, dErr)
+		require.Equal(t, from, decoded.From)
+	})
+
+	t.Run("Decode logs by event name", func(t *testing.T) {
+		approvalLog := newTestLog([]common.Hash{
+			contractAbi.Events["Approval"].ID,
+			common.BytesToHash(from.Bytes()),
+			common.BytesToHash(to.Bytes()),
+		}, data)
+
+		decoded, dErr := DecodeResponseInto[erc20Transfer](&types.QueryResponse{
+			Data: types.DataResponse{Logs: []types.Log{transferLog, approvalLog, transferLog}},
+		}, contractAbi, "Transfer")
+		require.NoError(t, dErr)
+		require.Len(t, decoded, 2)
+
+		_, dErr = DecodeLogsInto[erc20Transfer]([]types.Log{transferLog}, contractAbi, "Unknown")
+		require.Error(t, dErr)
+	})
+}
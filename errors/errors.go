@@ -5,4 +5,10 @@ import "errors"
 var (
 	ErrContractNotFound = errors.New("contract not found")
 	ErrWorkerCompleted  = errors.New("worker completed")
+	ErrRPCNotConfigured = errors.New("rpc endpoint not configured")
+	ErrChainMismatch    = errors.New("chain id mismatch")
+
+	// ErrUnsupportedTransactionType is returned when converting a transaction of a type go-ethereum does not
+	// support, such as OP-stack deposit transactions.
+	ErrUnsupportedTransactionType = errors.New("unsupported transaction type")
 )
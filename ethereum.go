@@ -0,0 +1,355 @@
+package hypersyncgo
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/enviodev/hypersync-client-go/types"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/pkg/errors"
+)
+
+// DefaultPollInterval is the interval at which EthereumAdapter subscriptions poll the archive height.
+const DefaultPollInterval = 2 * time.Second
+
+var (
+	_ ethereum.LogFilterer = (*EthereumAdapter)(nil)
+	_ ethereum.ChainReader = (*EthereumAdapter)(nil)
+)
+
+// EthereumAdapterOptions configures an EthereumAdapter.
+type EthereumAdapterOptions struct {
+	// PollInterval is the interval at which subscriptions poll for new blocks. Defaults to DefaultPollInterval.
+	PollInterval time.Duration
+}
+
+// EthereumAdapter serves go-ethereum's ethereum.LogFilterer and ethereum.ChainReader interfaces from
+// HyperSync, so abigen bindings and other go-ethereum tooling can filter logs and read blocks without RPC.
+//
+// Block numbers lower than zero, such as rpc.LatestBlockNumber or rpc.FinalizedBlockNumber, resolve to the
+// archive height. Subscriptions poll the archive height. Lookups by block hash are not supported by HyperSync
+// and are served by the RPC client. Blocks holding transactions of types go-ethereum does not support, such as
+// the OP-stack deposits starting every OP-stack block, cannot be returned by BlockByNumber.
+type EthereumAdapter struct {
+	client       *Client
+	fetch        FetchFn
+	height       func(ctx context.Context) (*big.Int, error)
+	pollInterval time.Duration
+}
+
+// NewEthereumAdapter creates an EthereumAdapter over the client.
+//
+// Example:
+//
+//	adapter := hypersyncgo.NewEthereumAdapter(client, nil)
+//	token, err := erc20.NewERC20Filterer(tokenAddr, adapter)
+//	if err != nil {
+//	    log.Fatalf("Failed to bind token: %v", err)
+//	}
+//	transfers, err := token.FilterTransfer(&bind.FilterOpts{Start: 18000000}, nil, nil)
+func NewEthereumAdapter(client *Client, opts *EthereumAdapterOptions) *EthereumAdapter {
+	pollInterval := DefaultPollInterval
+	if opts != nil && opts.PollInterval > 0 {
+		pollInterval = opts.PollInterval
+	}
+
+	return &EthereumAdapter{
+		client:       client,
+		fetch:        client.GetArrow,
+		height:       client.GetHeight,
+		pollInterval: pollInterval,
+	}
+}
+
+// FilterLogs returns the logs matching the filter query, fetching every page of the block range.
+func (a *EthereumAdapter) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]gethtypes.Log, error) {
+	from, to, err := a.filterRange(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, _, err := a.logs(ctx, q, from, to)
+	return logs, err
+}
+
+// SubscribeFilterLogs delivers the logs matching the filter query on ch as new blocks are indexed. Without
+// a FromBlock, only logs of blocks indexed after the subscription are delivered; with a ToBlock, the
+// subscription ends once the block is reached.
+func (a *EthereumAdapter) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- gethtypes.Log) (ethereum.Subscription, error) {
+	if q.BlockHash != nil {
+		return nil, errors.New("subscriptions do not support filtering by block hash")
+	}
+
+	height, err := a.height(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get archive height")
+	}
+
+	cursor := new(big.Int).Add(height, big.NewInt(1))
+	if q.FromBlock != nil {
+		cursor = a.resolve(q.FromBlock, height)
+	}
+
+	var to *big.Int
+	if q.ToBlock != nil {
+		to = a.resolve(q.ToBlock, height)
+	}
+
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		ticker := time.NewTicker(a.pollInterval)
+		defer ticker.Stop()
+
+		for {
+			if to != nil && cursor.Cmp(to) > 0 {
+				return nil
+			}
+
+			latest, hErr := a.height(ctx)
+			if hErr != nil {
+				return errors.Wrap(hErr, "failed to get archive height")
+			}
+
+			end := latest
+			if to != nil && to.Cmp(end) < 0 {
+				end = to
+			}
+
+			if cursor.Cmp(end) <= 0 {
+				logs, next, lErr := a.logs(ctx, q, cursor, end)
+				if lErr != nil {
+					return lErr
+				}
+				for _, log := range logs {
+					select {
+					case ch <- log:
+					case <-quit:
+						return nil
+					case <-ctx.Done():
+						return ctx.Err()
+					}
+				}
+				cursor = next
+			}
+
+			select {
+			case <-ticker.C:
+			case <-quit:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}), nil
+}
+
+// HeaderByNumber returns the header of the block. A nil number returns the header of the latest block.
+func (a *EthereumAdapter) HeaderByNumber(ctx context.Context, number *big.Int) (*gethtypes.Header, error) {
+	block, _, err := a.block(ctx, number, false)
+	if err != nil {
+		return nil, err
+	}
+	return block.ToHeader()
+}
+
+// BlockByNumber returns the block with its transactions. A nil number returns the latest block. A block
+// holding a transaction of a type go-ethereum does not support, such as an OP-stack deposit, fails with an
+// error wrapping errorshs.ErrUnsupportedTransactionType rather than returning the block without it; use
+// HeaderByNumber for the header of such blocks.
+func (a *EthereumAdapter) BlockByNumber(ctx context.Context, number *big.Int) (*gethtypes.Block, error) {
+	block, transactions, err := a.block(ctx, number, true)
+	if err != nil {
+		return nil, err
+	}
+	return block.ToGeth(transactions)
+}
+
+// HeaderByHash returns the header of the block with the given hash, using the RPC client.
+func (a *EthereumAdapter) HeaderByHash(ctx context.Context, hash common.Hash) (*gethtypes.Header, error) {
+	rpcClient, err := a.client.GetRPC()
+	if err != nil {
+		return nil, err
+	}
+	return rpcClient.HeaderByHash(ctx, hash)
+}
+
+// BlockByHash returns the block with the given hash, using the RPC client.
+func (a *EthereumAdapter) BlockByHash(ctx context.Context, hash common.Hash) (*gethtypes.Block, error) {
+	rpcClient, err := a.client.GetRPC()
+	if err != nil {
+		return nil, err
+	}
+	return rpcClient.BlockByHash(ctx, hash)
+}
+
+// TransactionCount returns the number of transactions in the block with the given hash, using the RPC client.
+func (a *EthereumAdapter) TransactionCount(ctx context.Context, blockHash common.Hash) (uint, error) {
+	rpcClient, err := a.client.GetRPC()
+	if err != nil {
+		return 0, err
+	}
+	return rpcClient.TransactionCount(ctx, blockHash)
+}
+
+// TransactionInBlock returns the transaction at the index of the block with the given hash, using the RPC client.
+func (a *EthereumAdapter) TransactionInBlock(ctx context.Context, blockHash common.Hash, index uint) (*gethtypes.Transaction, error) {
+	rpcClient, err := a.client.GetRPC()
+	if err != nil {
+		return nil, err
+	}
+	return rpcClient.TransactionInBlock(ctx, blockHash, index)
+}
+
+// SubscribeNewHead delivers the header of every block indexed after the subscription on ch.
+func (a *EthereumAdapter) SubscribeNewHead(ctx context.Context, ch chan<- *gethtypes.Header) (ethereum.Subscription, error) {
+	height, err := a.height(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get archive height")
+	}
+
+	cursor := new(big.Int).Add(height, big.NewInt(1))
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		ticker := time.NewTicker(a.pollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+			case <-quit:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+
+			latest, hErr := a.height(ctx)
+			if hErr != nil {
+				return errors.Wrap(hErr, "failed to get archive height")
+			}
+
+			for ; cursor.Cmp(latest) <= 0; cursor = new(big.Int).Add(cursor, big.NewInt(1)) {
+				header, hdErr := a.HeaderByNumber(ctx, cursor)
+				if hdErr != nil {
+					return hdErr
+				}
+				select {
+				case ch <- header:
+				case <-quit:
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+		}
+	}), nil
+}
+
+// filterRange resolves the inclusive block range of a filter query.
+func (a *EthereumAdapter) filterRange(ctx context.Context, q ethereum.FilterQuery) (*big.Int, *big.Int, error) {
+	if q.BlockHash != nil {
+		if q.FromBlock != nil || q.ToBlock != nil {
+			return nil, nil, errors.New("filter query cannot specify both block hash and block range")
+		}
+		header, err := a.HeaderByHash(ctx, *q.BlockHash)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to get header of block: %s", q.BlockHash.Hex())
+		}
+		return header.Number, header.Number, nil
+	}
+
+	height, err := a.height(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get archive height")
+	}
+
+	from := a.resolve(q.FromBlock, height)
+	to := a.resolve(q.ToBlock, height)
+	if from.Cmp(to) > 0 {
+		return nil, nil, fmt.Errorf("invalid block range: from block %s is after to block %s", from, to)
+	}
+	return from, to, nil
+}
+
+// resolve returns the block number, resolving nil and negative numbers to the archive height.
+func (a *EthereumAdapter) resolve(number *big.Int, height *big.Int) *big.Int {
+	if number == nil || number.Sign() < 0 {
+		return new(big.Int).Set(height)
+	}
+	return new(big.Int).Set(number)
+}
+
+// logs fetches the logs matching the filter query between from and to, both inclusive, and returns them
+// along with the first block that was not fetched.
+func (a *EthereumAdapter) logs(ctx context.Context, q ethereum.FilterQuery, from *big.Int, to *big.Int) ([]gethtypes.Log, *big.Int, error) {
+	query := &types.Query{
+		FromBlock: new(big.Int).Set(from),
+		ToBlock:   new(big.Int).Add(to, big.NewInt(1)),
+		Logs: []types.LogSelection{
+			{Address: q.Addresses, Topics: q.Topics},
+		},
+		FieldSelection: types.FieldSelection{
+			Log: types.LogSchemaFieldsAsString(),
+		},
+	}
+
+	logs := make([]gethtypes.Log, 0)
+	next, err := types.Paginate(ctx, query, a.fetch, func(response *types.QueryResponse) (bool, error) {
+		for _, log := range response.Data.Logs {
+			gethLog, lErr := log.ToGeth()
+			if lErr != nil {
+				return false, lErr
+			}
+			if q.BlockHash != nil && gethLog.BlockHash != *q.BlockHash {
+				continue
+			}
+			logs = append(logs, *gethLog)
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get logs")
+	}
+	return logs, next, nil
+}
+
+// block fetches the block at number and, when requested, its transactions ordered by index.
+func (a *EthereumAdapter) block(ctx context.Context, number *big.Int, withTransactions bool) (*types.Block, []types.Transaction, error) {
+	if number == nil || number.Sign() < 0 {
+		height, err := a.height(ctx)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to get archive height")
+		}
+		number = height
+	}
+
+	query := &types.Query{
+		FromBlock:        new(big.Int).Set(number),
+		ToBlock:          new(big.Int).Add(number, big.NewInt(1)),
+		IncludeAllBlocks: true,
+		FieldSelection: types.FieldSelection{
+			Block: types.BlockSchemaFieldsAsString(),
+		},
+	}
+	if withTransactions {
+		query.Transactions = []types.TransactionSelection{{}}
+		query.FieldSelection.Transaction = types.TransactionSchemaFieldsAsString()
+	}
+
+	response, err := a.fetch(ctx, query)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to get block: %s", number)
+	}
+
+	block := response.GetBlockByNumber(number)
+	if block == nil {
+		return nil, nil, ethereum.NotFound
+	}
+
+	transactions := response.Data.Transactions
+	types.SortTransactionsByIndex(transactions)
+
+	return block, transactions, nil
+}
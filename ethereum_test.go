@@ -0,0 +1,224 @@
+package hypersyncgo
+
+import (
+	"context"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	errorshs "github.com/enviodev/hypersync-client-go/errors"
+	"github.com/enviodev/hypersync-client-go/types"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAdapter(fetch FetchFn, height func(ctx context.Context) (*big.Int, error)) *EthereumAdapter {
+	return &EthereumAdapter{
+		fetch:        fetch,
+		height:       height,
+		pollInterval: time.Millisecond,
+	}
+}
+
+func fixedHeight(height int64) func(ctx context.Context) (*big.Int, error) {
+	return func(ctx context.Context) (*big.Int, error) {
+		return big.NewInt(height), nil
+	}
+}
+
+func newTestLog(block int64, index uint64, addr common.Address, topic common.Hash) types.Log {
+	data := []byte{byte(block)}
+	return types.Log{
+		BlockNumber: big.NewInt(block),
+		LogIndex:    &index,
+		Address:     &addr,
+		Data:        &data,
+		Topic0:      &topic,
+	}
+}
+
+func TestEthereumAdapterFilterLogs(t *testing.T) {
+	token := common.HexToAddress("0x01")
+	other := common.HexToAddress("0x02")
+	transfer := common.HexToHash("0xaa")
+	approval := common.HexToHash("0xbb")
+
+	logs := []types.Log{
+		newTestLog(5, 0, token, transfer),
+		newTestLog(15, 1, token, approval),
+		newTestLog(25, 2, other, transfer),
+		newTestLog(35, 3, token, transfer),
+		newTestLog(99, 4, token, transfer),
+	}
+
+	var queries []*types.Query
+	adapter := newTestAdapter(newFakeFetch(logs, 10, &queries), fixedHeight(99))
+
+	t.Run("Paginated range", func(t *testing.T) {
+		queries = nil
+		result, err := adapter.FilterLogs(context.Background(), ethereum.FilterQuery{
+			FromBlock: big.NewInt(0),
+			ToBlock:   big.NewInt(49),
+			Addresses: []common.Address{token},
+			Topics:    [][]common.Hash{{transfer}},
+		})
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		require.Equal(t, uint64(5), result[0].BlockNumber)
+		require.Equal(t, uint64(35), result[1].BlockNumber)
+		require.Equal(t, token, result[1].Address)
+		require.Equal(t, []common.Hash{transfer}, result[1].Topics)
+
+		require.Len(t, queries, 5)
+		require.Equal(t, big.NewInt(50), queries[0].ToBlock)
+		require.Equal(t, []string{"address", "data"}, filterFields(queries[0].FieldSelection.Log, "address", "data"))
+	})
+
+	t.Run("Latest block", func(t *testing.T) {
+		result, err := adapter.FilterLogs(context.Background(), ethereum.FilterQuery{
+			ToBlock: big.NewInt(int64(rpc.LatestBlockNumber)),
+		})
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		require.Equal(t, uint64(99), result[0].BlockNumber)
+	})
+
+	t.Run("Invalid range", func(t *testing.T) {
+		_, err := adapter.FilterLogs(context.Background(), ethereum.FilterQuery{
+			FromBlock: big.NewInt(50),
+			ToBlock:   big.NewInt(10),
+		})
+		require.EqualError(t, err, "invalid block range: from block 50 is after to block 10")
+	})
+}
+
+func TestEthereumAdapterSubscribeFilterLogs(t *testing.T) {
+	token := common.HexToAddress("0x01")
+	transfer := common.HexToHash("0xaa")
+	logs := []types.Log{
+		newTestLog(3, 0, token, transfer),
+		newTestLog(12, 1, token, transfer),
+		newTestLog(21, 2, token, transfer),
+		newTestLog(31, 3, token, transfer),
+	}
+
+	// The archive grows by ten blocks on every poll.
+	var polls atomic.Int64
+	height := func(ctx context.Context) (*big.Int, error) {
+		return big.NewInt(polls.Add(1) * 10), nil
+	}
+
+	var queries []*types.Query
+	adapter := newTestAdapter(newFakeFetch(logs, 100, &queries), height)
+
+	ch := make(chan gethtypes.Log)
+	sub, err := adapter.SubscribeFilterLogs(context.Background(), ethereum.FilterQuery{
+		FromBlock: big.NewInt(0),
+		ToBlock:   big.NewInt(25),
+		Addresses: []common.Address{token},
+	}, ch)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	received := make([]uint64, 0)
+	for len(received) < 3 {
+		select {
+		case log := <-ch:
+			received = append(received, log.BlockNumber)
+		case err := <-sub.Err():
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for logs")
+		}
+	}
+	require.Equal(t, []uint64{3, 12, 21}, received)
+
+	select {
+	case err, ok := <-sub.Err():
+		require.False(t, ok, "unexpected subscription error: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription did not end at the to block")
+	}
+}
+
+func TestEthereumAdapterHeaderByNumber(t *testing.T) {
+	header := &gethtypes.Header{
+		ParentHash:  common.HexToHash("0x01"),
+		UncleHash:   gethtypes.EmptyUncleHash,
+		Coinbase:    common.HexToAddress("0x02"),
+		Root:        common.HexToHash("0x03"),
+		TxHash:      gethtypes.EmptyTxsHash,
+		ReceiptHash: gethtypes.EmptyReceiptsHash,
+		Difficulty:  big.NewInt(0),
+		Number:      big.NewInt(42),
+		GasLimit:    30000000,
+		Time:        1700000000,
+		Extra:       []byte{},
+		BaseFee:     big.NewInt(7),
+	}
+
+	fetch := func(ctx context.Context, query *types.Query) (*types.QueryResponse, error) {
+		response := &types.QueryResponse{NextBlock: query.ToBlock}
+		if query.FromBlock.Cmp(header.Number) == 0 {
+			response.Data.Blocks = []types.Block{*types.NewBlockFromHeader(header)}
+		}
+		return response, nil
+	}
+	adapter := newTestAdapter(fetch, fixedHeight(42))
+
+	result, err := adapter.HeaderByNumber(context.Background(), nil)
+	require.NoError(t, err)
+	require.Equal(t, header.Hash(), result.Hash())
+
+	block, err := adapter.BlockByNumber(context.Background(), big.NewInt(42))
+	require.NoError(t, err)
+	require.Equal(t, header.Hash(), block.Hash())
+	require.Empty(t, block.Transactions())
+
+	_, err = adapter.HeaderByNumber(context.Background(), big.NewInt(41))
+	require.ErrorIs(t, err, ethereum.NotFound)
+}
+
+func TestEthereumAdapterBlockByNumberUnsupportedTransactions(t *testing.T) {
+	header := &gethtypes.Header{
+		UncleHash:  gethtypes.EmptyUncleHash,
+		TxHash:     common.HexToHash("0x7e"),
+		Difficulty: big.NewInt(0),
+		Number:     big.NewInt(42),
+		Extra:      []byte{},
+	}
+
+	kind, index := types.DepositTxType, uint64(0)
+	fetch := func(ctx context.Context, query *types.Query) (*types.QueryResponse, error) {
+		response := &types.QueryResponse{NextBlock: query.ToBlock}
+		response.Data.Blocks = []types.Block{*types.NewBlockFromHeader(header)}
+		response.Data.Transactions = []types.Transaction{{BlockNumber: big.NewInt(42), TransactionIndex: &index, Kind: &kind}}
+		return response, nil
+	}
+	adapter := newTestAdapter(fetch, fixedHeight(42))
+
+	_, err := adapter.BlockByNumber(context.Background(), big.NewInt(42))
+	require.ErrorIs(t, err, errorshs.ErrUnsupportedTransactionType)
+
+	result, err := adapter.HeaderByNumber(context.Background(), big.NewInt(42))
+	require.NoError(t, err)
+	require.Equal(t, header.Hash(), result.Hash())
+}
+
+// filterFields returns the wanted fields present in fields, in the wanted order.
+func filterFields(fields []string, wanted ...string) []string {
+	toReturn := make([]string, 0)
+	for _, w := range wanted {
+		for _, f := range fields {
+			if f == w {
+				toReturn = append(toReturn, w)
+				break
+			}
+		}
+	}
+	return toReturn
+}
@@ -18,22 +18,16 @@ import (
 	"go.uber.org/zap"
 )
 
-func getEnvPtr(key string) *string {
-	if val := os.Getenv(key); val != "" {
-		return &val
-	}
-	return nil
-}
-
 func main() {
 	opts := options.Options{
 		Blockchains: []options.Node{
 			{
-				Type:        utils.EthereumNetwork,
-				NetworkId:   utils.EthereumNetworkID,
+				Type:        utils.ArbitrumOneNetwork,
+				NetworkId:   utils.ArbitrumOneNetworkID,
 				Endpoint:    "https://arbitrum.hypersync.xyz",
 				RpcEndpoint: "https://arbitrum.rpc.hypersync.xyz",
-				BearerToken: getEnvPtr("HYPERSYNC_BEARER_TOKEN"),
+				ApiToken:    os.Getenv("ENVIO_API_TOKEN"),
+				Probe:       options.ProbeFail,
 			},
 		},
 	}
@@ -50,12 +44,12 @@ func main() {
 		return
 	}
 
-	client, found := hsClient.GetClient(utils.EthereumNetworkID)
+	client, found := hsClient.GetClient(utils.ArbitrumOneNetworkID)
 	if !found {
 		logger.L().Error(
 			"failure to discover hyper client",
 			zap.Error(err),
-			zap.Any("network_id", utils.EthereumNetworkID),
+			zap.Any("network_id", utils.ArbitrumOneNetworkID),
 		)
 		return
 	}
@@ -67,7 +61,7 @@ func main() {
 	logger.L().Info(
 		"New signature hash with custom stream query request started",
 		zap.Error(err),
-		zap.Any("network_id", utils.EthereumNetworkID),
+		zap.Any("network_id", utils.ArbitrumOneNetworkID),
 		zap.Any("start_block", startBlock),
 		zap.Any("end_block", endBlock),
 	)
@@ -102,7 +96,7 @@ func main() {
 		logger.L().Error(
 			"failure to execute hyper client stream in range",
 			zap.Error(err),
-			zap.Any("network_id", utils.EthereumNetworkID),
+			zap.Any("network_id", utils.ArbitrumOneNetworkID),
 			zap.Any("start_block", startBlock),
 			zap.Any("end_block", endBlock),
 		)
@@ -119,7 +113,7 @@ func main() {
 			logger.L().Error(
 				"failure to execute hyper client stream in range",
 				zap.Error(cErr),
-				zap.Any("network_id", utils.EthereumNetworkID),
+				zap.Any("network_id", utils.ArbitrumOneNetworkID),
 				zap.Any("start_block", startBlock),
 				zap.Any("end_block", endBlock),
 			)
@@ -193,7 +187,7 @@ func main() {
 		case <-time.After(15 * time.Second):
 			logger.L().Error(
 				"expected ranges to receive at least one logs range in 15s",
-				zap.Any("network_id", utils.EthereumNetworkID),
+				zap.Any("network_id", utils.ArbitrumOneNetworkID),
 				zap.Any("start_block", startBlock),
 				zap.Any("latest_batch_block_received", latestBatchReceived),
 				zap.Any("end_block", endBlock),
@@ -0,0 +1,268 @@
+package hypersyncgo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/enviodev/hypersync-client-go/options"
+	"github.com/pkg/errors"
+)
+
+// EndpointStatus is the health of one of the nodes a client sends requests to.
+type EndpointStatus struct {
+	// Endpoint is the HyperSync endpoint of the node.
+	Endpoint string
+
+	// RpcEndpoint is the RPC endpoint of the node.
+	RpcEndpoint string
+
+	// Healthy is false while the node is skipped after a failure.
+	Healthy bool
+
+	// Failures is the number of consecutive failed requests.
+	Failures int
+
+	// LastError is the error of the last failed request, or nil once a request succeeded.
+	LastError error
+}
+
+// endpoint is a node of a failover pool along with its health.
+type endpoint struct {
+	node      options.Node
+	failures  int
+	downUntil time.Time
+	lastErr   error
+}
+
+// endpointPool tracks the health of the nodes of a network and orders them for each request.
+type endpointPool struct {
+	mu        sync.Mutex
+	opts      options.FailoverOptions
+	endpoints []*endpoint
+	next      int
+}
+
+func newEndpointPool(nodes []options.Node, opts *options.FailoverOptions) *endpointPool {
+	if opts == nil {
+		opts = options.DefaultFailoverOptions()
+	}
+	pool := &endpointPool{opts: *opts}
+	for _, node := range nodes {
+		pool.endpoints = append(pool.endpoints, &endpoint{node: node})
+	}
+	return pool
+}
+
+// order returns the endpoints in the order a request tries them: the healthy ones as the policy selects,
+// then those in cooldown, soonest available first, as a last resort.
+func (p *endpointPool) order() []*endpoint {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	var healthy, down []*endpoint
+	for _, ep := range p.endpoints {
+		if now.Before(ep.downUntil) {
+			down = append(down, ep)
+		} else {
+			healthy = append(healthy, ep)
+		}
+	}
+
+	if p.opts.Policy == options.FailoverRoundRobin && len(healthy) > 0 {
+		start := p.next % len(healthy)
+		p.next++
+		rotated := make([]*endpoint, 0, len(healthy))
+		rotated = append(rotated, healthy[start:]...)
+		healthy = append(rotated, healthy[:start]...)
+	}
+
+	for i := 1; i < len(down); i++ {
+		for j := i; j > 0 && down[j].downUntil.Before(down[j-1].downUntil); j-- {
+			down[j], down[j-1] = down[j-1], down[j]
+		}
+	}
+	return append(healthy, down...)
+}
+
+func (p *endpointPool) succeeded(ep *endpoint) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	ep.failures = 0
+	ep.downUntil = time.Time{}
+	ep.lastErr = nil
+}
+
+func (p *endpointPool) failed(ep *endpoint, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	ep.failures++
+	ep.downUntil = time.Now().Add(p.opts.GetCooldown())
+	ep.lastErr = err
+}
+
+func (p *endpointPool) status() []EndpointStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	statuses := make([]EndpointStatus, len(p.endpoints))
+	for i, ep := range p.endpoints {
+		statuses[i] = EndpointStatus{
+			Endpoint:    ep.node.Endpoint,
+			RpcEndpoint: ep.node.RpcEndpoint,
+			Healthy:     !now.Before(ep.downUntil),
+			Failures:    ep.failures,
+			LastError:   ep.lastErr,
+		}
+	}
+	return statuses
+}
+
+// failoverTransport sends requests built for the first node of a pool to its nodes in turn until one
+// succeeds, rewriting the endpoint and the API token of the request for each node. With rpc set, the RPC
+// endpoints of the nodes are rewritten, requests are built for the first node that has one and nodes without
+// one are skipped.
+type failoverTransport struct {
+	pool *endpointPool
+	base http.RoundTripper
+	rpc  bool
+}
+
+func (t *failoverTransport) baseURL(node options.Node) string {
+	if t.rpc {
+		return node.RpcEndpoint
+	}
+	return node.Endpoint
+}
+
+// primary returns the endpoint of the first node that has one, which requests are built for, or an empty
+// string when no node has one.
+func (t *failoverTransport) primary() string {
+	for _, ep := range t.pool.endpoints {
+		if base := t.baseURL(ep.node); base != "" {
+			return base
+		}
+	}
+	return ""
+}
+
+func (t *failoverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read request body")
+		}
+	}
+
+	primary := t.primary()
+	target := req.URL.String()
+
+	var endpoints []*endpoint
+	for _, ep := range t.pool.order() {
+		if t.baseURL(ep.node) != "" {
+			endpoints = append(endpoints, ep)
+		}
+	}
+
+	var lastErr error
+	for i, ep := range endpoints {
+		if err := req.Context().Err(); err != nil {
+			return nil, err
+		}
+
+		attempt := req.Clone(req.Context())
+		if primary != "" && strings.HasPrefix(target, primary) {
+			u, err := url.Parse(t.baseURL(ep.node) + strings.TrimPrefix(target, primary))
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid endpoint %s", t.baseURL(ep.node))
+			}
+			attempt.URL = u
+			attempt.Host = ""
+		}
+		attempt.Header.Set("Authorization", "Bearer "+ep.node.ApiToken)
+		if body != nil {
+			attempt.Body = io.NopCloser(bytes.NewReader(body))
+			attempt.ContentLength = int64(len(body))
+		}
+
+		resp, err := t.base.RoundTrip(attempt)
+		if err == nil && resp.StatusCode < http.StatusInternalServerError && resp.StatusCode != http.StatusTooManyRequests {
+			t.pool.succeeded(ep)
+			return resp, nil
+		}
+
+		if err == nil {
+			err = fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+			if i == len(endpoints)-1 {
+				t.pool.failed(ep, err)
+				return resp, nil
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return nil, err
+		}
+		t.pool.failed(ep, err)
+		lastErr = errors.Wrapf(err, "endpoint %s failed", t.baseURL(ep.node))
+	}
+	if lastErr == nil {
+		lastErr = errors.New("no endpoint to send the request to")
+	}
+	return nil, lastErr
+}
+
+// NewFailoverClient creates a client for a network served by several nodes. Requests are sent to the nodes
+// as the failover policy selects and transparently retried on the next node when one fails with a network
+// error, a 5xx or a 429 status; failing nodes are skipped for the cooldown of the failover options. RPC calls
+// fail over between the HTTP RPC endpoints of the nodes the same way.
+//
+// The first node provides the retry, decoding and probe settings of the client.
+//
+// Example:
+//
+//	client, err := hypersyncgo.NewFailoverClient(ctx, []options.Node{primary, secondary}, &options.FailoverOptions{
+//		Policy:     options.FailoverPrimary,
+//		CooldownMs: 10000,
+//	})
+func NewFailoverClient(ctx context.Context, nodes []options.Node, opts *options.FailoverOptions) (*Client, error) {
+	if len(nodes) == 0 {
+		return nil, errors.New("at least one node is required")
+	}
+	if opts != nil {
+		if err := opts.Validate(); err != nil {
+			return nil, errors.Wrap(err, "invalid failover options")
+		}
+	}
+	for i, node := range nodes {
+		if err := node.Validate(); err != nil {
+			return nil, errors.Wrapf(err, "invalid node options [%d]", i)
+		}
+		if node.NetworkId != nodes[0].NetworkId {
+			return nil, fmt.Errorf("node [%d] is of network %s, but node [0] is of network %s", i, node.NetworkId, nodes[0].NetworkId)
+		}
+	}
+	if len(nodes) == 1 {
+		return NewClient(ctx, nodes[0])
+	}
+	return newClientWithPool(ctx, nodes[0], nil, newEndpointPool(nodes, opts))
+}
+
+// Endpoints returns the health of the nodes the client sends requests to, in configuration order.
+func (c *Client) Endpoints() []EndpointStatus {
+	if c.pool == nil {
+		return []EndpointStatus{{Endpoint: c.opts.Endpoint, RpcEndpoint: c.opts.RpcEndpoint, Healthy: true}}
+	}
+	return c.pool.status()
+}
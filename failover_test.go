@@ -0,0 +1,264 @@
+package hypersyncgo
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	errorshs "github.com/enviodev/hypersync-client-go/errors"
+	"github.com/enviodev/hypersync-client-go/options"
+	"github.com/enviodev/hypersync-client-go/utils"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// heightServer serves the height endpoint, or fails with the status when it is set, and counts requests.
+type heightServer struct {
+	*httptest.Server
+	token  string
+	status atomic.Int32
+	hits   atomic.Int32
+}
+
+func newHeightServer(t *testing.T, token string, height int64) *heightServer {
+	s := &heightServer{token: token}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.hits.Add(1)
+		require.Equal(t, "Bearer "+s.token, r.Header.Get("Authorization"))
+		if status := s.status.Load(); status != 0 {
+			w.WriteHeader(int(status))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"height": height})
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func failoverNode(endpoint string, token string) options.Node {
+	return options.Node{NetworkId: utils.EthereumNetworkID, Endpoint: endpoint, ApiToken: token}
+}
+
+func TestFailoverClient(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Primary fails over to secondary", func(t *testing.T) {
+		primary := newHeightServer(t, "primary-token", 100)
+		secondary := newHeightServer(t, "secondary-token", 101)
+		primary.status.Store(http.StatusServiceUnavailable)
+
+		client, err := NewFailoverClient(ctx, []options.Node{
+			failoverNode(primary.URL, "primary-token"),
+			failoverNode(secondary.URL, "secondary-token"),
+		}, nil)
+		require.NoError(t, err)
+
+		height, err := client.GetHeight(ctx)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(101), height)
+
+		endpoints := client.Endpoints()
+		require.Len(t, endpoints, 2)
+		require.False(t, endpoints[0].Healthy)
+		require.Equal(t, 1, endpoints[0].Failures)
+		require.ErrorContains(t, endpoints[0].LastError, "unexpected status code: 503")
+		require.True(t, endpoints[1].Healthy)
+
+		// The primary is skipped during its cooldown.
+		_, err = client.GetHeight(ctx)
+		require.NoError(t, err)
+		require.Equal(t, int32(1), primary.hits.Load())
+		require.Equal(t, int32(2), secondary.hits.Load())
+	})
+
+	t.Run("Primary is used again after its cooldown", func(t *testing.T) {
+		primary := newHeightServer(t, "token", 100)
+		secondary := newHeightServer(t, "token", 101)
+		primary.status.Store(http.StatusBadGateway)
+
+		client, err := NewFailoverClient(ctx, []options.Node{failoverNode(primary.URL, "token"), failoverNode(secondary.URL, "token")},
+			&options.FailoverOptions{Policy: options.FailoverPrimary})
+		require.NoError(t, err)
+
+		_, err = client.GetHeight(ctx)
+		require.NoError(t, err)
+
+		primary.status.Store(0)
+		height, err := client.GetHeight(ctx)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(100), height)
+		require.True(t, client.Endpoints()[0].Healthy)
+	})
+
+	t.Run("Round robin", func(t *testing.T) {
+		first := newHeightServer(t, "token", 100)
+		second := newHeightServer(t, "token", 100)
+
+		client, err := NewFailoverClient(ctx, []options.Node{failoverNode(first.URL, "token"), failoverNode(second.URL, "token")},
+			&options.FailoverOptions{Policy: options.FailoverRoundRobin, CooldownMs: 1000})
+		require.NoError(t, err)
+
+		for i := 0; i < 4; i++ {
+			_, err = client.GetHeight(ctx)
+			require.NoError(t, err)
+		}
+		require.Equal(t, int32(2), first.hits.Load())
+		require.Equal(t, int32(2), second.hits.Load())
+	})
+
+	t.Run("Client errors are not retried", func(t *testing.T) {
+		primary := newHeightServer(t, "token", 100)
+		secondary := newHeightServer(t, "token", 101)
+		primary.status.Store(http.StatusBadRequest)
+
+		client, err := NewFailoverClient(ctx, []options.Node{failoverNode(primary.URL, "token"), failoverNode(secondary.URL, "token")}, nil)
+		require.NoError(t, err)
+
+		_, err = Do[struct{}, ArchiveHeight](ctx, client, client.GeUrlFromNodeAndPath(client.opts, "height"), http.MethodGet, struct{}{})
+		require.ErrorContains(t, err, "unexpected status code: 400")
+		require.Equal(t, int32(0), secondary.hits.Load())
+	})
+
+	t.Run("RPC fails over", func(t *testing.T) {
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		t.Cleanup(failing.Close)
+
+		rpcServer := rpc.NewServer()
+		require.NoError(t, rpcServer.RegisterName("eth", &chainIDService{chainID: 1}))
+		serving := httptest.NewServer(rpcServer)
+		t.Cleanup(serving.Close)
+		t.Cleanup(rpcServer.Stop)
+
+		hyper := newHeightServer(t, "token", 100)
+		primary := failoverNode(hyper.URL, "token")
+		primary.RpcEndpoint = failing.URL
+		secondary := failoverNode(hyper.URL, "token")
+		secondary.RpcEndpoint = serving.URL
+
+		client, err := NewFailoverClient(ctx, []options.Node{primary, secondary}, nil)
+		require.NoError(t, err)
+		rpcClient, err := client.GetRPC()
+		require.NoError(t, err)
+		chainID, err := rpcClient.ChainID(ctx)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(1), chainID)
+	})
+
+	t.Run("RPC of a secondary only", func(t *testing.T) {
+		rpcServer := rpc.NewServer()
+		require.NoError(t, rpcServer.RegisterName("eth", &chainIDService{chainID: 1}))
+		serving := httptest.NewServer(rpcServer)
+		t.Cleanup(serving.Close)
+		t.Cleanup(rpcServer.Stop)
+
+		hyper := newHeightServer(t, "token", 100)
+		secondary := failoverNode(hyper.URL, "token")
+		secondary.RpcEndpoint = serving.URL
+
+		client, err := NewFailoverClient(ctx, []options.Node{failoverNode(hyper.URL, "token"), secondary}, nil)
+		require.NoError(t, err)
+		rpcClient, err := client.GetRPC()
+		require.NoError(t, err)
+		chainID, err := rpcClient.ChainID(ctx)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(1), chainID)
+
+		withoutRPC, err := NewFailoverClient(ctx, []options.Node{failoverNode(hyper.URL, "token"), failoverNode(hyper.URL, "token")}, nil)
+		require.NoError(t, err)
+		_, err = withoutRPC.GetRPC()
+		require.ErrorIs(t, err, errorshs.ErrRPCNotConfigured)
+	})
+
+	t.Run("Other URLs are not rewritten", func(t *testing.T) {
+		var hits atomic.Int32
+		other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hits.Add(1)
+			require.Equal(t, "/other", r.URL.Path)
+		}))
+		t.Cleanup(other.Close)
+
+		secondary := failoverNode("https://eth.hypersync.xyz", "token")
+		secondary.RpcEndpoint = "http://rpc.invalid"
+		transport := &failoverTransport{
+			pool: newEndpointPool([]options.Node{failoverNode("https://eth.hypersync.xyz", "token"), secondary}, nil),
+			base: http.DefaultTransport,
+			rpc:  true,
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, other.URL+"/other", nil)
+		require.NoError(t, err)
+		resp, err := transport.RoundTrip(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, int32(1), hits.Load())
+	})
+
+	t.Run("Invalid nodes", func(t *testing.T) {
+		_, err := NewFailoverClient(ctx, nil, nil)
+		require.EqualError(t, err, "at least one node is required")
+
+		other := failoverNode("https://base.hypersync.xyz", "token")
+		other.NetworkId = utils.BaseNetworkID
+		_, err = NewFailoverClient(ctx, []options.Node{failoverNode("https://eth.hypersync.xyz", "token"), other}, nil)
+		require.EqualError(t, err, "node [1] is of network 8453, but node [0] is of network 1")
+
+		_, err = NewFailoverClient(ctx, []options.Node{failoverNode("https://eth.hypersync.xyz", "token")}, &options.FailoverOptions{Policy: "random"})
+		require.ErrorContains(t, err, `invalid failover policy "random"`)
+	})
+}
+
+func TestHyperClientManagement(t *testing.T) {
+	ctx := context.Background()
+	primary := newHeightServer(t, "token", 100)
+	secondary := newHeightServer(t, "token", 101)
+
+	hyper, err := NewHyper(ctx, options.Options{
+		LogLevel: zap.ErrorLevel,
+		Blockchains: []options.Node{
+			failoverNode(primary.URL, "token"),
+			failoverNode(secondary.URL, "token"),
+		},
+	})
+	require.NoError(t, err)
+
+	client, ok := hyper.GetClient(utils.EthereumNetworkID)
+	require.True(t, ok)
+	require.Len(t, client.Endpoints(), 2)
+
+	primary.status.Store(http.StatusInternalServerError)
+	height, err := client.GetHeight(ctx)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(101), height)
+
+	base := failoverNode(secondary.URL, "token")
+	base.NetworkId = utils.BaseNetworkID
+	added, err := hyper.AddClient(base)
+	require.NoError(t, err)
+	require.Len(t, hyper.GetClients(), 2)
+
+	_, err = hyper.AddClient(base)
+	require.EqualError(t, err, "network 8453 already has a client")
+
+	replaced, err := hyper.ReplaceClient(base, base)
+	require.NoError(t, err)
+	require.NotSame(t, added, replaced)
+	require.Len(t, replaced.Endpoints(), 2)
+	current, ok := hyper.GetClient(utils.BaseNetworkID)
+	require.True(t, ok)
+	require.Same(t, replaced, current)
+
+	removed, ok := hyper.RemoveClient(utils.BaseNetworkID)
+	require.True(t, ok)
+	require.Same(t, replaced, removed)
+	_, ok = hyper.GetClient(utils.BaseNetworkID)
+	require.False(t, ok)
+	_, ok = hyper.RemoveClient(utils.BaseNetworkID)
+	require.False(t, ok)
+}
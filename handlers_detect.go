@@ -0,0 +1,139 @@
+package hypersyncgo
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/enviodev/hypersync-client-go/contracts"
+	"github.com/enviodev/hypersync-client-go/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// DetectOptions configures DetectContractStandards.
+type DetectOptions struct {
+	// FromBlock is the first block to inspect logs from. Defaults to 0.
+	FromBlock *big.Int
+	// ToBlock is the block to stop at (exclusive). Defaults to the archive height.
+	ToBlock *big.Int
+	// MaxLogs caps the number of logs inspected. Defaults to 1000.
+	MaxLogs int
+	// UseBytecode fetches the deployed bytecode over RPC to match function selectors.
+	UseBytecode bool
+	// Register registers the contract in the manager under the most confident standard.
+	Register bool
+	// MinConfidence is the confidence required to register the contract. Defaults to contracts.DefaultMinConfidence.
+	MinConfidence float64
+}
+
+// DetectionResult holds the outcome of DetectContractStandards.
+type DetectionResult struct {
+	// Detections are the likely standards, sorted by decreasing confidence.
+	Detections []contracts.Detection
+	// Contract is the registered contract when DetectOptions.Register is set and a standard was detected.
+	Contract *contracts.Contract
+	// InspectedLogs is the number of logs the detection is based on.
+	InspectedLogs int
+}
+
+// DetectContractStandards inspects the logs emitted by addr over a block range and, optionally, its deployed
+// bytecode, and classifies it against the standards registered in the manager for the client network.
+//
+// Example:
+//
+//	result, err := client.DetectContractStandards(ctx, manager, addr, &hypersyncgo.DetectOptions{
+//	    FromBlock: big.NewInt(18000000),
+//	    Register:  true,
+//	})
+//	if err != nil {
+//	    log.Fatalf("Failed to detect contract standards: %v", err)
+//	}
+func (c *Client) DetectContractStandards(ctx context.Context, manager *contracts.Manager, addr common.Address, opts *DetectOptions) (*DetectionResult, error) {
+	if manager == nil {
+		return nil, errors.New("contract manager is nil")
+	}
+
+	if opts == nil {
+		opts = &DetectOptions{}
+	}
+
+	maxLogs := opts.MaxLogs
+	if maxLogs <= 0 {
+		maxLogs = 1000
+	}
+
+	fromBlock := big.NewInt(0)
+	if opts.FromBlock != nil {
+		fromBlock = new(big.Int).Set(opts.FromBlock)
+	}
+
+	logs := make([]types.Log, 0)
+	for len(logs) < maxLogs {
+		query := types.Query{
+			FromBlock: fromBlock,
+			ToBlock:   opts.ToBlock,
+			Logs: []types.LogSelection{
+				{Address: []common.Address{addr}},
+			},
+			FieldSelection: types.FieldSelection{
+				Log: []string{"address", "topic0", "topic1", "topic2", "topic3"},
+			},
+			MaxNumLogs: big.NewInt(int64(maxLogs - len(logs))),
+		}
+
+		response, err := c.GetArrow(ctx, &query)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get logs for address: %s", addr.Hex())
+		}
+
+		logs = append(logs, response.Data.Logs...)
+
+		if !response.HasNextBlock() || response.NextBlock.Cmp(fromBlock) <= 0 {
+			break
+		}
+		if opts.ToBlock != nil && response.NextBlock.Cmp(opts.ToBlock) >= 0 {
+			break
+		}
+		if response.ArchiveHeight != nil && response.NextBlock.Cmp(response.ArchiveHeight) >= 0 {
+			break
+		}
+		fromBlock = response.NextBlock
+	}
+
+	if len(logs) > maxLogs {
+		logs = logs[:maxLogs]
+	}
+
+	var bytecode []byte
+	if opts.UseBytecode {
+		rpcClient, err := c.GetRPC()
+		if err != nil {
+			return nil, errors.Wrap(err, "bytecode detection requires rpc")
+		}
+		code, err := rpcClient.CodeAt(ctx, addr, nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get bytecode for address: %s", addr.Hex())
+		}
+		bytecode = code
+	}
+
+	result := &DetectionResult{
+		Detections:    manager.DetectStandards(c.opts.NetworkId, logs, bytecode),
+		InspectedLogs: len(logs),
+	}
+
+	if opts.Register {
+		minConfidence := opts.MinConfidence
+		if minConfidence <= 0 {
+			minConfidence = contracts.DefaultMinConfidence
+		}
+
+		contract, err := manager.RegisterDetected(c.opts.NetworkId, addr, result.Detections, minConfidence)
+		if err != nil {
+			return result, err
+		}
+		result.Contract = contract
+	}
+
+	return result, nil
+}
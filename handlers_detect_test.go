@@ -0,0 +1,47 @@
+package hypersyncgo
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/enviodev/hypersync-client-go/contracts"
+	"github.com/enviodev/hypersync-client-go/options"
+	"github.com/enviodev/hypersync-client-go/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectContractStandards(t *testing.T) {
+	skipWithoutApiToken(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client, err := NewClient(ctx, options.Node{
+		Type:        utils.EthereumNetwork,
+		NetworkId:   utils.EthereumNetworkID,
+		Endpoint:    "https://eth.hypersync.xyz",
+		RpcEndpoint: "https://eth.rpc.hypersync.xyz",
+		ApiToken:    getTestApiToken(),
+	})
+	require.NoError(t, err)
+
+	manager, err := contracts.NewManagerWithDefaults()
+	require.NoError(t, err)
+
+	// Tether USD
+	addr := common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7")
+	result, err := client.DetectContractStandards(ctx, manager, addr, &DetectOptions{
+		FromBlock:   big.NewInt(18000000),
+		ToBlock:     big.NewInt(18000100),
+		MaxLogs:     200,
+		UseBytecode: true,
+		Register:    true,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, result.Detections)
+	require.Equal(t, utils.Erc20, result.Detections[0].Standard)
+	require.NotNil(t, result.Contract)
+	require.Equal(t, addr, result.Contract.Addr())
+}
@@ -2,14 +2,33 @@ package hypersyncgo
 
 import (
 	"context"
+	"github.com/enviodev/hypersync-client-go/verify"
 	"github.com/ethereum/go-ethereum/core/types"
 	"math/big"
 )
 
 func (c *Client) HeaderByNumber(ctx context.Context, blockNumber *big.Int) (*types.Header, error) {
-	return c.GetRPC().HeaderByNumber(ctx, blockNumber)
+	rpcClient, err := c.GetRPC()
+	if err != nil {
+		return nil, err
+	}
+	return rpcClient.HeaderByNumber(ctx, blockNumber)
 }
 
 func (c *Client) BlockByNumber(ctx context.Context, blockNumber *big.Int) (*types.Block, error) {
-	return c.GetRPC().BlockByNumber(ctx, blockNumber)
+	rpcClient, err := c.GetRPC()
+	if err != nil {
+		return nil, err
+	}
+	return rpcClient.BlockByNumber(ctx, blockNumber)
+}
+
+// CrossVerify compares the HyperSync data of the blocks selected by opts with the data of the configured
+// RPC endpoint, field by field. See verify.CrossCheck.
+func (c *Client) CrossVerify(ctx context.Context, opts *verify.CrossCheckOptions) (*verify.CrossReport, error) {
+	rpcClient, err := c.GetRPC()
+	if err != nil {
+		return nil, err
+	}
+	return verify.CrossCheck(ctx, c, rpcClient, opts)
 }
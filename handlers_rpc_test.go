@@ -5,8 +5,12 @@ import (
 	"math/big"
 	"testing"
 
+	errorshs "github.com/enviodev/hypersync-client-go/errors"
 	"github.com/enviodev/hypersync-client-go/options"
 	"github.com/enviodev/hypersync-client-go/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
@@ -101,3 +105,66 @@ func TestBlockByNumber(t *testing.T) {
 		})
 	}
 }
+
+// testEthService serves eth_getBlockByNumber for a single header.
+type testEthService struct {
+	header *types.Header
+}
+
+func (s *testEthService) GetBlockByNumber(number rpc.BlockNumber, fullTx bool) (*types.Header, error) {
+	if number.Int64() != s.header.Number.Int64() && number != rpc.LatestBlockNumber {
+		return nil, nil
+	}
+	return s.header, nil
+}
+
+func TestRPCNotConfigured(t *testing.T) {
+	client, err := NewClient(context.Background(), options.Node{
+		Endpoint: "https://eth.hypersync.xyz",
+		ApiToken: "token",
+	})
+	require.NoError(t, err)
+
+	_, err = client.GetRPC()
+	require.ErrorIs(t, err, errorshs.ErrRPCNotConfigured)
+
+	_, err = client.HeaderByNumber(context.Background(), big.NewInt(1))
+	require.ErrorIs(t, err, errorshs.ErrRPCNotConfigured)
+}
+
+func TestRPCDialedLazily(t *testing.T) {
+	client, err := NewClient(context.Background(), options.Node{
+		Endpoint:    "https://eth.hypersync.xyz",
+		RpcEndpoint: "unknown://rpc.invalid",
+		ApiToken:    "token",
+	})
+	require.NoError(t, err)
+
+	_, err = client.GetRPC()
+	require.ErrorContains(t, err, "failed to connect to RPC client")
+}
+
+func TestNewClientWithRPC(t *testing.T) {
+	header := &types.Header{
+		ParentHash: common.HexToHash("0x01"),
+		Difficulty: big.NewInt(0),
+		Number:     big.NewInt(10000000),
+		GasLimit:   30000000,
+		Time:       1700000000,
+		Extra:      []byte{},
+	}
+
+	server := rpc.NewServer()
+	defer server.Stop()
+	require.NoError(t, server.RegisterName("eth", &testEthService{header: header}))
+
+	client, err := NewClientWithRPC(context.Background(), options.Node{
+		Endpoint: "https://eth.hypersync.xyz",
+		ApiToken: "token",
+	}, rpc.DialInProc(server))
+	require.NoError(t, err)
+
+	resp, err := client.HeaderByNumber(context.Background(), big.NewInt(10000000))
+	require.NoError(t, err)
+	require.Equal(t, header.Hash(), resp.Hash())
+}
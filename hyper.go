@@ -19,6 +19,8 @@ type Hyper struct {
 
 // NewHyper creates a new instance of HyperSync with the given context and options.
 // It validates the provided options and initializes clients for each blockchain network.
+// Networks listed by several nodes get a client failing over between them as the failover options select,
+// with the first node listed as the primary.
 //
 // Returns an error if the options are invalid or if a client for any network cannot be created.
 func NewHyper(ctx context.Context, opts options.Options) (*Hyper, error) {
@@ -32,38 +34,111 @@ func NewHyper(ctx context.Context, opts options.Options) (*Hyper, error) {
 	}
 	logger.SetGlobalLogger(zLog)
 
-	mu := &sync.RWMutex{}
-	clientMap := make(map[utils.NetworkID]*Client)
+	h := &Hyper{
+		ctx:     ctx,
+		opts:    opts,
+		mu:      &sync.RWMutex{},
+		clients: make(map[utils.NetworkID]*Client),
+	}
 
 	for _, clientOpts := range opts.GetBlockchains() {
-		mu.Lock()
-		if _, ok := clientMap[clientOpts.NetworkId]; !ok {
-			nClient, err := NewClient(ctx, clientOpts)
-			if err != nil {
-				mu.Unlock()
-				return nil, errors.Wrapf(err, "failed to create hypersync client for network %s", clientOpts.NetworkId)
-			}
-			clientMap[clientOpts.NetworkId] = nClient
+		if _, ok := h.clients[clientOpts.NetworkId]; ok {
+			continue
 		}
-		mu.Unlock()
+		nClient, err := h.createClient(opts.GetNodesByNetworkId(clientOpts.NetworkId))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create hypersync client for network %s", clientOpts.NetworkId)
+		}
+		h.clients[clientOpts.NetworkId] = nClient
 	}
 
-	return &Hyper{
-		ctx:     ctx,
-		opts:    opts,
-		mu:      mu,
-		clients: clientMap,
-	}, nil
+	return h, nil
+}
+
+// createClient creates the client of the nodes of a network, failing over between them when there are several.
+func (h *Hyper) createClient(nodes []options.Node) (*Client, error) {
+	return NewFailoverClient(h.ctx, nodes, h.opts.GetFailoverOptions())
 }
 
-// GetClients returns a map of all blockchain clients managed by HyperSync.
+// AddClient creates a client for the network of the nodes and adds it, failing over between the nodes when
+// several are given. It returns an error when the network already has a client; use ReplaceClient to swap it.
+//
+// Example:
+//
+//	client, err := hyper.AddClient(node)
+//	if err != nil {
+//		return err
+//	}
+func (h *Hyper) AddClient(nodes ...options.Node) (*Client, error) {
+	if len(nodes) == 0 {
+		return nil, errors.New("at least one node is required")
+	}
+	networkId := nodes[0].NetworkId
+
+	h.mu.RLock()
+	_, exists := h.clients[networkId]
+	h.mu.RUnlock()
+	if exists {
+		return nil, errors.Errorf("network %s already has a client", networkId)
+	}
+
+	nClient, err := h.createClient(nodes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create hypersync client for network %s", networkId)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.clients[networkId]; ok {
+		return nil, errors.Errorf("network %s already has a client", networkId)
+	}
+	h.clients[networkId] = nClient
+	return nClient, nil
+}
+
+// ReplaceClient creates a client for the network of the nodes and swaps it in for the current one, or adds
+// it when the network has none. Holders of the previous client can keep using it.
+func (h *Hyper) ReplaceClient(nodes ...options.Node) (*Client, error) {
+	if len(nodes) == 0 {
+		return nil, errors.New("at least one node is required")
+	}
+	networkId := nodes[0].NetworkId
+
+	nClient, err := h.createClient(nodes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create hypersync client for network %s", networkId)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.clients[networkId] = nClient
+	return nClient, nil
+}
+
+// RemoveClient removes the client of the network and returns it. The boolean return value indicates whether
+// the network had a client.
+func (h *Hyper) RemoveClient(networkId utils.NetworkID) (*Client, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	c, ok := h.clients[networkId]
+	delete(h.clients, networkId)
+	return c, ok
+}
+
+// GetClients returns a map of all blockchain clients managed by HyperSync. The map is a copy, so clients
+// added or removed later are not reflected in it.
 func (h *Hyper) GetClients() map[utils.NetworkID]*Client {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
-	return h.clients
+	clients := make(map[utils.NetworkID]*Client, len(h.clients))
+	for networkId, c := range h.clients {
+		clients[networkId] = c
+	}
+	return clients
 }
 
-// GetClient returns a specific blockchain client by its network ID.
+// GetClient returns a specific blockchain client by its network ID. Clients of networks with several nodes
+// transparently retry requests on another node when one is unhealthy.
 // The boolean return value indicates whether the client was found.
 func (h *Hyper) GetClient(networkId utils.NetworkID) (*Client, bool) {
 	h.mu.RLock()
@@ -0,0 +1,205 @@
+package options
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	bigIntType   = reflect.TypeOf(big.Int{})
+
+	// placeholderPattern matches ${NAME} environment variable placeholders.
+	placeholderPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+)
+
+// ApplyDefaults sets every field of the struct v points to that holds its zero value to the value of its
+// default tag. Nested structs, non-nil struct pointers and slices of structs are visited as well.
+//
+// Durations are numbers of milliseconds, like the Ms fields of Node, and their defaults are given either
+// as an integer or with a unit, so default:"1ms" and default:"1" are the same.
+//
+// Example:
+//
+//	node := options.Node{Endpoint: "https://eth.hypersync.xyz", ApiToken: token}
+//	if err := options.ApplyDefaults(&node); err != nil {
+//		return err
+//	}
+//	// node.MaxNumRetries is now 12
+func ApplyDefaults(v any) error {
+	return visitFields(v, func(field reflect.StructField, value reflect.Value, path string) error {
+		tag, ok := field.Tag.Lookup("default")
+		if !ok || !value.IsZero() {
+			return nil
+		}
+		if err := setFromString(value, tag); err != nil {
+			return fmt.Errorf("invalid default %q of %s: %w", tag, path, err)
+		}
+		return nil
+	})
+}
+
+// ExpandEnv replaces the ${NAME} placeholders in every string field of the struct v points to with the
+// value of the NAME environment variable, so secrets such as API tokens can be kept out of config files.
+// A placeholder of an unset variable is an error naming the field and the variable.
+//
+// Example:
+//
+//	// apiToken: ${ENVIO_API_TOKEN}
+//	if err := options.ExpandEnv(&opts); err != nil {
+//		return err
+//	}
+func ExpandEnv(v any) error {
+	return visitFields(v, func(field reflect.StructField, value reflect.Value, path string) error {
+		if value.Kind() != reflect.String {
+			return nil
+		}
+		var missing string
+		expanded := placeholderPattern.ReplaceAllStringFunc(value.String(), func(match string) string {
+			name := placeholderPattern.FindStringSubmatch(match)[1]
+			env, ok := os.LookupEnv(name)
+			if !ok && missing == "" {
+				missing = name
+			}
+			return env
+		})
+		if missing != "" {
+			return fmt.Errorf("%s references the environment variable %s, which is not set", path, missing)
+		}
+		value.SetString(expanded)
+		return nil
+	})
+}
+
+// visitFields calls fn for every exported field of the struct v points to, then descends into the field.
+// The path of a field is the dotted list of yaml keys leading to it, such as blockchains[0].apiToken.
+func visitFields(v any, fn func(field reflect.StructField, value reflect.Value, path string) error) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return errors.New("options must be passed as a non-nil pointer")
+	}
+	return visitValue(rv.Elem(), "", fn)
+}
+
+func visitValue(v reflect.Value, path string, fn func(field reflect.StructField, value reflect.Value, path string) error) error {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		return visitValue(v.Elem(), path, fn)
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			if err := visitValue(v.Index(i), fmt.Sprintf("%s[%d]", path, i), fn); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			fieldPath := fieldKey(field)
+			if path != "" {
+				fieldPath = path + "." + fieldPath
+			}
+			if err := fn(field, v.Field(i), fieldPath); err != nil {
+				return err
+			}
+			if err := visitValue(v.Field(i), fieldPath, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fieldKey returns the yaml key of the field, or its name when it has none.
+func fieldKey(field reflect.StructField) string {
+	if key, _, _ := strings.Cut(field.Tag.Get("yaml"), ","); key != "" && key != "-" {
+		return key
+	}
+	return field.Name
+}
+
+// setFromString parses s into v according to the type of v. Pointers are allocated.
+func setFromString(v reflect.Value, s string) error {
+	switch {
+	case v.Type() == durationType:
+		ms, err := parseMilliseconds(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(ms))
+		return nil
+	case v.Kind() == reflect.Pointer && v.Type().Elem() == bigIntType:
+		n, ok := new(big.Int).SetString(s, 10)
+		if !ok {
+			return fmt.Errorf("%q is not an integer", s)
+		}
+		v.Set(reflect.ValueOf(n))
+		return nil
+	case v.Kind() == reflect.Pointer:
+		elem := reflect.New(v.Type().Elem())
+		if err := setFromString(elem.Elem(), s); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return fmt.Errorf("%q is not a boolean", s)
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", s)
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%q is not an unsigned integer", s)
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%q is not a number", s)
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// parseMilliseconds parses a number of milliseconds given as an integer, or as a duration with a unit
+// such as "1ms" or "2s".
+func parseMilliseconds(s string) (time.Duration, error) {
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Duration(n), nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("%q is neither a number of milliseconds nor a duration such as 500ms", s)
+	}
+	return d / time.Millisecond, nil
+}
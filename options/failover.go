@@ -0,0 +1,55 @@
+package options
+
+import (
+	"fmt"
+	"time"
+)
+
+// FailoverPolicy selects the endpoint a request of a network with several nodes is sent to first.
+type FailoverPolicy string
+
+const (
+	// FailoverPrimary sends requests to the first healthy node in configuration order, so later nodes act as
+	// secondaries. It is the default.
+	FailoverPrimary FailoverPolicy = "primary"
+
+	// FailoverRoundRobin spreads requests over the healthy nodes in turn.
+	FailoverRoundRobin FailoverPolicy = "round-robin"
+)
+
+// FailoverOptions configures how clients of networks with several nodes fail over between them. A request
+// failing on a node with a network error, a 5xx or a 429 status is retried on the next node, and the failing
+// node is skipped until its cooldown has passed.
+type FailoverOptions struct {
+	// Policy selects the node requests are sent to first.
+	Policy FailoverPolicy `mapstructure:"policy" yaml:"policy" json:"policy" default:"primary"`
+
+	// CooldownMs is the number of milliseconds a failing node is skipped for.
+	CooldownMs time.Duration `mapstructure:"cooldownMs" yaml:"cooldownMs" json:"cooldownMs" default:"30s"`
+}
+
+// Validate checks that the policy is known and the cooldown is not negative.
+func (f *FailoverOptions) Validate() error {
+	switch f.Policy {
+	case FailoverPrimary, FailoverRoundRobin:
+	default:
+		return fmt.Errorf("invalid failover policy %q, use %s or %s", string(f.Policy), FailoverPrimary, FailoverRoundRobin)
+	}
+	if f.CooldownMs < 0 {
+		return fmt.Errorf("failover cooldown must not be negative, got %dms", f.CooldownMs)
+	}
+	return nil
+}
+
+// GetCooldown returns the cooldown of a failing node as a duration.
+func (f *FailoverOptions) GetCooldown() time.Duration {
+	return f.CooldownMs * time.Millisecond
+}
+
+// DefaultFailoverOptions returns the primary/secondary policy with a cooldown of 30 seconds.
+func DefaultFailoverOptions() *FailoverOptions {
+	return &FailoverOptions{
+		Policy:     FailoverPrimary,
+		CooldownMs: 30000,
+	}
+}
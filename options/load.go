@@ -0,0 +1,190 @@
+package options
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// EnvPrefix prefixes the environment variables FromEnv reads. The variable of a Node field is the prefix
+	// followed by the upper snake case yaml key of the field, such as ENVIO_API_TOKEN for apiToken or
+	// ENVIO_NETWORK_ID for networkId.
+	EnvPrefix = "ENVIO_"
+
+	// ConfigEnv is the environment variable holding the path of a config file FromEnv starts from.
+	ConfigEnv = EnvPrefix + "CONFIG"
+
+	// LogLevelEnv is the environment variable holding the log level read by FromEnv.
+	LogLevelEnv = EnvPrefix + "LOG_LEVEL"
+)
+
+// Load reads options from a YAML (.yaml, .yml) or JSON (.json) file. Unknown keys are rejected, ${NAME}
+// placeholders are replaced with environment variables, default tags are applied and the options are
+// validated, including the stream options.
+//
+// A node without endpoints gets those of its network id from the network registry. A stream section without a concurrency gets
+// the number of CPUs.
+//
+// Example:
+//
+//	// config.yaml:
+//	//
+//	// blockchains:
+//	//   - networkId: 1
+//	//     apiToken: ${ENVIO_API_TOKEN}
+//	opts, err := options.Load("config.yaml")
+//	if err != nil {
+//		return err
+//	}
+//	hyper, err := hypersyncgo.NewHyper(ctx, *opts)
+func Load(path string) (*Options, error) {
+	opts, err := decodeFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := opts.prepare(); err != nil {
+		return nil, errors.Wrapf(err, "invalid config file %s", path)
+	}
+	return opts, nil
+}
+
+// FromEnv reads options from environment variables. When ConfigEnv is set, the options of that file are
+// read first. The node fields set in the environment, such as ENVIO_API_TOKEN, ENVIO_NETWORK_ID,
+// ENVIO_ENDPOINT or ENVIO_MAX_NUM_RETRIES, then override those of every node, or make up a single node
+// when the file has none. LogLevelEnv sets the log level. The options are completed and validated as by Load.
+//
+// Example:
+//
+//	// ENVIO_NETWORK_ID=8453 ENVIO_API_TOKEN=... go run .
+//	opts, err := options.FromEnv()
+//	if err != nil {
+//		return err
+//	}
+//	client, err := hypersyncgo.NewClient(ctx, opts.Blockchains[0])
+func FromEnv() (*Options, error) {
+	opts := &Options{}
+	if path := os.Getenv(ConfigEnv); path != "" {
+		loaded, err := decodeFile(path)
+		if err != nil {
+			return nil, err
+		}
+		opts = loaded
+	}
+
+	if level, ok := os.LookupEnv(LogLevelEnv); ok {
+		if err := opts.LogLevel.UnmarshalText([]byte(level)); err != nil {
+			return nil, fmt.Errorf("invalid %s %q, use debug, info, warn or error", LogLevelEnv, level)
+		}
+	}
+
+	if len(opts.Blockchains) == 0 {
+		opts.Blockchains = []Node{{}}
+	}
+	for i := range opts.Blockchains {
+		if err := overlayEnv(&opts.Blockchains[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := opts.prepare(); err != nil {
+		return nil, errors.Wrap(err, "invalid options from environment")
+	}
+	return opts, nil
+}
+
+// decodeFile decodes the options of a YAML or JSON file without completing them.
+func decodeFile(path string) (*Options, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read config file")
+	}
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		// YAML is converted to JSON so both formats decode the same way: yaml.v3 only accepts durations
+		// with a unit, while the Ms fields of Node hold plain numbers of milliseconds.
+		var document any
+		if err := yaml.Unmarshal(data, &document); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse config file %s", path)
+		}
+		if document == nil {
+			return &Options{}, nil
+		}
+		if data, err = json.Marshal(document); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse config file %s", path)
+		}
+	case ".json":
+	default:
+		return nil, fmt.Errorf("unsupported config file extension %q of %s, use .yaml, .yml or .json", ext, path)
+	}
+
+	opts := &Options{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(opts); err != nil && !errors.Is(err, io.EOF) {
+		return nil, errors.Wrapf(err, "failed to parse config file %s", path)
+	}
+	return opts, nil
+}
+
+// prepare resolves placeholders, applies defaults and validates the options.
+func (o *Options) prepare() error {
+	if err := ExpandEnv(o); err != nil {
+		return err
+	}
+	if err := ApplyDefaults(o); err != nil {
+		return err
+	}
+
+	if len(o.Blockchains) == 0 {
+		return errors.New("no blockchains are configured, add at least one node under blockchains")
+	}
+	for i := range o.Blockchains {
+		o.Blockchains[i].completeFromNetwork()
+	}
+	if o.Stream != nil && o.Stream.Concurrency == nil {
+		o.Stream.Concurrency = DefaultStreamOptions().Concurrency
+	}
+
+	return o.Validate()
+}
+
+// overlayEnv sets the fields of the node that have an environment variable set.
+func overlayEnv(node *Node) error {
+	v := reflect.ValueOf(node).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := envName(fieldKey(t.Field(i)))
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+		if err := setFromString(v.Field(i), value); err != nil {
+			return fmt.Errorf("invalid %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// envName returns the environment variable of a yaml key, such as ENVIO_RPC_ENDPOINT for rpcEndpoint.
+func envName(key string) string {
+	var b strings.Builder
+	b.WriteString(EnvPrefix)
+	for i, r := range key {
+		if i > 0 && unicode.IsUpper(r) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
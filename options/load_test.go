@@ -0,0 +1,213 @@
+package options
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/enviodev/hypersync-client-go/utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func writeConfig(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("YAML with placeholders and defaults", func(t *testing.T) {
+		t.Setenv("TEST_HYPERSYNC_TOKEN", "secret")
+		path := writeConfig(t, "config.yaml", `
+logLevel: debug
+blockchains:
+  - networkId: 1
+    apiToken: ${TEST_HYPERSYNC_TOKEN}
+  - type: base
+    networkId: 8453
+    endpoint: https://base.hypersync.xyz
+    apiToken: token-${TEST_HYPERSYNC_TOKEN}
+    maxNumRetries: 3
+stream:
+  concurrency: 4
+  verify:
+    headerHash: true
+failover:
+  policy: round-robin
+`)
+		opts, err := Load(path)
+		require.NoError(t, err)
+		require.Equal(t, zapcore.DebugLevel, opts.LogLevel)
+		require.Len(t, opts.Blockchains, 2)
+
+		eth := opts.Blockchains[0]
+		require.Equal(t, "secret", eth.ApiToken)
+		require.Equal(t, "https://1.hypersync.xyz", eth.Endpoint)
+		require.Equal(t, utils.EthereumNetworkID.ToNetwork(), eth.Type)
+		require.Equal(t, 12, eth.MaxNumRetries)
+		require.Equal(t, time.Duration(1), eth.RetryBackoffMs)
+		require.Equal(t, time.Duration(200), eth.RetryBaseMs)
+		require.Equal(t, time.Duration(5000), eth.RetryCeilingMs)
+
+		base := opts.Blockchains[1]
+		require.Equal(t, "token-secret", base.ApiToken)
+		require.Equal(t, 3, base.MaxNumRetries)
+
+		require.Equal(t, big.NewInt(4), opts.GetStreamOptions().Concurrency)
+		require.Equal(t, big.NewInt(4096), opts.GetStreamOptions().BatchSize)
+		require.True(t, opts.GetStreamOptions().Verify.HeaderHash)
+		require.Equal(t, FailoverRoundRobin, opts.GetFailoverOptions().Policy)
+		require.Equal(t, time.Duration(30000), opts.GetFailoverOptions().CooldownMs)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		path := writeConfig(t, "config.json", `{"blockchains": [{"networkId": 10, "apiToken": "token", "retryBaseMs": 50}]}`)
+		opts, err := Load(path)
+		require.NoError(t, err)
+		require.Equal(t, "https://10.hypersync.xyz", opts.Blockchains[0].Endpoint)
+		require.Equal(t, time.Duration(50), opts.Blockchains[0].RetryBaseMs)
+		require.Nil(t, opts.Stream)
+		require.Equal(t, DefaultStreamOptions(), opts.GetStreamOptions())
+	})
+
+	testCases := []struct {
+		name    string
+		file    string
+		content string
+		err     string
+	}{
+		{
+			name:    "Unset placeholder",
+			file:    "config.yaml",
+			content: "blockchains:\n  - networkId: 1\n    apiToken: ${TEST_HYPERSYNC_UNSET}\n",
+			err:     "blockchains[0].apiToken references the environment variable TEST_HYPERSYNC_UNSET, which is not set",
+		},
+		{
+			name:    "Unknown key",
+			file:    "config.yaml",
+			content: "blockchains:\n  - networkId: 1\n    apiTokn: token\n",
+			err:     `unknown field "apiTokn"`,
+		},
+		{
+			name:    "Unknown JSON key",
+			file:    "config.json",
+			content: `{"blockchain": []}`,
+			err:     `unknown field "blockchain"`,
+		},
+		{
+			name:    "Missing token",
+			file:    "config.yaml",
+			content: "blockchains:\n  - networkId: 1\n",
+			err:     "blockchain node [0] (1): api token is required",
+		},
+		{
+			name:    "Missing endpoint",
+			file:    "config.yaml",
+			content: "blockchains:\n  - apiToken: token\n",
+			err:     "endpoint is required, set it or the network id",
+		},
+		{
+			name:    "Invalid endpoint",
+			file:    "config.yaml",
+			content: "blockchains:\n  - apiToken: token\n    endpoint: eth.hypersync.xyz\n",
+			err:     `endpoint "eth.hypersync.xyz" must be an http or https url`,
+		},
+		{
+			name:    "Retry base above ceiling",
+			file:    "config.yaml",
+			content: "blockchains:\n  - networkId: 1\n    apiToken: token\n    retryBaseMs: 6000\n",
+			err:     "retry base of 6000ms is above the retry ceiling of 5000ms",
+		},
+		{
+			name:    "Invalid stream options",
+			file:    "config.yaml",
+			content: "blockchains:\n  - networkId: 1\n    apiToken: token\nstream:\n  batchSize: 10\n  minBatchSize: 100\n",
+			err:     "stream: invalid stream batch size provided, 10 is below the min batch size 100",
+		},
+		{
+			name:    "Invalid failover policy",
+			file:    "config.yaml",
+			content: "blockchains:\n  - networkId: 1\n    apiToken: token\nfailover:\n  policy: random\n",
+			err:     `failover: invalid failover policy "random", use primary or round-robin`,
+		},
+		{
+			name:    "No blockchains",
+			file:    "config.yaml",
+			content: "",
+			err:     "no blockchains are configured",
+		},
+		{
+			name:    "Unsupported extension",
+			file:    "config.toml",
+			content: "",
+			err:     `unsupported config file extension ".toml"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Load(writeConfig(t, tc.file, tc.content))
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Run("Node from variables", func(t *testing.T) {
+		t.Setenv(ConfigEnv, "")
+		t.Setenv("ENVIO_API_TOKEN", "env-token")
+		t.Setenv("ENVIO_NETWORK_ID", "8453")
+		t.Setenv("ENVIO_MAX_NUM_RETRIES", "5")
+		t.Setenv("ENVIO_RETRY_BACKOFF_MS", "250ms")
+		t.Setenv("ENVIO_STRICT_DECODING", "true")
+		t.Setenv(LogLevelEnv, "warn")
+
+		opts, err := FromEnv()
+		require.NoError(t, err)
+		require.Equal(t, zapcore.WarnLevel, opts.LogLevel)
+		require.Len(t, opts.Blockchains, 1)
+
+		node := opts.Blockchains[0]
+		require.Equal(t, utils.NetworkID(8453), node.NetworkId)
+		require.Equal(t, "https://8453.hypersync.xyz", node.Endpoint)
+		require.Equal(t, "env-token", node.ApiToken)
+		require.Equal(t, 5, node.MaxNumRetries)
+		require.Equal(t, time.Duration(250), node.RetryBackoffMs)
+		require.True(t, node.StrictDecoding)
+	})
+
+	t.Run("Variables override the config file", func(t *testing.T) {
+		t.Setenv(ConfigEnv, writeConfig(t, "config.yaml", "blockchains:\n  - networkId: 1\n    apiToken: file-token\n  - networkId: 10\n"))
+		t.Setenv("ENVIO_API_TOKEN", "env-token")
+
+		opts, err := FromEnv()
+		require.NoError(t, err)
+		require.Len(t, opts.Blockchains, 2)
+		require.Equal(t, "env-token", opts.Blockchains[0].ApiToken)
+		require.Equal(t, "env-token", opts.Blockchains[1].ApiToken)
+		require.Equal(t, "https://10.hypersync.xyz", opts.Blockchains[1].Endpoint)
+	})
+
+	t.Run("Invalid variable", func(t *testing.T) {
+		t.Setenv(ConfigEnv, "")
+		t.Setenv("ENVIO_NETWORK_ID", "base")
+		_, err := FromEnv()
+		require.EqualError(t, err, `invalid ENVIO_NETWORK_ID: "base" is not an unsigned integer`)
+	})
+}
+
+func TestApplyDefaults(t *testing.T) {
+	node := Node{MaxNumRetries: 2}
+	require.NoError(t, ApplyDefaults(&node))
+	require.Equal(t, 2, node.MaxNumRetries)
+	require.Equal(t, time.Duration(1), node.RetryBackoffMs)
+
+	var invalid struct {
+		Count int `default:"many"`
+	}
+	require.EqualError(t, ApplyDefaults(&invalid), `invalid default "many" of Count: "many" is not an integer`)
+	require.Error(t, ApplyDefaults(node))
+}
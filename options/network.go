@@ -0,0 +1,66 @@
+package options
+
+import (
+	"fmt"
+
+	"github.com/enviodev/hypersync-client-go/utils"
+)
+
+// NodeForNetwork returns a node for a registered network, with the HyperSync and HyperRPC endpoints of
+// the network registry and the defaults of the Node fields applied. Networks that are not registered can
+// be added, and the endpoints of registered ones overridden, with utils.RegisterNetwork.
+//
+// Example:
+//
+//	node, err := options.NodeForNetwork(utils.BaseNetworkID, os.Getenv("ENVIO_API_TOKEN"))
+//	if err != nil {
+//		return err
+//	}
+//	client, err := hypersyncgo.NewClient(ctx, node)
+func NodeForNetwork(id utils.NetworkID, token string) (Node, error) {
+	info, ok := utils.GetNetworkInfo(id)
+	if !ok {
+		return Node{}, fmt.Errorf("unknown network %s, register it with utils.RegisterNetwork or set the endpoint of the node", id)
+	}
+	return nodeForNetworkInfo(info, token)
+}
+
+// NodeForNetworkName returns a node for the registered network of the name or alias, such as "base" or
+// "arbitrum", as NodeForNetwork does.
+func NodeForNetworkName(name string, token string) (Node, error) {
+	info, ok := utils.GetNetworkInfoByName(name)
+	if !ok {
+		return Node{}, fmt.Errorf("unknown network %q, register it with utils.RegisterNetwork or set the endpoint of the node", name)
+	}
+	return nodeForNetworkInfo(info, token)
+}
+
+func nodeForNetworkInfo(info utils.NetworkInfo, token string) (Node, error) {
+	node := Node{
+		Type:        info.Name,
+		NetworkId:   info.ID,
+		Endpoint:    info.HyperSyncURL,
+		RpcEndpoint: info.HyperRPCURL,
+		ApiToken:    token,
+	}
+	if err := ApplyDefaults(&node); err != nil {
+		return Node{}, err
+	}
+	return node, nil
+}
+
+// completeFromNetwork fills the type and endpoints a node leaves empty from its network id.
+func (n *Node) completeFromNetwork() {
+	if !n.NetworkId.IsValid() {
+		return
+	}
+	if info, ok := utils.GetNetworkInfo(n.NetworkId); ok && n.Type == "" {
+		n.Type = info.Name
+	}
+	if n.Endpoint == "" {
+		n.Endpoint = utils.HyperSyncURL(n.NetworkId)
+	}
+	if n.RpcEndpoint == "" {
+		n.RpcEndpoint = utils.HyperRPCURL(n.NetworkId)
+	}
+}
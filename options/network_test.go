@@ -0,0 +1,29 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/enviodev/hypersync-client-go/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeForNetwork(t *testing.T) {
+	node, err := NodeForNetwork(utils.BaseNetworkID, "token")
+	require.NoError(t, err)
+	require.Equal(t, utils.BaseNetwork, node.Type)
+	require.Equal(t, "https://8453.hypersync.xyz", node.Endpoint)
+	require.Equal(t, "https://8453.rpc.hypersync.xyz", node.RpcEndpoint)
+	require.Equal(t, "token", node.ApiToken)
+	require.Equal(t, 12, node.MaxNumRetries)
+	require.NoError(t, node.Validate())
+
+	byName, err := NodeForNetworkName("Base", "token")
+	require.NoError(t, err)
+	require.Equal(t, node, byName)
+
+	_, err = NodeForNetwork(999999999, "token")
+	require.EqualError(t, err, "unknown network 999999999, register it with utils.RegisterNetwork or set the endpoint of the node")
+
+	_, err = NodeForNetworkName("nowhere", "token")
+	require.ErrorContains(t, err, `unknown network "nowhere"`)
+}
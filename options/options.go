@@ -3,7 +3,9 @@ package options
 import (
 	"fmt"
 	"math"
+	"net/url"
 
+	"github.com/enviodev/hypersync-client-go/types"
 	"github.com/enviodev/hypersync-client-go/utils"
 	"go.uber.org/zap/zapcore"
 	"time"
@@ -12,9 +14,13 @@ import (
 // Options represents the configuration options for network nodes.
 type Options struct {
 	// LogLevel ...
-	LogLevel zapcore.Level `json:"logLevel"`
+	LogLevel zapcore.Level `mapstructure:"logLevel" yaml:"logLevel" json:"logLevel"`
 	// Nodes is a slice of Node representing the network nodes.
 	Blockchains []Node `mapstructure:"blockchains" yaml:"blockchains" json:"blockchains"`
+	// Stream is the optional stream configuration shared by the nodes.
+	Stream *StreamOptions `mapstructure:"stream" yaml:"stream" json:"stream"`
+	// Failover configures how the clients of networks listed by several nodes fail over between them.
+	Failover *FailoverOptions `mapstructure:"failover" yaml:"failover" json:"failover"`
 }
 
 func (o *Options) Validate() error {
@@ -23,6 +29,16 @@ func (o *Options) Validate() error {
 			return fmt.Errorf("blockchain node [%d] (%s): %w", i, node.NetworkId, err)
 		}
 	}
+	if o.Stream != nil {
+		if err := o.Stream.Validate(); err != nil {
+			return fmt.Errorf("stream: %w", err)
+		}
+	}
+	if o.Failover != nil {
+		if err := o.Failover.Validate(); err != nil {
+			return fmt.Errorf("failover: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -31,6 +47,33 @@ func (o *Options) GetBlockchains() []Node {
 	return o.Blockchains
 }
 
+// GetFailoverOptions returns the configured failover options, or the default ones when none are configured.
+func (o *Options) GetFailoverOptions() *FailoverOptions {
+	if o.Failover != nil {
+		return o.Failover
+	}
+	return DefaultFailoverOptions()
+}
+
+// GetNodesByNetworkId returns every node of the network, in configuration order.
+func (o *Options) GetNodesByNetworkId(networkId utils.NetworkID) []Node {
+	var nodes []Node
+	for _, node := range o.Blockchains {
+		if node.NetworkId == networkId {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// GetStreamOptions returns the configured stream options, or the default ones when none are configured.
+func (o *Options) GetStreamOptions() *StreamOptions {
+	if o.Stream != nil {
+		return o.Stream
+	}
+	return DefaultStreamOptions()
+}
+
 func (o *Options) GetNodeByNetworkId(networkId utils.NetworkID) (*Node, bool) {
 	for _, node := range o.Blockchains {
 		if node.NetworkId == networkId {
@@ -51,7 +94,7 @@ type Node struct {
 	// Endpoint represents the network endpoint of the node.
 	Endpoint string `mapstructure:"endpoint" yaml:"endpoint" json:"endpoint"`
 
-	// RpcEndpoint ...
+	// RpcEndpoint is the optional JSON-RPC endpoint of the node. It is dialed on first use.
 	RpcEndpoint string `mapstructure:"rpcEndpoint" yaml:"rpcEndpoint" json:"rpcEndpoint"`
 
 	// ApiToken is the HyperSync API token (required). It is sent as an
@@ -68,19 +111,43 @@ type Node struct {
 	RetryBackoffMs time.Duration `mapstructure:"retryBackoffMs" yaml:"retryBackoffMs" json:"retryBackoffMs" default:"1ms"`
 
 	// RetryBaseMs is the initial wait time for request backoff.
-	RetryBaseMs time.Duration `mapstructure:"retryBaseMs" yaml:"retryBaseMs" json:"retryBaseMs"`
+	RetryBaseMs time.Duration `mapstructure:"retryBaseMs" yaml:"retryBaseMs" json:"retryBaseMs" default:"200"`
 
 	// RetryCeilingMs is the ceiling time for request backoff.
-	RetryCeilingMs time.Duration `mapstructure:"retryCeilingMs" yaml:"retryCeilingMs" json:"retryCeilingMs"`
+	RetryCeilingMs time.Duration `mapstructure:"retryCeilingMs" yaml:"retryCeilingMs" json:"retryCeilingMs" default:"5000"`
+
+	// StrictDecoding fails responses holding columns of an unexpected type or with an unknown name. By default
+	// such columns are skipped and reported in the decode report of the response.
+	StrictDecoding bool `mapstructure:"strictDecoding" yaml:"strictDecoding" json:"strictDecoding"`
+
+	// Probe, when set to warn or fail, checks at client creation that the HyperSync endpoint, and the RPC
+	// endpoint when configured, serve the chain of NetworkId. The discovered chain metadata is cached on the
+	// client.
+	Probe ProbeMode `mapstructure:"probe" yaml:"probe" json:"probe"`
 }
 
-// Validate checks that all required Node fields are set.
+// Validate checks that all required Node fields are set and that the retry settings are consistent.
 func (n *Node) Validate() error {
 	if n.ApiToken == "" {
-		return fmt.Errorf("api token is required")
+		return fmt.Errorf("api token is required, get one at https://docs.envio.dev/docs/HyperSync/api-tokens")
 	}
 	if n.Endpoint == "" {
-		return fmt.Errorf("endpoint is required")
+		return fmt.Errorf("endpoint is required, set it or the network id")
+	}
+	if u, err := url.Parse(n.Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("endpoint %q must be an http or https url", n.Endpoint)
+	}
+	if n.MaxNumRetries < 0 {
+		return fmt.Errorf("max num retries must not be negative, got %d", n.MaxNumRetries)
+	}
+	if n.RetryBaseMs < 0 || n.RetryBackoffMs < 0 || n.RetryCeilingMs < 0 {
+		return fmt.Errorf("retry base, backoff and ceiling must not be negative")
+	}
+	if n.RetryCeilingMs > 0 && n.RetryBaseMs > n.RetryCeilingMs {
+		return fmt.Errorf("retry base of %dms is above the retry ceiling of %dms", n.RetryBaseMs, n.RetryCeilingMs)
+	}
+	if err := n.Probe.Validate(); err != nil {
+		return err
 	}
 	return nil
 }
@@ -99,6 +166,14 @@ func (n *Node) GetNetworkID() int64 {
 	return int64(n.NetworkId)
 }
 
+// GetDecodeMode returns the mode responses are decoded in.
+func (n *Node) GetDecodeMode() types.DecodeMode {
+	if n.StrictDecoding {
+		return types.DecodeStrict
+	}
+	return types.DecodeLenient
+}
+
 // GetEndpoint returns the network endpoint of the node.
 func (n *Node) GetEndpoint() string {
 	return n.Endpoint
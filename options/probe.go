@@ -0,0 +1,35 @@
+package options
+
+import "fmt"
+
+// ProbeMode selects whether a client probes its endpoints at creation and how it reacts when they do not
+// serve the configured network.
+type ProbeMode string
+
+const (
+	// ProbeOff skips the probe. It is the default.
+	ProbeOff ProbeMode = ""
+
+	// ProbeWarn probes the endpoints and logs a warning when a probe fails or the endpoints serve another
+	// chain than the configured one.
+	ProbeWarn ProbeMode = "warn"
+
+	// ProbeFail probes the endpoints and fails the client creation when a probe fails or the endpoints serve
+	// another chain than the configured one.
+	ProbeFail ProbeMode = "fail"
+)
+
+// Validate checks that the mode is known.
+func (m ProbeMode) Validate() error {
+	switch m {
+	case ProbeOff, "off", ProbeWarn, ProbeFail:
+		return nil
+	default:
+		return fmt.Errorf("invalid probe mode %q, use off, warn or fail", string(m))
+	}
+}
+
+// Enabled reports whether the endpoints are probed.
+func (m ProbeMode) Enabled() bool {
+	return m == ProbeWarn || m == ProbeFail
+}
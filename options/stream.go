@@ -30,7 +30,7 @@ type StreamOptions struct {
 	Concurrency *big.Int `mapstructure:"concurrency" yaml:"concurrency" json:"concurrency"`
 
 	// BatchSize is the initial batch size. Size would be adjusted based on response size during execution.
-	BatchSize *big.Int `mapstructure:"batchSize" yaml:"batchSize" json:"batchSize"`
+	BatchSize *big.Int `mapstructure:"batchSize" yaml:"batchSize" json:"batchSize" default:"4096"`
 
 	// DisableAcknowledgements streaming as soon as all retrievals are completed will signal completion of workload.
 	// WARNING: Disable on your own will, you should not touch this if you're not sure why.
@@ -42,14 +42,38 @@ type StreamOptions struct {
 
 	// MinBatchSize is the minimum batch size that could be used during dynamic adjustment.
 	MinBatchSize *big.Int `mapstructure:"minBatchSize" yaml:"minBatchSize" json:"minBatchSize"`
+
+	// Verify, when set, verifies the integrity of every response. Mismatches are reported on the stream Err()
+	// channel when it has room and kept by the stream VerifyErrors() method, while the response is still
+	// delivered.
+	Verify *VerifyOptions `mapstructure:"verify" yaml:"verify" json:"verify"`
+
+	// ValidateContinuity checks that the streamed blocks and logs form a continuous chain: parent hashes link
+	// consecutive blocks, block numbers are contiguous when the query includes all blocks, and logs come in
+	// strictly increasing (block_number, log_index) order without duplicates. Violations are reported on the
+	// stream Err() channel when it has room and kept by the stream ContinuityErrors() method. Blocks must select
+	// number, hash and parent_hash; logs block_number and log_index.
+	ValidateContinuity bool `mapstructure:"validateContinuity" yaml:"validateContinuity" json:"validateContinuity"`
 }
 
 func (s *StreamOptions) Validate() error {
 	if s.Concurrency == nil || s.Concurrency.Cmp(big.NewInt(0)) <= 0 {
-		return fmt.Errorf("invalid stream concurrency provided")
+		return fmt.Errorf("invalid stream concurrency provided, it must be at least 1")
 	}
 	if s.BatchSize == nil || s.BatchSize.Cmp(big.NewInt(0)) <= 0 {
-		return fmt.Errorf("invalid stream batch size provided")
+		return fmt.Errorf("invalid stream batch size provided, it must be at least 1")
+	}
+	if s.MinBatchSize != nil && s.MinBatchSize.Sign() <= 0 {
+		return fmt.Errorf("invalid stream min batch size provided, it must be at least 1")
+	}
+	if s.MinBatchSize != nil && s.MaxBatchSize != nil && s.MaxBatchSize.Cmp(s.MinBatchSize) < 0 {
+		return fmt.Errorf("invalid stream max batch size provided, %s is below the min batch size %s", s.MaxBatchSize, s.MinBatchSize)
+	}
+	if s.MinBatchSize != nil && s.BatchSize.Cmp(s.MinBatchSize) < 0 {
+		return fmt.Errorf("invalid stream batch size provided, %s is below the min batch size %s", s.BatchSize, s.MinBatchSize)
+	}
+	if s.MaxBatchSize != nil && s.BatchSize.Cmp(s.MaxBatchSize) > 0 {
+		return fmt.Errorf("invalid stream batch size provided, %s is above the max batch size %s", s.BatchSize, s.MaxBatchSize)
 	}
 	return nil
 }
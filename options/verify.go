@@ -0,0 +1,35 @@
+package options
+
+// VerifyOptions selects the integrity checks the verify package runs on query responses.
+type VerifyOptions struct {
+	// HeaderHash recomputes the hash of every block header and compares it to the block hash.
+	// Every header field must be selected. The check of a Cancun block without a requests hash that
+	// does not match is skipped, as it may be a Prague block whose requests hash was not returned.
+	HeaderHash bool `mapstructure:"headerHash" yaml:"headerHash" json:"headerHash"`
+
+	// TransactionsRoot recomputes the transactions root of every block from its transactions and compares it
+	// to the block transactions root. Every transaction of the selected blocks must be selected.
+	TransactionsRoot bool `mapstructure:"transactionsRoot" yaml:"transactionsRoot" json:"transactionsRoot"`
+
+	// TransactionHash recomputes the hash of every transaction from its RLP encoding and compares it to the
+	// transaction hash.
+	TransactionHash bool `mapstructure:"transactionHash" yaml:"transactionHash" json:"transactionHash"`
+
+	// Sender recovers the sender of every transaction from its signature and compares it to the from address.
+	Sender bool `mapstructure:"sender" yaml:"sender" json:"sender"`
+}
+
+// Enabled reports whether at least one check is enabled.
+func (v *VerifyOptions) Enabled() bool {
+	return v != nil && (v.HeaderHash || v.TransactionsRoot || v.TransactionHash || v.Sender)
+}
+
+// DefaultVerifyOptions returns options enabling every integrity check.
+func DefaultVerifyOptions() *VerifyOptions {
+	return &VerifyOptions{
+		HeaderHash:       true,
+		TransactionsRoot: true,
+		TransactionHash:  true,
+		Sender:           true,
+	}
+}
@@ -1 +1,3 @@
+// Package parquet exports HyperSync blocks, transactions, logs and traces as tables, with a column per
+// selected field, and writes them as Parquet files.
 package parquet
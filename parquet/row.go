@@ -0,0 +1,208 @@
+package parquet
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow/go/v10/arrow"
+	"github.com/enviodev/hypersync-client-go/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+// Columns returns the fields carried by the entity type, such as types.Log, in the order they are given.
+// Fields the entity does not carry are left out.
+func Columns(entity any, fields []string) []string {
+	indexes := fieldIndexes(reflect.TypeOf(entity))
+	toReturn := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if _, ok := indexes[field]; ok {
+			toReturn = append(toReturn, field)
+		}
+	}
+	return toReturn
+}
+
+// ColumnType returns the Parquet column type the Writer uses for the column of the entity type, and false
+// when the entity does not carry the column. Unsigned and signed integers are written as 64-bit integer
+// columns, or 8-bit ones for uint8 fields such as the transaction type. Quantities HyperSync serves as 64-bit
+// integers, such as block numbers, are written as 64-bit integer columns too. Booleans are written as boolean
+// columns and timestamps as timestamps in seconds. Other values, including 256-bit quantities such as values
+// and gas prices, are written as strings formatted as by Row: Parquet files written with Arrow v10 cannot
+// hold 256-bit decimals, so they keep their full precision as decimal strings.
+func ColumnType(entity any, column string) (arrow.DataType, bool) {
+	t := reflect.TypeOf(entity)
+	if t == nil {
+		return nil, false
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	index, ok := fieldIndexes(t)[column]
+	if !ok {
+		return nil, false
+	}
+
+	field := t.Field(index).Type
+	if field.Kind() == reflect.Pointer {
+		field = field.Elem()
+	}
+	switch {
+	case field == bigIntType:
+		if served, ok := servedTypes(t)[column]; ok && served.ID() == arrow.UINT64 {
+			return arrow.PrimitiveTypes.Uint64, true
+		}
+		return arrow.BinaryTypes.String, true
+	case field == timeType:
+		return arrow.FixedWidthTypes.Timestamp_s, true
+	case field.Implements(textMarshalerType) || reflect.PointerTo(field).Implements(textMarshalerType):
+		return arrow.BinaryTypes.String, true
+	case field.Kind() == reflect.Uint8:
+		return arrow.PrimitiveTypes.Uint8, true
+	case field.Kind() >= reflect.Uint && field.Kind() <= reflect.Uint64:
+		return arrow.PrimitiveTypes.Uint64, true
+	case field.Kind() >= reflect.Int && field.Kind() <= reflect.Int64:
+		return arrow.PrimitiveTypes.Int64, true
+	case field.Kind() == reflect.Bool:
+		return arrow.FixedWidthTypes.Boolean, true
+	default:
+		return arrow.BinaryTypes.String, true
+	}
+}
+
+// servedTypes maps the columns of the entity type to the types HyperSync serves them with.
+func servedTypes(t reflect.Type) map[string]arrow.DataType {
+	var schema *arrow.Schema
+	switch t {
+	case reflect.TypeOf(types.Block{}):
+		schema = types.BlockHeaderSchema(nil)
+	case reflect.TypeOf(types.Transaction{}):
+		schema = types.TransactionSchema(nil)
+	case reflect.TypeOf(types.Log{}):
+		schema = types.LogSchema(nil)
+	case reflect.TypeOf(types.Trace{}):
+		schema = types.TraceSchema(nil)
+	default:
+		return nil
+	}
+
+	toReturn := make(map[string]arrow.DataType, len(schema.Fields()))
+	for _, field := range schema.Fields() {
+		toReturn[field.Name] = field.Type
+	}
+	return toReturn
+}
+
+// Row formats the columns of the entity, a struct or a pointer to one. Missing values are nil.
+//
+// Numbers are formatted in decimal, binary data, hashes and addresses in 0x-prefixed hex, timestamps as
+// unix seconds and nested values, such as access lists, as JSON.
+func Row(entity any, columns []string) ([]*string, error) {
+	value := reflect.ValueOf(entity)
+	if value.Kind() == reflect.Pointer {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("unsupported entity type: %T", entity)
+	}
+
+	indexes := fieldIndexes(value.Type())
+	toReturn := make([]*string, len(columns))
+	for i, column := range columns {
+		index, ok := indexes[column]
+		if !ok {
+			return nil, fmt.Errorf("unknown column for %s: %s", value.Type().Name(), column)
+		}
+		formatted, err := format(value.Field(index))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to format column: %s", column)
+		}
+		toReturn[i] = formatted
+	}
+	return toReturn, nil
+}
+
+// fieldIndexes maps the JSON names of the struct fields to their index.
+func fieldIndexes(t reflect.Type) map[string]int {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	toReturn := make(map[string]int)
+	if t.Kind() != reflect.Struct {
+		return toReturn
+	}
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			toReturn[name] = i
+		}
+	}
+	return toReturn
+}
+
+var (
+	bigIntType        = reflect.TypeOf(big.Int{})
+	timeType          = reflect.TypeOf(time.Time{})
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// format formats a field value, returning nil for nil pointers.
+func format(value reflect.Value) (*string, error) {
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil, nil
+		}
+		if value.Type().Elem() == bigIntType {
+			formatted := value.Interface().(*big.Int).String()
+			return &formatted, nil
+		}
+		value = value.Elem()
+	}
+
+	var formatted string
+	switch {
+	case value.Type() == timeType:
+		formatted = strconv.FormatInt(value.Interface().(time.Time).Unix(), 10)
+	case value.Kind() == reflect.Slice && value.Type().Elem().Kind() == reflect.Uint8:
+		formatted = hexutil.Encode(value.Bytes())
+	case value.Type().Implements(textMarshalerType):
+		text, err := value.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		formatted = string(text)
+	case reflect.PointerTo(value.Type()).Implements(textMarshalerType) && value.CanAddr():
+		text, err := value.Addr().Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		formatted = string(text)
+	case value.Kind() == reflect.Array && value.Type().Elem().Kind() == reflect.Uint8:
+		bytes := make([]byte, value.Len())
+		reflect.Copy(reflect.ValueOf(bytes), value)
+		formatted = hexutil.Encode(bytes)
+	case value.Kind() == reflect.String:
+		formatted = value.String()
+	case value.CanInt():
+		formatted = strconv.FormatInt(value.Int(), 10)
+	case value.CanUint():
+		formatted = strconv.FormatUint(value.Uint(), 10)
+	case value.CanFloat():
+		formatted = strconv.FormatFloat(value.Float(), 'f', -1, 64)
+	case value.Kind() == reflect.Bool:
+		formatted = strconv.FormatBool(value.Bool())
+	default:
+		encoded, err := json.Marshal(value.Interface())
+		if err != nil {
+			return nil, err
+		}
+		formatted = string(encoded)
+	}
+	return &formatted, nil
+}
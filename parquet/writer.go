@@ -0,0 +1,208 @@
+package parquet
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+	"time"
+
+	"github.com/apache/arrow/go/v10/arrow"
+	"github.com/apache/arrow/go/v10/arrow/array"
+	"github.com/apache/arrow/go/v10/arrow/memory"
+	pq "github.com/apache/arrow/go/v10/parquet"
+	"github.com/apache/arrow/go/v10/parquet/compress"
+	"github.com/apache/arrow/go/v10/parquet/pqarrow"
+	"github.com/pkg/errors"
+)
+
+// DefaultRowGroupSize is the default number of rows buffered before a row group is written.
+const DefaultRowGroupSize = 65536
+
+// Writer writes entities, such as types.Log, to a Snappy-compressed Parquet file with a typed column per
+// field. See ColumnType for the type of each column.
+type Writer struct {
+	writer       *pqarrow.FileWriter
+	builder      *array.RecordBuilder
+	entity       reflect.Type
+	indexes      []int
+	rows         int
+	rowGroupSize int
+}
+
+// NewWriter creates a Writer of the columns of the entity type to w, such as returned by Columns for the same
+// entity. A rowGroupSize of zero or less uses DefaultRowGroupSize.
+//
+// Example:
+//
+//	columns := parquet.Columns(types.Log{}, types.LogSchemaFieldsAsString())
+//	writer, err := parquet.NewWriter(file, types.Log{}, columns, 0)
+//	if err != nil {
+//	    log.Fatalf("Failed to create parquet writer: %v", err)
+//	}
+//	for i := range response.Data.Logs {
+//	    _ = writer.Write(&response.Data.Logs[i])
+//	}
+//	if err := writer.Close(); err != nil {
+//	    log.Fatalf("Failed to close parquet writer: %v", err)
+//	}
+func NewWriter(w io.Writer, entity any, columns []string, rowGroupSize int) (*Writer, error) {
+	if len(columns) == 0 {
+		return nil, errors.New("parquet writer requires at least one column")
+	}
+	if rowGroupSize <= 0 {
+		rowGroupSize = DefaultRowGroupSize
+	}
+
+	entityType := reflect.TypeOf(entity)
+	if entityType != nil && entityType.Kind() == reflect.Pointer {
+		entityType = entityType.Elem()
+	}
+	if entityType == nil || entityType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("unsupported entity type: %T", entity)
+	}
+
+	indexes := fieldIndexes(entityType)
+	fields := make([]arrow.Field, len(columns))
+	fieldIndex := make([]int, len(columns))
+	for i, column := range columns {
+		index, ok := indexes[column]
+		if !ok {
+			return nil, fmt.Errorf("unknown column for %s: %s", entityType.Name(), column)
+		}
+		dataType, _ := ColumnType(entity, column)
+		fields[i] = arrow.Field{Name: column, Type: dataType, Nullable: true}
+		fieldIndex[i] = index
+	}
+	schema := arrow.NewSchema(fields, nil)
+
+	props := pq.NewWriterProperties(pq.WithCompression(compress.Codecs.Snappy))
+	writer, err := pqarrow.NewFileWriter(schema, w, props, pqarrow.DefaultWriterProps())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create parquet file writer")
+	}
+
+	return &Writer{
+		writer:       writer,
+		builder:      array.NewRecordBuilder(memory.NewGoAllocator(), schema),
+		entity:       entityType,
+		indexes:      fieldIndex,
+		rowGroupSize: rowGroupSize,
+	}, nil
+}
+
+// Write buffers the row of the entity, a struct or a pointer to one of the writer entity type, writing a row
+// group once enough rows are buffered. Missing values are written as nulls.
+func (w *Writer) Write(entity any) error {
+	value := reflect.ValueOf(entity)
+	if value.Kind() == reflect.Pointer {
+		value = value.Elem()
+	}
+	if !value.IsValid() || value.Type() != w.entity {
+		return fmt.Errorf("entity of type %T written to a %s parquet writer", entity, w.entity.Name())
+	}
+
+	// Every value is converted before any is appended, so a failing row leaves the columns aligned.
+	values := make([]any, len(w.indexes))
+	for i, index := range w.indexes {
+		converted, err := columnValue(w.builder.Schema().Field(i).Type, value.Field(index))
+		if err != nil {
+			return errors.Wrapf(err, "failed to write column: %s", w.builder.Schema().Field(i).Name)
+		}
+		values[i] = converted
+	}
+	for i, converted := range values {
+		appendValue(w.builder.Field(i), converted)
+	}
+
+	w.rows++
+	if w.rows >= w.rowGroupSize {
+		return w.flush()
+	}
+	return nil
+}
+
+// Close writes the buffered rows and the file footer, and closes the underlying writer when it is an io.Closer.
+func (w *Writer) Close() error {
+	defer w.builder.Release()
+	if err := w.flush(); err != nil {
+		return err
+	}
+	if err := w.writer.Close(); err != nil {
+		return errors.Wrap(err, "failed to close parquet file writer")
+	}
+	return nil
+}
+
+func (w *Writer) flush() error {
+	if w.rows == 0 {
+		return nil
+	}
+
+	record := w.builder.NewRecord()
+	defer record.Release()
+	w.rows = 0
+
+	if err := w.writer.Write(record); err != nil {
+		return errors.Wrap(err, "failed to write parquet row group")
+	}
+	return nil
+}
+
+// columnValue converts a field value to the Go value of its column type: uint64, uint8, int64, bool,
+// time.Time or string, or nil for missing values.
+func columnValue(dataType arrow.DataType, value reflect.Value) (any, error) {
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil, nil
+		}
+		if number, ok := value.Interface().(*big.Int); ok && dataType.ID() == arrow.UINT64 {
+			if !number.IsUint64() {
+				return nil, fmt.Errorf("value %s does not fit a 64-bit unsigned integer", number)
+			}
+			return number.Uint64(), nil
+		}
+	}
+
+	switch dataType.ID() {
+	case arrow.UINT64:
+		return reflect.Indirect(value).Uint(), nil
+	case arrow.UINT8:
+		return uint8(reflect.Indirect(value).Uint()), nil
+	case arrow.INT64:
+		return reflect.Indirect(value).Int(), nil
+	case arrow.BOOL:
+		return reflect.Indirect(value).Bool(), nil
+	case arrow.TIMESTAMP:
+		return reflect.Indirect(value).Interface().(time.Time), nil
+	default:
+		formatted, err := format(value)
+		if err != nil || formatted == nil {
+			return nil, err
+		}
+		return *formatted, nil
+	}
+}
+
+// appendValue appends a value converted by columnValue to the builder of its column.
+func appendValue(builder array.Builder, value any) {
+	if value == nil {
+		builder.AppendNull()
+		return
+	}
+
+	switch b := builder.(type) {
+	case *array.Uint64Builder:
+		b.Append(value.(uint64))
+	case *array.Uint8Builder:
+		b.Append(value.(uint8))
+	case *array.Int64Builder:
+		b.Append(value.(int64))
+	case *array.BooleanBuilder:
+		b.Append(value.(bool))
+	case *array.TimestampBuilder:
+		b.Append(arrow.Timestamp(value.(time.Time).Unix()))
+	case *array.StringBuilder:
+		b.Append(value.(string))
+	}
+}
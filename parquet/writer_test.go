@@ -0,0 +1,170 @@
+package parquet
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v10/arrow"
+	"github.com/apache/arrow/go/v10/arrow/array"
+	"github.com/apache/arrow/go/v10/arrow/memory"
+	"github.com/apache/arrow/go/v10/parquet/file"
+	"github.com/apache/arrow/go/v10/parquet/pqarrow"
+	"github.com/enviodev/hypersync-client-go/types"
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRow(t *testing.T) {
+	index := uint64(3)
+	data := []byte{0x01, 0xff}
+	address := common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+	topic := common.HexToHash("0xaa")
+	log := types.Log{
+		LogIndex:    &index,
+		BlockNumber: big.NewInt(19000000),
+		Address:     &address,
+		Data:        &data,
+		Topic0:      &topic,
+	}
+
+	columns := Columns(types.Log{}, []string{"block_number", "log_index", "address", "data", "topic0", "topic1", "unknown"})
+	require.Equal(t, []string{"block_number", "log_index", "address", "data", "topic0", "topic1"}, columns)
+
+	row, err := Row(&log, columns)
+	require.NoError(t, err)
+	require.Equal(t, []string{"19000000", "3", "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2", "0x01ff", topic.Hex()}, values(row[:5]))
+	require.Nil(t, row[5])
+
+	timestamp := time.Unix(1700000000, 0)
+	nonce := gethtypes.EncodeNonce(0x42)
+	withdrawals := []types.Withdrawal{{Index: 1}}
+	row, err = Row(types.Block{Timestamp: &timestamp, Nonce: &nonce, Withdrawals: &withdrawals}, []string{"timestamp", "nonce", "withdrawals"})
+	require.NoError(t, err)
+	require.Equal(t, "1700000000", *row[0])
+	require.Equal(t, "0x0000000000000042", *row[1])
+	require.Contains(t, *row[2], `"index":1`)
+
+	_, err = Row(log, []string{"unknown"})
+	require.EqualError(t, err, "unknown column for Log: unknown")
+}
+
+func TestColumnType(t *testing.T) {
+	tests := []struct {
+		entity   any
+		column   string
+		expected arrow.DataType
+	}{
+		{entity: types.Block{}, column: "number", expected: arrow.PrimitiveTypes.Uint64},
+		{entity: types.Block{}, column: "gas_used", expected: arrow.PrimitiveTypes.Uint64},
+		{entity: types.Block{}, column: "timestamp", expected: arrow.FixedWidthTypes.Timestamp_s},
+		{entity: types.Block{}, column: "difficulty", expected: arrow.BinaryTypes.String},
+		{entity: types.Block{}, column: "nonce", expected: arrow.BinaryTypes.String},
+		{entity: &types.Transaction{}, column: "type", expected: arrow.PrimitiveTypes.Uint8},
+		{entity: &types.Transaction{}, column: "value", expected: arrow.BinaryTypes.String},
+		{entity: types.Log{}, column: "block_number", expected: arrow.PrimitiveTypes.Uint64},
+		{entity: types.Log{}, column: "removed", expected: arrow.FixedWidthTypes.Boolean},
+		{entity: types.Log{}, column: "address", expected: arrow.BinaryTypes.String},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.column, func(t *testing.T) {
+			dataType, ok := ColumnType(tt.entity, tt.column)
+			require.True(t, ok)
+			require.True(t, arrow.TypeEqual(tt.expected, dataType), "expected %s, got %s", tt.expected, dataType)
+		})
+	}
+
+	_, ok := ColumnType(types.Log{}, "unknown")
+	require.False(t, ok)
+}
+
+func TestWriter(t *testing.T) {
+	newLog := func(block int64, index uint64, address *common.Address) types.Log {
+		return types.Log{BlockNumber: big.NewInt(block), LogIndex: &index, Address: address}
+	}
+	address := common.HexToAddress("0x01")
+
+	var buf bytes.Buffer
+	columns := []string{"block_number", "log_index", "address"}
+	writer, err := NewWriter(&buf, types.Log{}, columns, 2)
+	require.NoError(t, err)
+
+	require.NoError(t, writer.Write(newLog(1, 0, &address)))
+	require.NoError(t, writer.Write(newLog(2, 1, nil)))
+	require.NoError(t, writer.Write(ptr(newLog(3, 2, &address))))
+	require.EqualError(t, writer.Write(types.Block{}), "entity of type types.Block written to a Log parquet writer")
+
+	overflow := newLog(0, 3, nil)
+	overflow.BlockNumber = new(big.Int).Lsh(big.NewInt(1), 64)
+	require.EqualError(t, writer.Write(overflow), "failed to write column: block_number: value 18446744073709551616 does not fit a 64-bit unsigned integer")
+	require.NoError(t, writer.Close())
+
+	_, err = NewWriter(&buf, types.Log{}, []string{"unknown"}, 0)
+	require.EqualError(t, err, "unknown column for Log: unknown")
+
+	reader, err := file.NewParquetReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	defer reader.Close()
+	require.Equal(t, 2, reader.NumRowGroups())
+
+	fileReader, err := pqarrow.NewFileReader(reader, pqarrow.ArrowReadProperties{}, memory.NewGoAllocator())
+	require.NoError(t, err)
+	table, err := fileReader.ReadTable(context.Background())
+	require.NoError(t, err)
+	defer table.Release()
+
+	require.Equal(t, int64(3), table.NumRows())
+	require.Equal(t, "block_number", table.Schema().Field(0).Name)
+
+	numbers := table.Column(0).Data().Chunk(0).(*array.Uint64)
+	require.Equal(t, uint64(1), numbers.Value(0))
+	indexes := table.Column(1).Data().Chunk(0).(*array.Uint64)
+	require.Equal(t, uint64(2), indexes.Value(2))
+	addresses := table.Column(2).Data().Chunk(0).(*array.String)
+	require.True(t, addresses.IsNull(1))
+	require.Equal(t, "0x0000000000000000000000000000000000000001", addresses.Value(0))
+}
+
+func TestWriterTimestamps(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := NewWriter(&buf, types.Block{}, []string{"number", "timestamp", "difficulty"}, 0)
+	require.NoError(t, err)
+
+	timestamp := time.Unix(1700000000, 0)
+	difficulty, _ := new(big.Int).SetString("58750003716598352816469", 10)
+	require.NoError(t, writer.Write(types.Block{Number: big.NewInt(19000000), Timestamp: &timestamp, Difficulty: difficulty}))
+	require.NoError(t, writer.Close())
+
+	reader, err := file.NewParquetReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	defer reader.Close()
+
+	fileReader, err := pqarrow.NewFileReader(reader, pqarrow.ArrowReadProperties{}, memory.NewGoAllocator())
+	require.NoError(t, err)
+	table, err := fileReader.ReadTable(context.Background())
+	require.NoError(t, err)
+	defer table.Release()
+
+	timestamps := table.Column(1).Data().Chunk(0).(*array.Timestamp)
+	unit := timestamps.DataType().(*arrow.TimestampType).Unit
+	require.Equal(t, timestamp.Unix(), int64(timestamps.Value(0))*int64(unit.Multiplier())/int64(time.Second))
+	require.Equal(t, "58750003716598352816469", table.Column(2).Data().Chunk(0).(*array.String).Value(0))
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
+
+func values(row []*string) []string {
+	toReturn := make([]string, len(row))
+	for i, value := range row {
+		if value != nil {
+			toReturn[i] = *value
+		}
+	}
+	return toReturn
+}
@@ -0,0 +1,145 @@
+package hypersyncgo
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	errorshs "github.com/enviodev/hypersync-client-go/errors"
+	"github.com/enviodev/hypersync-client-go/logger"
+	"github.com/enviodev/hypersync-client-go/options"
+	"github.com/enviodev/hypersync-client-go/utils"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// probeTimeout bounds the probe run at client creation.
+const probeTimeout = 30 * time.Second
+
+// ArchiveChainID is the response of the chain_id endpoint.
+type ArchiveChainID struct {
+	ChainID uint64 `json:"chain_id"`
+}
+
+// ChainInfo is the chain metadata discovered by probing the endpoints of a node.
+type ChainInfo struct {
+	// ChainID is the chain id served by the HyperSync endpoint.
+	ChainID utils.NetworkID
+
+	// Height is the archive height of the HyperSync endpoint at the time of the probe.
+	Height *big.Int
+
+	// RPCChainID is the chain id served by the RPC endpoint, or nil when the node has no RPC endpoint.
+	RPCChainID *big.Int
+
+	// Network is the registered network of the chain id, or nil when it is not registered.
+	Network *utils.NetworkInfo
+
+	// ProbedAt is the time of the probe.
+	ProbedAt time.Time
+}
+
+// Probe queries the chain id and height endpoints of the node, and eth_chainId of its RPC endpoint when one
+// is configured, and caches the discovered metadata on the client. When the endpoints serve another chain
+// than the NetworkId of the node, or disagree with each other, the metadata is returned along with an error
+// wrapping errorshs.ErrChainMismatch.
+//
+// Probe runs at client creation when the Probe option of the node is set to warn or fail.
+//
+// Example:
+//
+//	info, err := client.Probe(ctx)
+//	if errors.Is(err, errorshs.ErrChainMismatch) {
+//		// The endpoint serves info.ChainID rather than the configured network
+//	}
+func (c *Client) Probe(ctx context.Context) (*ChainInfo, error) {
+	chainID, err := Do[struct{}, ArchiveChainID](ctx, c, c.GeUrlFromNodeAndPath(c.opts, "chain_id"), http.MethodGet, struct{}{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get chain id of endpoint %s", c.opts.Endpoint)
+	}
+
+	height, err := Do[struct{}, ArchiveHeight](ctx, c, c.GeUrlFromNodeAndPath(c.opts, "height"), http.MethodGet, struct{}{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get height of endpoint %s", c.opts.Endpoint)
+	}
+
+	info := &ChainInfo{
+		ChainID:  utils.NetworkID(chainID.ChainID),
+		Height:   height.Height,
+		ProbedAt: time.Now(),
+	}
+	if network, ok := utils.GetNetworkInfo(info.ChainID); ok {
+		info.Network = &network
+	}
+
+	if c.hasRPC() {
+		rpcClient, rErr := c.GetRPC()
+		if rErr != nil {
+			return nil, rErr
+		}
+		rpcChainID, rErr := rpcClient.ChainID(ctx)
+		if rErr != nil {
+			return nil, errors.Wrapf(rErr, "failed to get chain id of rpc endpoint %s", c.opts.RpcEndpoint)
+		}
+		info.RPCChainID = rpcChainID
+	}
+
+	c.chainMu.Lock()
+	c.chainInfo = info
+	c.chainMu.Unlock()
+
+	var mismatches []string
+	if c.opts.NetworkId.IsValid() && info.ChainID != c.opts.NetworkId {
+		mismatches = append(mismatches, fmt.Sprintf("endpoint %s serves chain %s, but the node is configured for network %s", c.opts.Endpoint, info.ChainID, c.opts.NetworkId))
+	}
+	if info.RPCChainID != nil && info.RPCChainID.Cmp(info.ChainID.ToBig()) != 0 {
+		mismatches = append(mismatches, fmt.Sprintf("rpc endpoint %s serves chain %s, but endpoint %s serves chain %s", c.opts.RpcEndpoint, info.RPCChainID, c.opts.Endpoint, info.ChainID))
+	}
+	if len(mismatches) > 0 {
+		return info, errors.Wrap(errorshs.ErrChainMismatch, strings.Join(mismatches, "; "))
+	}
+	return info, nil
+}
+
+// ChainInfo returns the chain metadata discovered by the last probe, or nil when the node was never probed.
+func (c *Client) ChainInfo() *ChainInfo {
+	c.chainMu.RLock()
+	defer c.chainMu.RUnlock()
+	return c.chainInfo
+}
+
+// hasRPC reports whether the client has an RPC client or an RPC endpoint to dial.
+func (c *Client) hasRPC() bool {
+	c.rpcMu.Lock()
+	defer c.rpcMu.Unlock()
+	return c.rpcClient != nil || c.opts.RpcEndpoint != ""
+}
+
+// probeOnCreate runs the probe configured by the node, failing or warning as the probe mode selects.
+func (c *Client) probeOnCreate(ctx context.Context) error {
+	if !c.opts.Probe.Enabled() {
+		return nil
+	}
+
+	probeCtx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	_, err := c.Probe(probeCtx)
+	if err == nil {
+		return nil
+	}
+	if c.opts.Probe == options.ProbeFail {
+		return errors.Wrap(err, "node probe failed")
+	}
+
+	if l := logger.L(); l != nil {
+		l.Warn("node probe failed", zap.Error(err), zap.Any("network_id", c.opts.NetworkId))
+	} else {
+		log.Printf("Warning: node probe failed for network %s: %v", c.opts.NetworkId, err)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package hypersyncgo
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	errorshs "github.com/enviodev/hypersync-client-go/errors"
+	"github.com/enviodev/hypersync-client-go/options"
+	"github.com/enviodev/hypersync-client-go/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// chainIDService serves eth_chainId.
+type chainIDService struct {
+	chainID uint64
+}
+
+func (s *chainIDService) ChainId() hexutil.Uint64 {
+	return hexutil.Uint64(s.chainID)
+}
+
+func newProbeServer(t *testing.T, chainID uint64) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/chain_id":
+			_ = json.NewEncoder(w).Encode(map[string]any{"chain_id": chainID})
+		case "/height":
+			_ = json.NewEncoder(w).Encode(map[string]any{"height": 20000000})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newChainIDRPC(t *testing.T, chainID uint64) *rpc.Client {
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", &chainIDService{chainID: chainID}))
+	client := rpc.DialInProc(server)
+	t.Cleanup(func() {
+		client.Close()
+		server.Stop()
+	})
+	return client
+}
+
+func TestNewClientProbe(t *testing.T) {
+	ctx := context.Background()
+	arbitrum := newProbeServer(t, uint64(utils.ArbitrumOneNetworkID))
+
+	node := options.Node{
+		NetworkId: utils.EthereumNetworkID,
+		Endpoint:  arbitrum.URL,
+		ApiToken:  "token",
+	}
+
+	t.Run("Probe off", func(t *testing.T) {
+		client, err := NewClient(ctx, node)
+		require.NoError(t, err)
+		require.Nil(t, client.ChainInfo())
+	})
+
+	t.Run("Probe fails on mismatch", func(t *testing.T) {
+		node := node
+		node.Probe = options.ProbeFail
+		_, err := NewClient(ctx, node)
+		require.ErrorIs(t, err, errorshs.ErrChainMismatch)
+		require.ErrorContains(t, err, "serves chain 42161, but the node is configured for network 1")
+	})
+
+	t.Run("Probe warns on mismatch", func(t *testing.T) {
+		node := node
+		node.Probe = options.ProbeWarn
+		client, err := NewClient(ctx, node)
+		require.NoError(t, err)
+		info := client.ChainInfo()
+		require.NotNil(t, info)
+		require.Equal(t, utils.ArbitrumOneNetworkID, info.ChainID)
+		require.Equal(t, big.NewInt(20000000), info.Height)
+		require.Equal(t, utils.ArbitrumOneNetwork, info.Network.Name)
+		require.Nil(t, info.RPCChainID)
+	})
+
+	t.Run("Probe fails when the endpoint is unreachable", func(t *testing.T) {
+		node := node
+		node.Endpoint = arbitrum.URL + "/missing"
+		node.Probe = options.ProbeFail
+		_, err := NewClient(ctx, node)
+		require.ErrorContains(t, err, "failed to get chain id of endpoint")
+	})
+
+	t.Run("RPC chain id", func(t *testing.T) {
+		node := node
+		node.NetworkId = utils.ArbitrumOneNetworkID
+		node.Probe = options.ProbeFail
+
+		client, err := NewClientWithRPC(ctx, node, newChainIDRPC(t, uint64(utils.ArbitrumOneNetworkID)))
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(42161), client.ChainInfo().RPCChainID)
+
+		_, err = NewClientWithRPC(ctx, node, newChainIDRPC(t, uint64(utils.EthereumNetworkID)))
+		require.ErrorIs(t, err, errorshs.ErrChainMismatch)
+		require.ErrorContains(t, err, "serves chain 1, but endpoint")
+	})
+}
+
+func TestProbeModeValidate(t *testing.T) {
+	node := options.Node{Endpoint: "https://eth.hypersync.xyz", ApiToken: "token", Probe: "strict"}
+	_, err := NewClient(context.Background(), node)
+	require.ErrorContains(t, err, `invalid probe mode "strict", use off, warn or fail`)
+}
@@ -0,0 +1,4 @@
+// Package rpcserver serves a subset of the Ethereum JSON-RPC API from HyperSync, so tools hardwired to
+// JSON-RPC can read logs, blocks, transactions and receipts without a node. Requests are translated into
+// HyperSync queries against a Backend, which the HyperSync client implements.
+package rpcserver
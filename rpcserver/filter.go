@@ -0,0 +1,80 @@
+package rpcserver
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// maxTopics is the number of topic positions a log can hold.
+const maxTopics = 4
+
+// FilterCriteria is the filter object of eth_getLogs.
+type FilterCriteria struct {
+	BlockHash *common.Hash
+	FromBlock *rpc.BlockNumber
+	ToBlock   *rpc.BlockNumber
+	Addresses []common.Address
+	Topics    [][]common.Hash
+}
+
+// UnmarshalJSON decodes the filter object. The address may be a single address or a list, and every topic
+// position may be null, a single topic or a list of alternatives.
+func (f *FilterCriteria) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		BlockHash *common.Hash      `json:"blockHash"`
+		FromBlock *rpc.BlockNumber  `json:"fromBlock"`
+		ToBlock   *rpc.BlockNumber  `json:"toBlock"`
+		Address   json.RawMessage   `json:"address"`
+		Topics    []json.RawMessage `json:"topics"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	if raw.BlockHash != nil && (raw.FromBlock != nil || raw.ToBlock != nil) {
+		return fmt.Errorf("cannot specify both blockHash and fromBlock/toBlock")
+	}
+	f.BlockHash, f.FromBlock, f.ToBlock = raw.BlockHash, raw.FromBlock, raw.ToBlock
+
+	addresses, err := oneOrMany[common.Address](raw.Address)
+	if err != nil {
+		return fmt.Errorf("invalid address: %w", err)
+	}
+	f.Addresses = addresses
+
+	if len(raw.Topics) > maxTopics {
+		return fmt.Errorf("too many topics: %d", len(raw.Topics))
+	}
+	f.Topics = make([][]common.Hash, len(raw.Topics))
+	for i, topic := range raw.Topics {
+		hashes, tErr := oneOrMany[common.Hash](topic)
+		if tErr != nil {
+			return fmt.Errorf("invalid topic at position %d: %w", i, tErr)
+		}
+		f.Topics[i] = hashes
+	}
+	return nil
+}
+
+// oneOrMany decodes null, a single value or a list of values.
+func oneOrMany[T any](data json.RawMessage) ([]T, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	if data[0] == '[' {
+		var values []T
+		if err := json.Unmarshal(data, &values); err != nil {
+			return nil, err
+		}
+		return values, nil
+	}
+
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return []T{value}, nil
+}
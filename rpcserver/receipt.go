@@ -0,0 +1,79 @@
+package rpcserver
+
+import (
+	"github.com/enviodev/hypersync-client-go/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Receipt is the JSON-RPC representation of a transaction receipt, as returned by eth_getTransactionReceipt.
+type Receipt struct {
+	BlockHash         *common.Hash    `json:"blockHash"`
+	BlockNumber       *hexutil.Big    `json:"blockNumber"`
+	TransactionHash   *common.Hash    `json:"transactionHash"`
+	TransactionIndex  *hexutil.Uint64 `json:"transactionIndex"`
+	From              *common.Address `json:"from"`
+	To                *common.Address `json:"to"`
+	Type              *hexutil.Uint64 `json:"type,omitempty"`
+	CumulativeGasUsed *hexutil.Uint64 `json:"cumulativeGasUsed"`
+	GasUsed           *hexutil.Uint64 `json:"gasUsed"`
+	EffectiveGasPrice *hexutil.Big    `json:"effectiveGasPrice,omitempty"`
+	ContractAddress   *common.Address `json:"contractAddress"`
+	Logs              []*types.RPCLog `json:"logs"`
+	LogsBloom         *hexutil.Bytes  `json:"logsBloom"`
+	Root              *hexutil.Bytes  `json:"root,omitempty"`
+	Status            *hexutil.Uint64 `json:"status,omitempty"`
+	BlobGasUsed       *hexutil.Uint64 `json:"blobGasUsed,omitempty"`
+	BlobGasPrice      *hexutil.Big    `json:"blobGasPrice,omitempty"`
+	// Rollup fields, set on OP-stack and Arbitrum chains.
+	DepositNonce          *hexutil.Uint64 `json:"depositNonce,omitempty"`
+	DepositReceiptVersion *hexutil.Uint64 `json:"depositReceiptVersion,omitempty"`
+	L1Fee                 *hexutil.Big    `json:"l1Fee,omitempty"`
+	L1GasPrice            *hexutil.Big    `json:"l1GasPrice,omitempty"`
+	L1GasUsed             *hexutil.Uint64 `json:"l1GasUsed,omitempty"`
+	L1FeeScalar           *float64        `json:"l1FeeScalar,omitempty,string"`
+	L1BaseFeeScalar       *hexutil.Uint64 `json:"l1BaseFeeScalar,omitempty"`
+	L1BlobBaseFee         *hexutil.Big    `json:"l1BlobBaseFee,omitempty"`
+	L1BlobBaseFeeScalar   *hexutil.Uint64 `json:"l1BlobBaseFeeScalar,omitempty"`
+	GasUsedForL1          *hexutil.Uint64 `json:"gasUsedForL1,omitempty"`
+	L1BlockNumber         *hexutil.Big    `json:"l1BlockNumber,omitempty"`
+}
+
+// NewReceipt builds the receipt of a transaction selected with its receipt fields, and its logs.
+func NewReceipt(tx *types.Transaction, logs []types.Log) *Receipt {
+	rpcTx := tx.ToRPC()
+	toReturn := &Receipt{
+		BlockHash:             rpcTx.BlockHash,
+		BlockNumber:           rpcTx.BlockNumber,
+		TransactionHash:       rpcTx.Hash,
+		TransactionIndex:      rpcTx.TransactionIndex,
+		From:                  rpcTx.From,
+		To:                    rpcTx.To,
+		Type:                  rpcTx.Type,
+		CumulativeGasUsed:     rpcTx.CumulativeGasUsed,
+		GasUsed:               rpcTx.GasUsed,
+		EffectiveGasPrice:     rpcTx.EffectiveGasPrice,
+		ContractAddress:       rpcTx.ContractAddress,
+		Logs:                  make([]*types.RPCLog, 0, len(logs)),
+		LogsBloom:             rpcTx.LogsBloom,
+		Root:                  rpcTx.Root,
+		Status:                rpcTx.Status,
+		BlobGasUsed:           rpcTx.BlobGasUsed,
+		BlobGasPrice:          rpcTx.BlobGasPrice,
+		DepositNonce:          rpcTx.DepositNonce,
+		DepositReceiptVersion: rpcTx.DepositReceiptVersion,
+		L1Fee:                 rpcTx.L1Fee,
+		L1GasPrice:            rpcTx.L1GasPrice,
+		L1GasUsed:             rpcTx.L1GasUsed,
+		L1FeeScalar:           rpcTx.L1FeeScalar,
+		L1BaseFeeScalar:       rpcTx.L1BaseFeeScalar,
+		L1BlobBaseFee:         rpcTx.L1BlobBaseFee,
+		L1BlobBaseFeeScalar:   rpcTx.L1BlobBaseFeeScalar,
+		GasUsedForL1:          rpcTx.GasUsedForL1,
+		L1BlockNumber:         rpcTx.L1BlockNumber,
+	}
+	for i := range logs {
+		toReturn.Logs = append(toReturn.Logs, logs[i].ToRPC())
+	}
+	return toReturn
+}
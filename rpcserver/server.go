@@ -0,0 +1,324 @@
+package rpcserver
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/enviodev/hypersync-client-go/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+)
+
+// DefaultMaxLogs is the default maximum number of logs returned by eth_getLogs.
+const DefaultMaxLogs = 10000
+
+// limitExceededCode is the JSON-RPC error code of requests exceeding a result limit.
+const limitExceededCode = -32005
+
+// Backend executes HyperSync queries. It is implemented by the HyperSync client.
+type Backend interface {
+	GetHeight(ctx context.Context) (*big.Int, error)
+	Get(ctx context.Context, query *types.Query) (*types.QueryResponse, error)
+}
+
+// Options configures the served API.
+type Options struct {
+	// MaxLogs is the maximum number of logs eth_getLogs returns before failing. Defaults to DefaultMaxLogs.
+	MaxLogs int
+	// MaxBlockRange is the maximum number of blocks an eth_getLogs range may span. Zero means no limit.
+	MaxBlockRange uint64
+}
+
+// LimitExceededError is returned when a request exceeds a result limit.
+type LimitExceededError struct {
+	Message string
+}
+
+// Error returns the error message.
+func (e *LimitExceededError) Error() string {
+	return e.Message
+}
+
+// ErrorCode returns the JSON-RPC error code, as used by common JSON-RPC providers for exceeded limits.
+func (e *LimitExceededError) ErrorCode() int {
+	return limitExceededCode
+}
+
+// NewServer creates a JSON-RPC server serving the eth namespace from the backend. The server is an
+// http.Handler and can be served over HTTP, or over websockets with WebsocketHandler.
+//
+// Example:
+//
+//	server, err := rpcserver.NewServer(client, nil)
+//	if err != nil {
+//	    log.Fatalf("Failed to create rpc server: %v", err)
+//	}
+//	defer server.Stop()
+//	log.Fatal(http.ListenAndServe(":8545", server))
+func NewServer(backend Backend, opts *Options) (*rpc.Server, error) {
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", NewEthAPI(backend, opts)); err != nil {
+		server.Stop()
+		return nil, errors.Wrap(err, "failed to register eth api")
+	}
+	return server, nil
+}
+
+// EthAPI serves eth_blockNumber, eth_getLogs, eth_getBlockByNumber, eth_getTransactionByHash and
+// eth_getTransactionReceipt from HyperSync.
+//
+// The latest, pending, safe and finalized block tags resolve to the archive height, and earliest resolves
+// to the genesis block. Lookups by block hash are not supported.
+type EthAPI struct {
+	backend Backend
+	opts    Options
+}
+
+// NewEthAPI creates the eth namespace API over the backend, to be registered on a rpc.Server.
+func NewEthAPI(backend Backend, opts *Options) *EthAPI {
+	api := &EthAPI{backend: backend, opts: Options{MaxLogs: DefaultMaxLogs}}
+	if opts != nil {
+		if opts.MaxLogs > 0 {
+			api.opts.MaxLogs = opts.MaxLogs
+		}
+		api.opts.MaxBlockRange = opts.MaxBlockRange
+	}
+	return api
+}
+
+// BlockNumber returns the archive height.
+func (api *EthAPI) BlockNumber(ctx context.Context) (hexutil.Uint64, error) {
+	height, err := api.height(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(height), nil
+}
+
+// GetLogs returns the logs matching the filter criteria.
+func (api *EthAPI) GetLogs(ctx context.Context, crit FilterCriteria) ([]*types.RPCLog, error) {
+	if crit.BlockHash != nil {
+		return nil, errors.New("filtering logs by block hash is not supported")
+	}
+
+	height, err := api.height(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to := resolve(crit.FromBlock, height), resolve(crit.ToBlock, height)
+	if from > to {
+		return nil, errors.New("invalid block range params")
+	}
+	// No block of the range is indexed yet.
+	if from > height {
+		return []*types.RPCLog{}, nil
+	}
+	if to > height {
+		to = height
+	}
+	if api.opts.MaxBlockRange > 0 && to-from+1 > api.opts.MaxBlockRange {
+		return nil, &LimitExceededError{Message: fmt.Sprintf("block range exceeds limit of %d blocks", api.opts.MaxBlockRange)}
+	}
+
+	query := &types.Query{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to + 1),
+		Logs: []types.LogSelection{
+			{Address: crit.Addresses, Topics: crit.Topics},
+		},
+		FieldSelection: types.FieldSelection{
+			Log: types.LogSchemaFieldsAsString(),
+		},
+		MaxNumLogs: big.NewInt(int64(api.opts.MaxLogs) + 1),
+	}
+
+	logs := make([]*types.RPCLog, 0)
+	_, err = types.Paginate(ctx, query, api.backend.Get, func(response *types.QueryResponse) (bool, error) {
+		for i := range response.Data.Logs {
+			logs = append(logs, response.Data.Logs[i].ToRPC())
+		}
+		return len(logs) > api.opts.MaxLogs, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(logs) > api.opts.MaxLogs {
+		return nil, &LimitExceededError{Message: fmt.Sprintf("query returned more than %d results", api.opts.MaxLogs)}
+	}
+	return logs, nil
+}
+
+// GetBlockByNumber returns the block, with full transactions when fullTx is set and transaction hashes
+// otherwise, or null when the block is not indexed yet.
+func (api *EthAPI) GetBlockByNumber(ctx context.Context, number rpc.BlockNumber, fullTx bool) (*types.RPCBlock, error) {
+	height, err := api.height(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	blockNumber := resolve(&number, height)
+	if blockNumber > height {
+		return nil, nil
+	}
+
+	query := &types.Query{
+		FromBlock:        new(big.Int).SetUint64(blockNumber),
+		ToBlock:          new(big.Int).SetUint64(blockNumber + 1),
+		IncludeAllBlocks: true,
+		Transactions:     []types.TransactionSelection{{}},
+		FieldSelection: types.FieldSelection{
+			Block:       types.BlockSchemaFieldsAsString(),
+			Transaction: []string{"hash", "transaction_index"},
+		},
+	}
+	if fullTx {
+		query.FieldSelection.Transaction = transactionFields()
+	}
+
+	response, err := api.backend.Get(ctx, query)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get block: %d", blockNumber)
+	}
+
+	block := response.GetBlockByNumber(query.FromBlock)
+	if block == nil {
+		return nil, nil
+	}
+
+	transactions := response.Data.Transactions
+	types.SortTransactionsByIndex(transactions)
+	return types.NewRPCBlock(block, transactions, fullTx), nil
+}
+
+// GetTransactionByHash returns the transaction, or null when it is not found.
+func (api *EthAPI) GetTransactionByHash(ctx context.Context, hash common.Hash) (*types.RPCTransaction, error) {
+	tx, _, err := api.transaction(ctx, hash, false)
+	if err != nil || tx == nil {
+		return nil, err
+	}
+	return tx.ToRPC(), nil
+}
+
+// GetTransactionReceipt returns the receipt of the transaction, or null when it is not found.
+func (api *EthAPI) GetTransactionReceipt(ctx context.Context, hash common.Hash) (*Receipt, error) {
+	tx, logs, err := api.transaction(ctx, hash, true)
+	if err != nil || tx == nil {
+		return nil, err
+	}
+	return NewReceipt(tx, logs), nil
+}
+
+// transaction searches the chain for the transaction and, when withReceipt is set, returns its receipt
+// fields and logs too. A nil transaction is returned when it is not found.
+func (api *EthAPI) transaction(ctx context.Context, hash common.Hash, withReceipt bool) (*types.Transaction, []types.Log, error) {
+	height, err := api.height(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	query := &types.Query{
+		FromBlock:    big.NewInt(0),
+		ToBlock:      new(big.Int).SetUint64(height + 1),
+		Transactions: []types.TransactionSelection{{Hash: []common.Hash{hash}}},
+		FieldSelection: types.FieldSelection{
+			Transaction: transactionFields(),
+		},
+	}
+	if withReceipt {
+		query.JoinMode = types.JoinAll
+		query.FieldSelection.Transaction = types.TransactionSchemaFieldsAsString()
+		query.FieldSelection.Log = types.LogSchemaFieldsAsString()
+	}
+
+	var tx *types.Transaction
+	var logs []types.Log
+	_, err = types.Paginate(ctx, query, api.backend.Get, func(response *types.QueryResponse) (bool, error) {
+		for i := range response.Data.Transactions {
+			if found := response.Data.Transactions[i]; found.Hash != nil && *found.Hash == hash {
+				tx = &found
+				break
+			}
+		}
+		if tx == nil {
+			return false, nil
+		}
+
+		for _, log := range response.Data.Logs {
+			if log.TransactionHash != nil && *log.TransactionHash == hash {
+				logs = append(logs, log)
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to get transaction: %s", hash.Hex())
+	}
+
+	types.SortLogsByIndex(logs)
+	return tx, logs, nil
+}
+
+// height returns the archive height.
+func (api *EthAPI) height(ctx context.Context) (uint64, error) {
+	height, err := api.backend.GetHeight(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get archive height")
+	}
+	if height == nil || !height.IsUint64() {
+		return 0, fmt.Errorf("invalid archive height: %v", height)
+	}
+	return height.Uint64(), nil
+}
+
+// resolve returns the block number of a block number or tag. A missing number resolves to the archive height.
+func resolve(number *rpc.BlockNumber, height uint64) uint64 {
+	switch {
+	case number == nil:
+		return height
+	case *number == rpc.EarliestBlockNumber:
+		return 0
+	case *number < 0:
+		return height
+	default:
+		return uint64(*number)
+	}
+}
+
+// receiptFields are the transaction fields that belong to its receipt.
+var receiptFields = map[string]bool{
+	"cumulative_gas_used":     true,
+	"effective_gas_price":     true,
+	"gas_used":                true,
+	"contract_address":        true,
+	"logs_bloom":              true,
+	"root":                    true,
+	"status":                  true,
+	"blob_gas_used":           true,
+	"blob_gas_price":          true,
+	"deposit_receipt_version": true,
+	"l1_fee":                  true,
+	"l1_gas_price":            true,
+	"l1_gas_used":             true,
+	"l1_fee_scalar":           true,
+	"l1_base_fee_scalar":      true,
+	"l1_blob_base_fee":        true,
+	"l1_blob_base_fee_scalar": true,
+	"gas_used_for_l1":         true,
+	"l1_block_number":         true,
+}
+
+// transactionFields returns the transaction fields served by eth_getTransactionByHash, leaving out the
+// receipt fields.
+func transactionFields() []string {
+	toReturn := make([]string, 0)
+	for _, field := range types.TransactionSchemaFieldsAsString() {
+		if !receiptFields[field] {
+			toReturn = append(toReturn, field)
+		}
+	}
+	return toReturn
+}
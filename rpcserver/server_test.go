@@ -0,0 +1,299 @@
+package rpcserver
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/enviodev/hypersync-client-go/types"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testToken    = common.HexToAddress("0x01")
+	testOther    = common.HexToAddress("0x02")
+	testTransfer = common.HexToHash("0xaa")
+	testApproval = common.HexToHash("0xbb")
+)
+
+// fakeBackend serves ten blocks holding one transaction each, the transaction of every block emitting one
+// log, with pages of pageSize blocks.
+type fakeBackend struct {
+	height   int64
+	pageSize int64
+	data     types.DataResponse
+	queries  []*types.Query
+}
+
+func newFakeBackend(pageSize int64) *fakeBackend {
+	backend := &fakeBackend{height: 9, pageSize: pageSize}
+	for number := int64(0); number <= backend.height; number++ {
+		blockHash := common.BigToHash(big.NewInt(0x1000 + number))
+		txHash := common.BigToHash(big.NewInt(0x2000 + number))
+		gasUsed, index, logIndex := uint64(21000), uint64(0), uint64(number)
+		status := uint8(1)
+		timestamp := time.Unix(1700000000+number*12, 0)
+		bloom := types.BloomFilter(make([]byte, 256))
+
+		backend.data.Blocks = append(backend.data.Blocks, types.Block{
+			Number:    big.NewInt(number),
+			Hash:      &blockHash,
+			GasUsed:   &gasUsed,
+			Timestamp: &timestamp,
+		})
+		backend.data.Transactions = append(backend.data.Transactions, types.Transaction{
+			BlockHash:         &blockHash,
+			BlockNumber:       big.NewInt(number),
+			Hash:              &txHash,
+			TransactionIndex:  &index,
+			From:              &testOther,
+			To:                &testToken,
+			Value:             big.NewInt(number),
+			GasUsed:           &gasUsed,
+			CumulativeGasUsed: &gasUsed,
+			Status:            &status,
+			LogsBloom:         &bloom,
+		})
+
+		address, topic := testToken, testTransfer
+		if number%3 == 1 {
+			topic = testApproval
+		}
+		if number%3 == 2 {
+			address = testOther
+		}
+		data := []byte{byte(number)}
+		backend.data.Logs = append(backend.data.Logs, types.Log{
+			BlockHash:        &blockHash,
+			BlockNumber:      big.NewInt(number),
+			TransactionHash:  &txHash,
+			TransactionIndex: &index,
+			LogIndex:         &logIndex,
+			Address:          &address,
+			Data:             &data,
+			Topic0:           &topic,
+		})
+	}
+	return backend
+}
+
+func (f *fakeBackend) GetHeight(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(f.height), nil
+}
+
+func (f *fakeBackend) Get(ctx context.Context, query *types.Query) (*types.QueryResponse, error) {
+	captured := *query
+	f.queries = append(f.queries, &captured)
+
+	from := query.FromBlock.Int64()
+	to := query.ToBlock.Int64()
+	if from+f.pageSize < to {
+		to = from + f.pageSize
+	}
+	inRange := func(number *big.Int) bool {
+		return number.Int64() >= from && number.Int64() < to
+	}
+
+	response := &types.QueryResponse{ArchiveHeight: big.NewInt(f.height + 1), NextBlock: big.NewInt(to)}
+	if query.IncludeAllBlocks {
+		for _, block := range f.data.Blocks {
+			if inRange(block.Number) {
+				response.Data.Blocks = append(response.Data.Blocks, block)
+			}
+		}
+	}
+
+	joined := make(map[common.Hash]bool)
+	for _, selection := range query.Transactions {
+		for _, tx := range f.data.Transactions {
+			if inRange(tx.BlockNumber) && (len(selection.Hash) == 0 || selection.Hash[0] == *tx.Hash) {
+				response.Data.Transactions = append(response.Data.Transactions, tx)
+				joined[*tx.Hash] = true
+			}
+		}
+	}
+
+	for _, log := range f.data.Logs {
+		if !inRange(log.BlockNumber) {
+			continue
+		}
+		if query.JoinMode == types.JoinAll && joined[*log.TransactionHash] {
+			response.Data.Logs = append(response.Data.Logs, log)
+			continue
+		}
+		for _, selection := range query.Logs {
+			if matches(log, selection) {
+				response.Data.Logs = append(response.Data.Logs, log)
+			}
+		}
+	}
+	return response, nil
+}
+
+func matches(log types.Log, selection types.LogSelection) bool {
+	if len(selection.Address) > 0 && !contains(selection.Address, *log.Address) {
+		return false
+	}
+	topics := log.Topics()
+	for i, wanted := range selection.Topics {
+		if len(wanted) > 0 && (i >= len(topics) || !contains(wanted, topics[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+func contains[T comparable](values []T, value T) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func newTestClient(t *testing.T, backend Backend, opts *Options) (*rpc.Client, *ethclient.Client) {
+	server, err := NewServer(backend, opts)
+	require.NoError(t, err)
+	t.Cleanup(server.Stop)
+
+	rpcClient := rpc.DialInProc(server)
+	t.Cleanup(rpcClient.Close)
+	return rpcClient, ethclient.NewClient(rpcClient)
+}
+
+func TestBlockNumber(t *testing.T) {
+	_, client := newTestClient(t, newFakeBackend(100), nil)
+
+	number, err := client.BlockNumber(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(9), number)
+}
+
+func TestGetLogs(t *testing.T) {
+	ctx := context.Background()
+	backend := newFakeBackend(2)
+	rpcClient, client := newTestClient(t, backend, &Options{MaxLogs: 5, MaxBlockRange: 8})
+
+	t.Run("Paginated range", func(t *testing.T) {
+		backend.queries = nil
+		logs, err := client.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: big.NewInt(0),
+			ToBlock:   big.NewInt(6),
+			Addresses: []common.Address{testToken},
+			Topics:    [][]common.Hash{{testTransfer}},
+		})
+		require.NoError(t, err)
+		require.Len(t, logs, 3)
+		require.Equal(t, []uint64{0, 3, 6}, []uint64{logs[0].BlockNumber, logs[1].BlockNumber, logs[2].BlockNumber})
+		require.Equal(t, common.BigToHash(big.NewInt(0x2003)), logs[1].TxHash)
+		require.Equal(t, []byte{3}, logs[1].Data)
+		require.Len(t, backend.queries, 4)
+		require.Equal(t, big.NewInt(7), backend.queries[0].ToBlock)
+	})
+
+	t.Run("Block tags", func(t *testing.T) {
+		var logs []*types.RPCLog
+		err := rpcClient.CallContext(ctx, &logs, "eth_getLogs", map[string]any{
+			"fromBlock": "latest",
+			"address":   testToken,
+			"topics":    []any{nil},
+		})
+		require.NoError(t, err)
+		require.Len(t, logs, 1)
+		require.Equal(t, big.NewInt(9), logs[0].ToLog().BlockNumber)
+
+		err = rpcClient.CallContext(ctx, &logs, "eth_getLogs", map[string]any{
+			"fromBlock": "earliest",
+			"toBlock":   "0x1",
+			"topics":    []any{[]common.Hash{testTransfer, testApproval}},
+		})
+		require.NoError(t, err)
+		require.Len(t, logs, 2)
+	})
+
+	t.Run("Result limit", func(t *testing.T) {
+		_, err := client.FilterLogs(ctx, ethereum.FilterQuery{FromBlock: big.NewInt(0), ToBlock: big.NewInt(7)})
+		require.EqualError(t, err, "query returned more than 5 results")
+
+		var rpcErr rpc.Error
+		require.ErrorAs(t, err, &rpcErr)
+		require.Equal(t, limitExceededCode, rpcErr.ErrorCode())
+	})
+
+	t.Run("Block range limit", func(t *testing.T) {
+		_, err := client.FilterLogs(ctx, ethereum.FilterQuery{FromBlock: big.NewInt(0)})
+		require.EqualError(t, err, "block range exceeds limit of 8 blocks")
+	})
+
+	t.Run("Range beyond the archive height", func(t *testing.T) {
+		backend.queries = nil
+		logs, err := client.FilterLogs(ctx, ethereum.FilterQuery{FromBlock: big.NewInt(12), ToBlock: big.NewInt(15)})
+		require.NoError(t, err)
+		require.Empty(t, logs)
+		require.Empty(t, backend.queries)
+
+		logs, err = client.FilterLogs(ctx, ethereum.FilterQuery{FromBlock: big.NewInt(8), ToBlock: big.NewInt(15)})
+		require.NoError(t, err)
+		require.Len(t, logs, 2)
+	})
+
+	t.Run("Invalid filters", func(t *testing.T) {
+		_, err := client.FilterLogs(ctx, ethereum.FilterQuery{FromBlock: big.NewInt(5), ToBlock: big.NewInt(2)})
+		require.EqualError(t, err, "invalid block range params")
+
+		blockHash := common.HexToHash("0x01")
+		_, err = client.FilterLogs(ctx, ethereum.FilterQuery{BlockHash: &blockHash})
+		require.EqualError(t, err, "filtering logs by block hash is not supported")
+	})
+}
+
+func TestGetBlockByNumber(t *testing.T) {
+	ctx := context.Background()
+	rpcClient, _ := newTestClient(t, newFakeBackend(100), nil)
+
+	var block map[string]any
+	require.NoError(t, rpcClient.CallContext(ctx, &block, "eth_getBlockByNumber", "0x3", false))
+	require.Equal(t, "0x3", block["number"])
+	require.Equal(t, []any{common.BigToHash(big.NewInt(0x2003)).Hex()}, block["transactions"])
+
+	require.NoError(t, rpcClient.CallContext(ctx, &block, "eth_getBlockByNumber", "latest", true))
+	require.Equal(t, "0x9", block["number"])
+	transactions := block["transactions"].([]any)
+	require.Len(t, transactions, 1)
+	require.Equal(t, "0x9", transactions[0].(map[string]any)["value"])
+
+	var missing *types.RPCBlock
+	require.NoError(t, rpcClient.CallContext(ctx, &missing, "eth_getBlockByNumber", "0xa", false))
+	require.Nil(t, missing)
+}
+
+func TestGetTransactionAndReceipt(t *testing.T) {
+	ctx := context.Background()
+	backend := newFakeBackend(2)
+	rpcClient, client := newTestClient(t, backend, nil)
+	hash := common.BigToHash(big.NewInt(0x2005))
+
+	var tx map[string]any
+	require.NoError(t, rpcClient.CallContext(ctx, &tx, "eth_getTransactionByHash", hash))
+	require.Equal(t, hash.Hex(), tx["hash"])
+	require.Equal(t, "0x5", tx["blockNumber"])
+	require.NotContains(t, backend.queries[len(backend.queries)-1].FieldSelection.Transaction, "status")
+
+	receipt, err := client.TransactionReceipt(ctx, hash)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), receipt.Status)
+	require.Equal(t, uint64(21000), receipt.GasUsed)
+	require.Equal(t, big.NewInt(5), receipt.BlockNumber)
+	require.Len(t, receipt.Logs, 1)
+	require.Equal(t, testOther, receipt.Logs[0].Address)
+	require.Equal(t, types.JoinAll, backend.queries[len(backend.queries)-1].JoinMode)
+
+	_, err = client.TransactionReceipt(ctx, common.HexToHash("0x01"))
+	require.ErrorIs(t, err, ethereum.NotFound)
+}
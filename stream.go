@@ -2,12 +2,14 @@ package hypersyncgo
 
 import (
 	"context"
+	"fmt"
 	"math/big"
 	"sync"
 
 	"github.com/enviodev/hypersync-client-go/options"
 	"github.com/enviodev/hypersync-client-go/streams"
 	"github.com/enviodev/hypersync-client-go/types"
+	"github.com/enviodev/hypersync-client-go/verify"
 	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
 )
@@ -15,20 +17,26 @@ import (
 // Stream represents a streaming process that handles data queries and responses
 // using a client and worker for concurrent processing.
 type Stream struct {
-	ctx      context.Context
-	cancelFn context.CancelFunc
-	client   *Client
-	queryCh  chan *types.Query
-	ch       chan *types.QueryResponse
-	errCh    chan error
-	opts     *options.StreamOptions
-	query    *types.Query
-	iterator *streams.BlockIterator
-	worker   *streams.Worker[*types.Query, *types.QueryResponse]
-	done     chan struct{}
-	mu       *sync.RWMutex
-	nextIdx  uint64
-	step     uint64
+	ctx        context.Context
+	cancelFn   context.CancelFunc
+	client     *Client
+	queryCh    chan *types.Query
+	ch         chan *types.QueryResponse
+	errCh      chan error
+	opts       *options.StreamOptions
+	query      *types.Query
+	iterator   *streams.BlockIterator
+	worker     *streams.Worker[*types.Query, *types.QueryResponse]
+	done       chan struct{}
+	mu         *sync.RWMutex
+	nextIdx    uint64
+	step       uint64
+	discovery  *discovery
+	continuity *verify.Continuity
+
+	reportedMu     sync.Mutex
+	verifyErrs     []error
+	continuityErrs []error
 }
 
 // NewStream creates a new Stream instance with the provided context, client, query, and options.
@@ -48,7 +56,7 @@ func NewStream(ctx context.Context, client *Client, query *types.Query, opts *op
 		return nil, errors.Wrap(err, "failed to create new stream subscriber worker")
 	}
 
-	return &Stream{
+	stream := &Stream{
 		ctx:      ctx,
 		opts:     opts,
 		client:   client,
@@ -62,25 +70,149 @@ func NewStream(ctx context.Context, client *Client, query *types.Query, opts *op
 		done:     done,
 		mu:       &sync.RWMutex{},
 		step:     step,
-	}, nil
+	}
+
+	if opts.ValidateContinuity {
+		stream.continuity = verify.NewContinuity(query.FromBlock.Uint64(), query.IncludeAllBlocks)
+		worker.OnPublish(stream.validate)
+	}
+
+	return stream, nil
 }
 
 // ProcessNextQuery processes the next query using the client and returns the response or error.
+// The server may answer with only part of the requested block range, in which case the remainder is fetched
+// and merged into the response, so that no block of the range is skipped.
 func (s *Stream) ProcessNextQuery(query *types.Query) (*types.QueryResponse, error) {
 	ctx, cancel := context.WithCancel(s.ctx)
 	defer cancel()
-	return s.client.GetArrow(ctx, query)
+
+	return fetchRange(ctx, s.fetch, query)
+}
+
+// fetchRange fetches the pages of the query until its whole block range is covered and merges them into the
+// first response.
+func fetchRange(ctx context.Context, fetch FetchFn, query *types.Query) (*types.QueryResponse, error) {
+	response, err := fetch(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	for response.NextBlock != nil && query.ToBlock != nil && response.NextBlock.Cmp(query.ToBlock) < 0 {
+		remainder := *query
+		remainder.FromBlock = new(big.Int).Set(response.NextBlock)
+
+		page, pErr := fetch(ctx, &remainder)
+		if pErr != nil {
+			return nil, pErr
+		}
+		if page.NextBlock == nil || page.NextBlock.Cmp(remainder.FromBlock) <= 0 {
+			return nil, fmt.Errorf("stream made no progress at block: %s", remainder.FromBlock)
+		}
+
+		response.Data.Blocks = append(response.Data.Blocks, page.Data.Blocks...)
+		response.Data.Transactions = append(response.Data.Transactions, page.Data.Transactions...)
+		response.Data.Logs = append(response.Data.Logs, page.Data.Logs...)
+		response.Data.Traces = append(response.Data.Traces, page.Data.Traces...)
+		response.NextBlock = page.NextBlock
+		response.ArchiveHeight = page.ArchiveHeight
+		response.RollbackGuard = page.RollbackGuard
+		response.TotalExecutionTime += page.TotalExecutionTime
+		if !page.DecodeReport.Empty() {
+			if response.DecodeReport == nil {
+				response.DecodeReport = &types.DecodeReport{}
+			}
+			response.DecodeReport.Merge(page.DecodeReport)
+		}
+	}
+
+	return response, nil
+}
+
+// fetch fetches a page of the query and verifies its integrity when enabled in the stream options.
+// Verification failures are recorded for VerifyErrors and queued on the error channel only when it has room,
+// while the response is still delivered, as a consumer may only read responses.
+func (s *Stream) fetch(ctx context.Context, query *types.Query) (*types.QueryResponse, error) {
+	response, err := s.client.GetArrow(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	s.verify(response)
+	return response, nil
+}
+
+// verify verifies the integrity of the response when enabled in the stream options.
+func (s *Stream) verify(response *types.QueryResponse) {
+	if !s.opts.Verify.Enabled() {
+		return
+	}
+
+	report, err := verify.Response(response, s.opts.Verify)
+	if err != nil {
+		s.report(&s.verifyErrs, errors.Wrapf(err, "failed to verify stream response ending at block %s", response.NextBlock))
+	} else if rErr := report.Err(); rErr != nil {
+		s.report(&s.verifyErrs, rErr)
+	}
+}
+
+// VerifyErrors returns the verification failures found so far. Failures are also queued on the Err() channel
+// when it has room, so the ones a consumer did not receive there are kept here.
+func (s *Stream) VerifyErrors() []error {
+	s.reportedMu.Lock()
+	defer s.reportedMu.Unlock()
+	return append([]error{}, s.verifyErrs...)
+}
+
+// report records the error in errs and queues it on the error channel without waiting for room.
+func (s *Stream) report(errs *[]error, err error) {
+	s.reportedMu.Lock()
+	*errs = append(*errs, err)
+	s.reportedMu.Unlock()
+
+	select {
+	case s.errCh <- err:
+	default:
+	}
+}
+
+// validate checks the continuity of the response with the previously published ones. Violations are recorded
+// for ContinuityErrors and queued on the error channel only when it has room, as validation runs while
+// publishing and must not wait for a consumer that only reads responses. Responses must be validated in the
+// order they are published.
+func (s *Stream) validate(response *types.QueryResponse) {
+	if s.continuity == nil {
+		return
+	}
+	err := s.continuity.Check(response)
+	if err == nil {
+		return
+	}
+
+	s.report(&s.continuityErrs, errors.Wrapf(err, "stream response ending at block %s", response.NextBlock))
+}
+
+// ContinuityErrors returns the continuity violations found so far, in stream order. Violations are also
+// queued on the Err() channel when it has room, so the ones a consumer did not receive there are kept here.
+func (s *Stream) ContinuityErrors() []error {
+	s.reportedMu.Lock()
+	defer s.reportedMu.Unlock()
+	return append([]error{}, s.continuityErrs...)
 }
 
 // Subscribe starts the streaming process, initializing the first query and handling subsequent ones.
 func (s *Stream) Subscribe() error {
+	if s.discovery != nil {
+		return s.subscribeWithDiscovery(s.fetch)
+	}
+
 	g, ctx := errgroup.WithContext(s.ctx)
 
 	// Initial fetch to get the first block and with it next paginated starting position
-	response, err := s.client.GetArrow(ctx, s.query)
+	response, err := s.fetch(ctx, s.query)
 	if err != nil {
 		return err
 	}
+	s.validate(response)
 	s.ch <- response
 
 	// We've fetched everything that's requested. Considering this stream as completed.
@@ -89,6 +221,9 @@ func (s *Stream) Subscribe() error {
 		return nil
 	}
 
+	// Continue right after the first page, which has already been published.
+	s.iterator.Seek(response.NextBlock.Uint64())
+
 	// Start the worker to fetch remaining pages
 	g.Go(func() error {
 		return s.worker.Start(s.ProcessNextQuery, s.queryCh)
@@ -0,0 +1,57 @@
+package hypersyncgo
+
+import (
+	"github.com/enviodev/hypersync-client-go/decoder"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/pkg/errors"
+)
+
+// DecodeStream consumes the stream responses and yields the logs of the named event decoded into T,
+// one slice per response. Responses are acknowledged once their decoded slice has been delivered and
+// decoding failures are reported on the stream's Err() channel.
+// The returned channel is closed once the stream is done.
+//
+// Example:
+//
+//	transfers := hypersyncgo.DecodeStream[Transfer](stream, contractAbi, "Transfer")
+//	for batch := range transfers {
+//	    fmt.Printf("Decoded %d transfers\n", len(batch))
+//	}
+func DecodeStream[T any](s *Stream, contractAbi *abi.ABI, eventName string) <-chan []T {
+	out := make(chan []T, s.opts.Concurrency.Uint64())
+
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-s.Done():
+				return
+			case response, ok := <-s.Channel():
+				if !ok {
+					return
+				}
+
+				decoded, err := decoder.DecodeResponseInto[T](response, contractAbi, eventName)
+				if err != nil {
+					s.QueueError(errors.Wrapf(err, "failed to decode stream response ending at block %s", response.NextBlock))
+				} else {
+					select {
+					case out <- decoded:
+					case <-s.ctx.Done():
+						return
+					case <-s.Done():
+						return
+					}
+				}
+
+				if !s.opts.DisableAcknowledgements {
+					s.Ack()
+				}
+			}
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,328 @@
+package hypersyncgo
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/enviodev/hypersync-client-go/contracts"
+	"github.com/enviodev/hypersync-client-go/options"
+	"github.com/enviodev/hypersync-client-go/types"
+	"github.com/enviodev/hypersync-client-go/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// DiscoveryOptions configures factory-driven contract discovery on a Stream.
+type DiscoveryOptions struct {
+	// Factories are the factories whose events announce child contracts. The Children selection of each
+	// factory may be pre-populated with already known child addresses.
+	Factories []*contracts.Factory
+	// Manager, when set, registers every discovered child under the client network using the factory Child template.
+	Manager *contracts.Manager
+}
+
+// FetchFn fetches a single page of a query.
+type FetchFn = types.PageFn
+
+// discovery tracks the child contracts discovered by a stream and builds the queries including them.
+type discovery struct {
+	opts       *DiscoveryOptions
+	network    utils.NetworkID
+	mu         sync.RWMutex
+	known      map[common.Address]bool
+	children   [][]common.Address // Child addresses per factory, in discovery order.
+	discovered []common.Address   // Every discovered child, in discovery order.
+}
+
+// newDiscovery creates the discovery state, seeding it with the child addresses already present in the factories.
+func newDiscovery(network utils.NetworkID, opts *DiscoveryOptions) (*discovery, error) {
+	if opts == nil || len(opts.Factories) == 0 {
+		return nil, errors.New("discovery requires at least one factory")
+	}
+
+	d := &discovery{
+		opts:     opts,
+		network:  network,
+		known:    make(map[common.Address]bool),
+		children: make([][]common.Address, len(opts.Factories)),
+	}
+
+	for i, factory := range opts.Factories {
+		if factory == nil || factory.Extract == nil {
+			return nil, fmt.Errorf("factory [%d] has no extract function", i)
+		}
+		for _, addr := range factory.Children.Address {
+			if !d.known[addr] {
+				d.known[addr] = true
+				d.children[i] = append(d.children[i], addr)
+			}
+		}
+	}
+
+	return d, nil
+}
+
+// query returns a copy of the base query starting at fromBlock, with the factory event selections and the
+// child selections extended with every discovered address. Fields needed to detect factory events and to
+// truncate pages are added to the field selection.
+func (d *discovery) query(base *types.Query, fromBlock *big.Int) *types.Query {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	query := *base
+	query.FromBlock = new(big.Int).Set(fromBlock)
+	query.Logs = append([]types.LogSelection{}, base.Logs...)
+
+	for i, factory := range d.opts.Factories {
+		query.Logs = append(query.Logs, factory.LogSelection())
+
+		// A selection without addresses matches every address, so children are only selected once known.
+		if len(d.children[i]) > 0 {
+			children := factory.Children
+			children.Address = append([]common.Address{}, d.children[i]...)
+			query.Logs = append(query.Logs, children)
+		}
+	}
+
+	query.FieldSelection = types.FieldSelection{
+		Block:       withFields(base.FieldSelection.Block, false, "number"),
+		Transaction: withFields(base.FieldSelection.Transaction, false, "block_number"),
+		Log:         withFields(base.FieldSelection.Log, true, "block_number", "address", "data", "topic0", "topic1", "topic2", "topic3"),
+		Trace:       withFields(base.FieldSelection.Trace, false, "block_number"),
+	}
+
+	return &query
+}
+
+// inspect extracts the children announced by factory logs in the response, registers the new ones and returns
+// the lowest block at which a new child was discovered, or nil when nothing new was found.
+func (d *discovery) inspect(response *types.QueryResponse) (*big.Int, error) {
+	var cut *big.Int
+
+	for _, log := range response.Data.Logs {
+		for i, factory := range d.opts.Factories {
+			if !factory.Matches(log) {
+				continue
+			}
+
+			addresses, err := factory.Extract(log)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to extract discovered contract addresses")
+			}
+
+			for _, addr := range addresses {
+				added, aErr := d.add(i, addr)
+				if aErr != nil {
+					return nil, aErr
+				}
+				if added && log.BlockNumber != nil && (cut == nil || log.BlockNumber.Cmp(cut) < 0) {
+					cut = new(big.Int).Set(log.BlockNumber)
+				}
+			}
+		}
+	}
+
+	return cut, nil
+}
+
+// add records a discovered child of the factory at index i and registers it in the manager.
+// It reports whether the address was not known before.
+func (d *discovery) add(i int, addr common.Address) (bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.known[addr] {
+		return false, nil
+	}
+
+	d.known[addr] = true
+	d.children[i] = append(d.children[i], addr)
+	d.discovered = append(d.discovered, addr)
+
+	factory := d.opts.Factories[i]
+	if d.opts.Manager == nil || factory.Child == nil {
+		return true, nil
+	}
+
+	if _, err := d.opts.Manager.GetByAddr(d.network, addr); err == nil {
+		return true, nil
+	}
+
+	if _, err := d.opts.Manager.RegisterNew(d.network, factory.Child, addr); err != nil {
+		return true, errors.Wrapf(err, "failed to register discovered contract: %s", addr.Hex())
+	}
+
+	return true, nil
+}
+
+// addresses returns every discovered child address in discovery order.
+func (d *discovery) addresses() []common.Address {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return append([]common.Address{}, d.discovered...)
+}
+
+// truncateResponse drops the response data at or above the given block and moves its next block there.
+func truncateResponse(response *types.QueryResponse, block *big.Int) {
+	below := func(number *big.Int) bool {
+		return number == nil || number.Cmp(block) < 0
+	}
+
+	blocks := make([]types.Block, 0, len(response.Data.Blocks))
+	for _, b := range response.Data.Blocks {
+		if below(b.Number) {
+			blocks = append(blocks, b)
+		}
+	}
+
+	transactions := make([]types.Transaction, 0, len(response.Data.Transactions))
+	for _, tx := range response.Data.Transactions {
+		if below(tx.BlockNumber) {
+			transactions = append(transactions, tx)
+		}
+	}
+
+	logs := make([]types.Log, 0, len(response.Data.Logs))
+	for _, log := range response.Data.Logs {
+		if below(log.BlockNumber) {
+			logs = append(logs, log)
+		}
+	}
+
+	traces := make([]types.Trace, 0, len(response.Data.Traces))
+	for _, trace := range response.Data.Traces {
+		if below(trace.BlockNumber) {
+			traces = append(traces, trace)
+		}
+	}
+
+	response.Data.Blocks = blocks
+	response.Data.Transactions = transactions
+	response.Data.Logs = logs
+	response.Data.Traces = traces
+	response.NextBlock = new(big.Int).Set(block)
+}
+
+// withFields returns the fields with the required ones appended when missing. Entities without any selected
+// field are not requested at all and are left untouched, unless force is set.
+func withFields(fields []string, force bool, required ...string) []string {
+	if len(fields) == 0 && !force {
+		return fields
+	}
+
+	toReturn := append([]string{}, fields...)
+	for _, field := range required {
+		found := false
+		for _, existing := range fields {
+			if existing == field {
+				found = true
+				break
+			}
+		}
+		if !found {
+			toReturn = append(toReturn, field)
+		}
+	}
+	return toReturn
+}
+
+// StreamWithDiscovery streams the query while discovering child contracts from factory events.
+//
+// Every page also selects the factory events. When a page announces new children, their addresses are added
+// to the factory Children selection for subsequent pages, the page is cut right before the block of the first
+// discovery and the remainder is queried again including the new children, so no child log is missed.
+// Discovered children are registered in the DiscoveryOptions manager. Pages are fetched sequentially, as each
+// page depends on the discoveries of the previous ones, so StreamOptions.Concurrency is not used.
+//
+// Example:
+//
+//	stream, err := client.StreamWithDiscovery(ctx, query, &hypersyncgo.DiscoveryOptions{
+//	    Factories: []*contracts.Factory{pairFactory},
+//	    Manager:   manager,
+//	}, options.DefaultStreamOptions())
+//	if err != nil {
+//	    log.Fatalf("Failed to start discovery stream: %v", err)
+//	}
+func (c *Client) StreamWithDiscovery(ctx context.Context, query *types.Query, discoveryOpts *DiscoveryOptions, opts *options.StreamOptions) (*Stream, error) {
+	if query.FromBlock == nil || query.ToBlock == nil {
+		return nil, errors.New("discovery stream requires both from and to blocks")
+	}
+
+	stream, err := NewStream(ctx, c, query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if stream.discovery, err = newDiscovery(c.opts.NetworkId, discoveryOpts); err != nil {
+		stream.cancelFn()
+		return nil, err
+	}
+
+	go func() {
+		if sErr := stream.Subscribe(); sErr != nil {
+			stream.QueueError(sErr)
+			return
+		}
+	}()
+
+	return stream, nil
+}
+
+// Discovered returns the child contract addresses discovered so far by a discovery stream.
+func (s *Stream) Discovered() []common.Address {
+	if s.discovery == nil {
+		return []common.Address{}
+	}
+	return s.discovery.addresses()
+}
+
+// subscribeWithDiscovery fetches the query page by page, extending the selections with discovered children.
+func (s *Stream) subscribeWithDiscovery(fetch FetchFn) error {
+	defer close(s.done)
+
+	cursor := new(big.Int).Set(s.query.FromBlock)
+	for cursor.Cmp(s.query.ToBlock) < 0 {
+		response, err := fetch(s.ctx, s.discovery.query(s.query, cursor))
+		if err != nil {
+			return err
+		}
+
+		if response.NextBlock == nil || response.NextBlock.Cmp(cursor) <= 0 {
+			return fmt.Errorf("discovery stream made no progress at block: %s", cursor)
+		}
+
+		cut, err := s.discovery.inspect(response)
+		if err != nil {
+			return err
+		}
+
+		if cut != nil {
+			// The children were discovered mid-page: keep the data below the discovery block and query the
+			// remainder again including them. Nothing is published when the discovery happened in the first block.
+			if cut.Cmp(cursor) <= 0 {
+				continue
+			}
+			truncateResponse(response, cut)
+		}
+
+		s.validate(response)
+
+		select {
+		case s.ch <- response:
+		case <-s.ctx.Done():
+			return s.ctx.Err()
+		}
+
+		if !s.opts.DisableAcknowledgements {
+			if aErr := s.worker.AwaitAck(s.ctx); aErr != nil {
+				return aErr
+			}
+		}
+
+		cursor = response.NextBlock
+	}
+
+	return nil
+}
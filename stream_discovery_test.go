@@ -0,0 +1,177 @@
+package hypersyncgo
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/enviodev/hypersync-client-go/contracts"
+	"github.com/enviodev/hypersync-client-go/options"
+	"github.com/enviodev/hypersync-client-go/types"
+	"github.com/enviodev/hypersync-client-go/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// newFakeFetch serves the logs matching the query selections, paginated by pageSize blocks.
+func newFakeFetch(logs []types.Log, pageSize int64, queries *[]*types.Query) FetchFn {
+	matches := func(selection types.LogSelection, log types.Log) bool {
+		if len(selection.Address) > 0 {
+			found := false
+			for _, addr := range selection.Address {
+				found = found || addr == *log.Address
+			}
+			if !found {
+				return false
+			}
+		}
+		topics := log.Topics()
+		for i, options := range selection.Topics {
+			if len(options) == 0 {
+				continue
+			}
+			found := false
+			for _, topic := range options {
+				found = found || (i < len(topics) && topics[i] == topic)
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	}
+
+	return func(ctx context.Context, query *types.Query) (*types.QueryResponse, error) {
+		*queries = append(*queries, query)
+		next := new(big.Int).Add(query.FromBlock, big.NewInt(pageSize))
+		if next.Cmp(query.ToBlock) > 0 {
+			next = new(big.Int).Set(query.ToBlock)
+		}
+
+		response := &types.QueryResponse{NextBlock: next, ArchiveHeight: query.ToBlock}
+		for _, log := range logs {
+			if log.BlockNumber.Cmp(query.FromBlock) < 0 || log.BlockNumber.Cmp(next) >= 0 {
+				continue
+			}
+			for _, selection := range query.Logs {
+				if matches(selection, log) {
+					response.Data.Logs = append(response.Data.Logs, log)
+					break
+				}
+			}
+		}
+		return response, nil
+	}
+}
+
+func TestStreamWithDiscovery(t *testing.T) {
+	manager, err := contracts.NewManagerWithDefaults()
+	require.NoError(t, err)
+
+	network := utils.EthereumNetworkID
+	v2Factory, err := manager.GetByStandard(network, utils.UniswapV2Factory)
+	require.NoError(t, err)
+	v2Pair, err := manager.GetByStandard(network, utils.UniswapV2Pair)
+	require.NoError(t, err)
+
+	factoryAddr := common.HexToAddress("0x5C69bEe701ef814a2B6a3EDD4B1652CB9cc5aA6f")
+	pairA := common.HexToAddress("0xB4e16d0168e52d35CaCD2c6185b44281Ec28C9Dc")
+	pairB := common.HexToAddress("0xA478c2975Ab1Ea89e8196811F51A7B7Ade33eB11")
+	unrelated := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+	factory, err := contracts.NewFactory(v2Factory, "PairCreated", "pair", v2Pair, factoryAddr)
+	require.NoError(t, err)
+
+	pairCreated := v2Factory.ABI().Events["PairCreated"]
+	sync := v2Pair.ABI().Events["Sync"]
+
+	newLog := func(block int64, index uint64, addr common.Address, topic0 common.Hash, data []byte) types.Log {
+		logIndex := index
+		return types.Log{
+			BlockNumber: big.NewInt(block),
+			LogIndex:    &logIndex,
+			Address:     &addr,
+			Topic0:      &topic0,
+			Data:        &data,
+		}
+	}
+	created := func(block int64, index uint64, pair common.Address) types.Log {
+		data, pErr := pairCreated.Inputs.NonIndexed().Pack(pair, big.NewInt(1))
+		require.NoError(t, pErr)
+		log := newLog(block, index, factoryAddr, pairCreated.ID, data)
+		token0, token1 := common.BytesToHash(unrelated.Bytes()), common.BytesToHash(pair.Bytes())
+		log.Topic1, log.Topic2 = &token0, &token1
+		return log
+	}
+	synced := func(block int64, index uint64, pair common.Address) types.Log {
+		data, pErr := sync.Inputs.NonIndexed().Pack(big.NewInt(block), big.NewInt(block))
+		require.NoError(t, pErr)
+		return newLog(block, index, pair, sync.ID, data)
+	}
+
+	chain := []types.Log{
+		synced(101, 0, unrelated),
+		created(105, 1, pairA),
+		synced(105, 2, pairA),
+		synced(110, 0, pairA),
+		created(120, 0, pairB),
+		synced(120, 1, pairB),
+		synced(130, 0, pairA),
+		synced(160, 0, pairB),
+		synced(170, 0, unrelated),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	query := &types.Query{FromBlock: big.NewInt(100), ToBlock: big.NewInt(200)}
+	stream, err := NewStream(ctx, nil, query, options.DefaultStreamOptions())
+	require.NoError(t, err)
+
+	stream.discovery, err = newDiscovery(network, &DiscoveryOptions{
+		Factories: []*contracts.Factory{factory},
+		Manager:   manager,
+	})
+	require.NoError(t, err)
+
+	queries := make([]*types.Query, 0)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- stream.subscribeWithDiscovery(newFakeFetch(chain, 50, &queries))
+	}()
+
+	received := make([]types.Log, 0)
+	nextBlock := big.NewInt(100)
+	for done := false; !done; {
+		select {
+		case response := <-stream.Channel():
+			require.True(t, response.NextBlock.Cmp(nextBlock) > 0, "pages must be contiguous and increasing")
+			for _, log := range response.Data.Logs {
+				require.True(t, log.BlockNumber.Cmp(nextBlock) >= 0 && log.BlockNumber.Cmp(response.NextBlock) < 0)
+			}
+			nextBlock = response.NextBlock
+			received = append(received, response.Data.Logs...)
+			stream.Ack()
+		case <-stream.Done():
+			done = true
+		}
+	}
+	require.NoError(t, <-errCh)
+	require.Equal(t, big.NewInt(200), nextBlock)
+
+	// Every factory and child log is received exactly once, unrelated logs are not selected.
+	require.Len(t, received, 7)
+	for i, log := range received {
+		require.NotEqual(t, unrelated, *log.Address, "log %d", i)
+	}
+	require.Equal(t, []common.Address{pairA, pairB}, stream.Discovered())
+
+	// Discovered children are registered and the last query includes them.
+	for _, pair := range []common.Address{pairA, pairB} {
+		contract, gErr := manager.GetByAddr(network, pair)
+		require.NoError(t, gErr)
+		require.Equal(t, utils.UniswapV2Pair, contract.Standard())
+	}
+	lastQuery := queries[len(queries)-1]
+	require.Equal(t, []common.Address{pairA, pairB}, lastQuery.Logs[len(lastQuery.Logs)-1].Address)
+}
@@ -0,0 +1,137 @@
+package hypersyncgo
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/enviodev/hypersync-client-go/options"
+	"github.com/enviodev/hypersync-client-go/types"
+	"github.com/enviodev/hypersync-client-go/verify"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamContinuityErrorsDoNotBlock(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	opts := options.DefaultStreamOptions()
+	opts.Concurrency = big.NewInt(1)
+	opts.ValidateContinuity = true
+
+	query := &types.Query{FromBlock: big.NewInt(100), ToBlock: big.NewInt(200)}
+	stream, err := NewStream(ctx, nil, query, opts)
+	require.NoError(t, err)
+
+	// Each response repeats the same log, so every one after the first is a violation.
+	logIndex := uint64(0)
+	response := &types.QueryResponse{
+		NextBlock: big.NewInt(101),
+		Data: types.DataResponse{
+			Logs: []types.Log{{BlockNumber: big.NewInt(100), LogIndex: &logIndex}},
+		},
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 4; i++ {
+			stream.validate(response)
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("validation blocked on the error channel")
+	}
+
+	violations := stream.ContinuityErrors()
+	require.Len(t, violations, 3)
+	for _, violation := range violations {
+		var continuityErr *verify.ContinuityError
+		require.ErrorAs(t, violation, &continuityErr)
+		require.Equal(t, verify.DuplicateLog, continuityErr.Violations[0].Kind)
+	}
+
+	// Only as many violations as the error channel holds were queued on it.
+	require.Len(t, stream.Err(), 1)
+}
+
+func TestStreamVerifyErrorsDoNotBlock(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	opts := options.DefaultStreamOptions()
+	opts.Concurrency = big.NewInt(1)
+	opts.Verify = options.DefaultVerifyOptions()
+
+	query := &types.Query{FromBlock: big.NewInt(100), ToBlock: big.NewInt(200)}
+	stream, err := NewStream(ctx, nil, query, opts)
+	require.NoError(t, err)
+
+	// The transaction misses the fields the checks need, so every response fails verification.
+	response := &types.QueryResponse{
+		NextBlock: big.NewInt(101),
+		Data:      types.DataResponse{Transactions: []types.Transaction{{}}},
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 4; i++ {
+			stream.verify(response)
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("verification blocked on the error channel")
+	}
+
+	failures := stream.VerifyErrors()
+	require.Len(t, failures, 4)
+	for _, failure := range failures {
+		var missing *types.MissingFieldsError
+		require.ErrorAs(t, failure, &missing)
+	}
+
+	// Only as many failures as the error channel holds were queued on it.
+	require.Len(t, stream.Err(), 1)
+}
+
+func TestFetchRangeMergesPages(t *testing.T) {
+	logs := make([]types.Log, 0)
+	for block := int64(100); block < 130; block += 5 {
+		logIndex := uint64(0)
+		logs = append(logs, types.Log{BlockNumber: big.NewInt(block), LogIndex: &logIndex})
+	}
+
+	queries := make([]*types.Query, 0)
+	query := &types.Query{
+		FromBlock: big.NewInt(100),
+		ToBlock:   big.NewInt(130),
+		Logs:      []types.LogSelection{{}},
+	}
+	response, err := fetchRange(context.Background(), newFakeFetch(logs, 10, &queries), query)
+	require.NoError(t, err)
+
+	require.Len(t, queries, 3)
+	require.Equal(t, big.NewInt(100), queries[0].FromBlock)
+	require.Equal(t, big.NewInt(110), queries[1].FromBlock)
+	require.Equal(t, big.NewInt(120), queries[2].FromBlock)
+	require.Equal(t, big.NewInt(130), response.NextBlock)
+	require.Equal(t, logs, response.Data.Logs)
+}
+
+func TestFetchRangeWithoutProgress(t *testing.T) {
+	stalled := func(ctx context.Context, query *types.Query) (*types.QueryResponse, error) {
+		return &types.QueryResponse{NextBlock: big.NewInt(110)}, nil
+	}
+
+	query := &types.Query{FromBlock: big.NewInt(100), ToBlock: big.NewInt(130)}
+	_, err := fetchRange(context.Background(), stalled, query)
+	require.EqualError(t, err, "stream made no progress at block: 110")
+}
@@ -43,6 +43,11 @@ func (b *BlockIterator) Completed() bool {
 	return b.offset >= b.end
 }
 
+// Seek moves the current offset to the given block, skipping the blocks already fetched.
+func (b *BlockIterator) Seek(offset uint64) {
+	b.offset = min(offset, b.end)
+}
+
 // Next returns the next batch of blocks to be processed. It updates the current offset and
 // returns the start and end of the batch, as well as a boolean indicating if the operation
 // was successful.
@@ -0,0 +1,30 @@
+package streams
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockIteratorSeek(t *testing.T) {
+	step := uint64(10)
+	iterator := NewBlockIterator(100, 135, &step)
+
+	// The first page was answered up to block 112, so the iterator continues from there.
+	iterator.Seek(112)
+
+	ranges := make([][2]uint64, 0)
+	for {
+		start, end, ok := iterator.Next()
+		if !ok {
+			break
+		}
+		ranges = append(ranges, [2]uint64{start, end})
+	}
+
+	require.Equal(t, [][2]uint64{{112, 122}, {122, 132}, {132, 135}}, ranges)
+	require.True(t, iterator.Completed())
+
+	iterator.Seek(200)
+	require.Equal(t, uint64(135), iterator.GetCurrentOffset())
+}
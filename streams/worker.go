@@ -28,6 +28,7 @@ type Worker[T any, R *types.QueryResponse] struct {
 	channel  chan R
 	ackCh    chan struct{} // Acknowledgment channel
 	wg       sync.WaitGroup
+	inspect  func(record R)
 }
 
 // OrderedResult holds the result of processing a descriptor, including its index, the
@@ -117,6 +118,9 @@ func (w *Worker[T, R]) Start(workerFn WorkerFn[T, R], descriptor <-chan T) error
 			// Push results to the output channel in order
 			for {
 				if record, ok := results[nextIndex]; ok {
+					if w.inspect != nil {
+						w.inspect(record)
+					}
 					w.channel <- record
 					delete(results, nextIndex)
 
@@ -157,11 +161,28 @@ func (w *Worker[T, R]) Start(workerFn WorkerFn[T, R], descriptor <-chan T) error
 	return w.Stop()
 }
 
+// OnPublish registers a function called with every record, in order, right before it is published.
+// It must be registered before Start.
+func (w *Worker[T, R]) OnPublish(fn func(record R)) {
+	w.inspect = fn
+}
+
 // Ack acknowledges that a response has been processed.
 func (w *Worker[T, R]) Ack() {
 	w.ackCh <- struct{}{}
 }
 
+// AwaitAck blocks until a response is acknowledged or the context is cancelled.
+// It is used by sequential producers that publish one response at a time.
+func (w *Worker[T, R]) AwaitAck(ctx context.Context) error {
+	select {
+	case <-w.ackCh:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // Done returns a channel that can be used to signal when the worker's operations are done.
 func (w *Worker[T, R]) Done() <-chan struct{} {
 	return w.done
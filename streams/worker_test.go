@@ -0,0 +1,50 @@
+package streams
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/enviodev/hypersync-client-go/options"
+	"github.com/enviodev/hypersync-client-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkerOnPublish(t *testing.T) {
+	step := uint64(10)
+	iterator := NewBlockIterator(0, 40, &step)
+	opts := &options.StreamOptions{
+		Concurrency:             big.NewInt(2),
+		BatchSize:               big.NewInt(10),
+		DisableAcknowledgements: true,
+	}
+
+	channel := make(chan *types.QueryResponse, 4)
+	worker, err := NewWorker[*types.Query, *types.QueryResponse](context.Background(), iterator, channel, make(chan struct{}), opts)
+	require.NoError(t, err)
+
+	published := make([]uint64, 0)
+	worker.OnPublish(func(record *types.QueryResponse) {
+		// Records are inspected before they reach the channel.
+		require.Len(t, channel, len(published))
+		published = append(published, record.NextBlock.Uint64())
+	})
+
+	descriptors := make(chan *types.Query, 4)
+	for {
+		start, end, ok := iterator.Next()
+		if !ok {
+			break
+		}
+		descriptors <- &types.Query{FromBlock: new(big.Int).SetUint64(start), ToBlock: new(big.Int).SetUint64(end)}
+	}
+	close(descriptors)
+
+	err = worker.Start(func(query *types.Query) (*types.QueryResponse, error) {
+		return &types.QueryResponse{NextBlock: query.ToBlock}, nil
+	}, descriptors)
+	require.NoError(t, err)
+
+	require.Equal(t, []uint64{10, 20, 30, 40}, published)
+	require.Len(t, channel, 4)
+}
@@ -2,14 +2,12 @@ package types
 
 import (
 	"github.com/apache/arrow/go/v10/arrow"
-	"github.com/apache/arrow/go/v10/arrow/array"
+	errorshs "github.com/enviodev/hypersync-client-go/errors"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/pkg/errors"
 	"math/big"
 	"time"
-	"encoding/binary"
-	"fmt"
 )
 
 // Block represents an Ethereum block object.
@@ -38,8 +36,8 @@ type Block struct {
 	Difficulty *big.Int `json:"difficulty,omitempty"`
 	// The cumulative sum of the difficulty of all blocks that have been mined in the Ethereum network since the inception of the network. It measures the overall security and integrity of the Ethereum network.
 	TotalDifficulty *big.Int `json:"total_difficulty,omitempty"`
-	// An arbitrary byte array containing data relevant to this block. This must be 32 bytes or fewer; formally Hx.
-	ExtraData *common.Hash `json:"extra_data,omitempty"`
+	// An arbitrary byte array containing data relevant to this block. This must be 32 bytes or fewer on Ethereum, other networks may store more; formally Hx.
+	ExtraData *[]byte `json:"extra_data,omitempty"`
 	// The size of this block in bytes as an integer value, encoded as hexadecimal.
 	Size *uint64 `json:"size,omitempty"`
 	// A scalar value equal to the current limit of gas expenditure per block; formally Hl.
@@ -62,6 +60,8 @@ type Block struct {
 	WithdrawalsRoot *common.Hash `json:"withdrawals_root,omitempty"`
 	// Withdrawal represents a validator withdrawal from the consensus layer.
 	Withdrawals *[]Withdrawal `json:"withdrawals,omitempty"`
+	// The SHA-256 commitment to the execution layer requests of the block, added in EIP-7685.
+	RequestsHash *common.Hash `json:"requests_hash,omitempty"`
 	// The L1 block number that would be used for block.number calls.
 	L1BlockNumber *big.Int `json:"l1_block_number,omitempty"`
 	// The number of L2 to L1 messages since Nitro genesis.
@@ -72,18 +72,220 @@ type Block struct {
 	MixHash *common.Hash `json:"mix_hash,omitempty"`
 }
 
+// ToHeader converts the block into a go-ethereum header. Every consensus field of the header must have been
+// selected, otherwise a *MissingFieldsError listing the missing ones is returned. The fields added by later
+// forks (base fee, withdrawals root, blob gas, parent beacon root and requests hash) are optional and only set
+// when present, so the header hash matches the block hash for blocks up to Prague as long as every field the
+// block carries was selected.
+func (b *Block) ToHeader() (*types.Header, error) {
+	missing := &MissingFieldsError{Entity: "block"}
+	missing.require("number", b.Number != nil)
+	missing.require("parent_hash", b.ParentHash != nil)
+	missing.require("sha3_uncles", b.Sha3Uncles != nil)
+	missing.require("miner", b.Miner != nil)
+	missing.require("state_root", b.StateRoot != nil)
+	missing.require("transactions_root", b.TransactionsRoot != nil)
+	missing.require("receipts_root", b.ReceiptsRoot != nil)
+	missing.require("logs_bloom", b.LogsBloom != nil)
+	missing.require("difficulty", b.Difficulty != nil)
+	missing.require("gas_limit", b.GasLimit != nil)
+	missing.require("gas_used", b.GasUsed != nil)
+	missing.require("timestamp", b.Timestamp != nil)
+	missing.require("extra_data", b.ExtraData != nil)
+	missing.require("mix_hash", b.MixHash != nil)
+	missing.require("nonce", b.Nonce != nil)
+	if err := missing.orNil(); err != nil {
+		return nil, err
+	}
+
+	header := &types.Header{
+		ParentHash:  *b.ParentHash,
+		UncleHash:   *b.Sha3Uncles,
+		Coinbase:    *b.Miner,
+		Root:        *b.StateRoot,
+		TxHash:      *b.TransactionsRoot,
+		ReceiptHash: *b.ReceiptsRoot,
+		Bloom:       *b.LogsBloom,
+		Difficulty:  new(big.Int).Set(b.Difficulty),
+		Number:      new(big.Int).Set(b.Number),
+		GasLimit:    *b.GasLimit,
+		GasUsed:     *b.GasUsed,
+		Time:        uint64(b.Timestamp.Unix()),
+		Extra:       append([]byte{}, *b.ExtraData...),
+		MixDigest:   *b.MixHash,
+		Nonce:       *b.Nonce,
+	}
+
+	if b.BaseFeePerGas != nil {
+		header.BaseFee = new(big.Int).Set(b.BaseFeePerGas)
+	}
+	if b.WithdrawalsRoot != nil {
+		root := *b.WithdrawalsRoot
+		header.WithdrawalsHash = &root
+	}
+	if b.BlobGasUsed != nil {
+		blobGasUsed := *b.BlobGasUsed
+		header.BlobGasUsed = &blobGasUsed
+	}
+	if b.ExcessBlobGas != nil {
+		excessBlobGas := *b.ExcessBlobGas
+		header.ExcessBlobGas = &excessBlobGas
+	}
+	if b.ParentBeaconBlockRoot != nil {
+		root := *b.ParentBeaconBlockRoot
+		header.ParentBeaconRoot = &root
+	}
+	if b.RequestsHash != nil {
+		requestsHash := *b.RequestsHash
+		header.RequestsHash = &requestsHash
+	}
+
+	return header, nil
+}
+
+// NewBlockFromHeader converts a go-ethereum header into a block holding the header fields, the inverse of
+// ToHeader. The values are copied, so the block does not share memory with the header.
+func NewBlockFromHeader(header *types.Header) *Block {
+	hash := header.Hash()
+	parentHash, uncleHash, coinbase := header.ParentHash, header.UncleHash, header.Coinbase
+	root, txHash, receiptHash := header.Root, header.TxHash, header.ReceiptHash
+	bloom, mixDigest, nonce := header.Bloom, header.MixDigest, header.Nonce
+	gasLimit, gasUsed := header.GasLimit, header.GasUsed
+	timestamp := time.Unix(int64(header.Time), 0)
+	extra := append([]byte{}, header.Extra...)
+
+	block := &Block{
+		Number:           new(big.Int).Set(header.Number),
+		Hash:             &hash,
+		ParentHash:       &parentHash,
+		Nonce:            &nonce,
+		Sha3Uncles:       &uncleHash,
+		LogsBloom:        &bloom,
+		TransactionsRoot: &txHash,
+		StateRoot:        &root,
+		ReceiptsRoot:     &receiptHash,
+		Miner:            &coinbase,
+		ExtraData:        &extra,
+		GasLimit:         &gasLimit,
+		GasUsed:          &gasUsed,
+		Timestamp:        &timestamp,
+		MixHash:          &mixDigest,
+	}
+
+	if header.Difficulty != nil {
+		block.Difficulty = new(big.Int).Set(header.Difficulty)
+	}
+	if header.BaseFee != nil {
+		block.BaseFeePerGas = new(big.Int).Set(header.BaseFee)
+	}
+	if header.WithdrawalsHash != nil {
+		withdrawalsRoot := *header.WithdrawalsHash
+		block.WithdrawalsRoot = &withdrawalsRoot
+	}
+	if header.BlobGasUsed != nil {
+		blobGasUsed := *header.BlobGasUsed
+		block.BlobGasUsed = &blobGasUsed
+	}
+	if header.ExcessBlobGas != nil {
+		excessBlobGas := *header.ExcessBlobGas
+		block.ExcessBlobGas = &excessBlobGas
+	}
+	if header.ParentBeaconRoot != nil {
+		beaconRoot := *header.ParentBeaconRoot
+		block.ParentBeaconBlockRoot = &beaconRoot
+	}
+	if header.RequestsHash != nil {
+		requestsHash := *header.RequestsHash
+		block.RequestsHash = &requestsHash
+	}
+
+	return block
+}
+
+// ToGeth converts the block, its withdrawals and the given transactions into a go-ethereum block. The header
+// is built by ToHeader and kept as is, so the block hash is the one of the original block even when only part
+// of its transactions are given. Transactions are converted with Transaction.ToGeth, in the given order. A
+// transaction of a type go-ethereum does not support, such as an OP-stack deposit, fails the conversion with
+// an error wrapping errorshs.ErrUnsupportedTransactionType; use ToGethSkipUnsupported to leave them out.
+func (b *Block) ToGeth(transactions []Transaction) (*types.Block, error) {
+	return b.toGeth(transactions, false)
+}
+
+// ToGethSkipUnsupported converts the block like ToGeth, but leaves out the transactions of types go-ethereum
+// does not support. The transactions of the returned block then no longer match its transactions root, and
+// their positions no longer match their transaction indexes.
+func (b *Block) ToGethSkipUnsupported(transactions []Transaction) (*types.Block, error) {
+	return b.toGeth(transactions, true)
+}
+
+// toGeth converts the block, leaving out the transactions of unsupported types when skipUnsupported is set.
+func (b *Block) toGeth(transactions []Transaction, skipUnsupported bool) (*types.Block, error) {
+	header, err := b.ToHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]*types.Transaction, 0, len(transactions))
+	for i := range transactions {
+		tx, tErr := transactions[i].ToGeth()
+		if skipUnsupported && errors.Is(tErr, errorshs.ErrUnsupportedTransactionType) {
+			continue
+		}
+		if tErr != nil {
+			return nil, errors.Wrapf(tErr, "failed to convert transaction %d of block %s", i, b.Number)
+		}
+		txs = append(txs, tx)
+	}
+
+	body := types.Body{Transactions: txs}
+	if b.Withdrawals != nil {
+		body.Withdrawals = make([]*types.Withdrawal, 0, len(*b.Withdrawals))
+		for _, withdrawal := range *b.Withdrawals {
+			body.Withdrawals = append(body.Withdrawals, withdrawal.ToGeth())
+		}
+	}
+
+	return types.NewBlockWithHeader(header).WithBody(body), nil
+}
+
+// ToCommon converts the block into a go-ethereum block without transactions. When fields required by
+// ToHeader are missing, the block only carries the number.
+//
+// Deprecated: Use ToGeth, which reports missing fields and carries the transactions.
 func (b *Block) ToCommon() *types.Block {
-	return &types.Block{}
+	block, err := b.ToGeth(nil)
+	if err != nil {
+		return types.NewBlockWithHeader(b.ToCommonHeader())
+	}
+	return block
 }
 
+// ToCommonHeader converts the block into a go-ethereum header. When fields required by ToHeader are missing,
+// the header only carries the number.
+//
+// Deprecated: Use ToHeader, which reports missing fields.
 func (b *Block) ToCommonHeader() *types.Header {
-	return &types.Header{
-		Number: b.Number,
-		//Bloom:  *b.LogsBloom,
+	header, err := b.ToHeader()
+	if err != nil {
+		return &types.Header{Number: b.Number}
 	}
+	return header
 }
 
+// NewBlockFromRecord decodes the first row of the record into a block. It decodes in lenient mode, skipping
+// columns of an unexpected type or with an unknown name; use NewBlockFromRecordWithMode to decode strictly.
 func NewBlockFromRecord(schema *arrow.Schema, record arrow.Record) (*Block, error) {
+	return NewBlockFromRecordWithMode(schema, record, DecodeLenient)
+}
+
+// NewBlockFromRecordWithMode decodes the first row of the record into a block in the given mode. Use a Decoder
+// to read the warnings of lenient decoding.
+func NewBlockFromRecordWithMode(schema *arrow.Schema, record arrow.Record, mode DecodeMode) (*Block, error) {
+	return NewDecoder(mode).Block(schema, record)
+}
+
+// Block decodes the first row of the record into a block according to the decoder mode.
+func (d *Decoder) Block(schema *arrow.Schema, record arrow.Record) (*Block, error) {
 	if record.NumCols() != int64(len(schema.Fields())) {
 		return nil, errors.New("number of columns in record does not match schema")
 	}
@@ -95,180 +297,87 @@ func NewBlockFromRecord(schema *arrow.Schema, record arrow.Record) (*Block, erro
 		if col.Len() == 0 {
 			continue
 		}
+		var err error
 		switch field.Name {
 		case "number":
-			if fCol, ok := col.(*array.Uint64); ok {
-				val := fCol.Value(0)
-				toReturn.Number = big.NewInt(0).SetUint64(val)
-			}
+			toReturn.Number, err = bigIntFromColumn(col, 0)
 		case "hash":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				hash := common.BytesToHash(val)
-				toReturn.Hash = &hash
-			}
+			toReturn.Hash, err = hashFromColumn(col, 0)
 		case "parent_hash":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				hash := common.BytesToHash(val)
-				toReturn.ParentHash = &hash
-			}
+			toReturn.ParentHash, err = hashFromColumn(col, 0)
 		case "nonce":
-			if fCol, ok := col.(*array.Binary); ok {
-				nonce := types.BlockNonce(fCol.Value(0))
-				toReturn.Nonce = &nonce
+			var val *big.Int
+			if val, err = bigIntFromColumn(col, 0); err == nil && val != nil {
+				if !val.IsUint64() {
+					err = errors.Errorf("nonce %s overflows 64 bits", val)
+				} else {
+					nonce := types.EncodeNonce(val.Uint64())
+					toReturn.Nonce = &nonce
+				}
 			}
 		case "sha3_uncles":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				hash := common.BytesToHash(val)
-				toReturn.Sha3Uncles = &hash
-			}
+			toReturn.Sha3Uncles, err = hashFromColumn(col, 0)
 		case "logs_bloom":
-			if fCol, ok := col.(*array.Binary); ok {
-				hash := types.Bloom(fCol.Value(0))
-				toReturn.LogsBloom = &hash
+			var val *[]byte
+			if val, err = bytesFromColumn(col, 0); err == nil && val != nil {
+				bloom := types.BytesToBloom(*val)
+				toReturn.LogsBloom = &bloom
 			}
 		case "transactions_root":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				hash := common.BytesToHash(val)
-				toReturn.TransactionsRoot = &hash
-			}
+			toReturn.TransactionsRoot, err = hashFromColumn(col, 0)
 		case "state_root":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				hash := common.BytesToHash(val)
-				toReturn.StateRoot = &hash
-			}
+			toReturn.StateRoot, err = hashFromColumn(col, 0)
 		case "receipts_root":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				hash := common.BytesToHash(val)
-				toReturn.ReceiptsRoot = &hash
-			}
+			toReturn.ReceiptsRoot, err = hashFromColumn(col, 0)
 		case "miner":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				miner := common.BytesToAddress(val)
-				toReturn.Miner = &miner
-			}
+			toReturn.Miner, err = addressFromColumn(col, 0)
 		case "difficulty":
-			if fCol, ok := col.(*array.Uint64); ok {
-				toReturn.Difficulty = big.NewInt(0).SetUint64(fCol.Value(0))
-			}
+			toReturn.Difficulty, err = bigIntFromColumn(col, 0)
 		case "total_difficulty":
-			if fCol, ok := col.(*array.Uint64); ok {
-				toReturn.TotalDifficulty = big.NewInt(0).SetUint64(fCol.Value(0))
-			}
+			toReturn.TotalDifficulty, err = bigIntFromColumn(col, 0)
 		case "extra_data":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				hash := common.BytesToHash(val)
-				toReturn.ExtraData = &hash
-			}
+			toReturn.ExtraData, err = bytesFromColumn(col, 0)
 		case "size":
-			if fCol, ok := col.(*array.Uint64); ok {
-				val := fCol.Value(0)
-				toReturn.Size = &val
-			}
+			toReturn.Size, err = uint64FromColumn(col, 0)
 		case "gas_limit":
-			if fCol, ok := col.(*array.Uint64); ok {
-				val := fCol.Value(0)
-				toReturn.GasLimit = &val
-			}
+			toReturn.GasLimit, err = uint64FromColumn(col, 0)
 		case "gas_used":
-			if fCol, ok := col.(*array.Uint64); ok {
-				val := fCol.Value(0)
-				toReturn.GasUsed = &val
-			}
+			toReturn.GasUsed, err = uint64FromColumn(col, 0)
 		case "timestamp":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				// Convert the first 4 bytes into an int32 timestamp
-				timestampInt := int64(binary.BigEndian.Uint32(val))
-				t := time.Unix(timestampInt, 0)
-				toReturn.Timestamp = &t
-			} else if fCol, ok := col.(*array.Int64); ok {
-				val := fCol.Value(0)
-				t := time.Unix(val, 0)
+			var timestamp *uint64
+			if timestamp, err = uint64FromColumn(col, 0); err == nil && timestamp != nil {
+				t := time.Unix(int64(*timestamp), 0)
 				toReturn.Timestamp = &t
-			} else {
-				return nil, fmt.Errorf("unsupported type for timestamp: %T", col)
 			}
 		case "uncles":
-			if fCol, ok := col.(*array.List); ok {
-				uncles := make([]common.Hash, fCol.Len())
-
-				/*				for j := 0; j < fCol.Len(); j++ {
-								val := fCol.Value(j).(*array.Binary).Value(0)
-								uncles[j] = common.BytesToHash(val)
-							}*/
-				toReturn.Uncles = &uncles
-			}
+			toReturn.Uncles, err = hashesFromColumn(col, 0)
 		case "base_fee_per_gas":
-			if fCol, ok := col.(*array.Uint64); ok {
-				toReturn.BaseFeePerGas = big.NewInt(0).SetUint64(fCol.Value(0))
-			}
+			toReturn.BaseFeePerGas, err = bigIntFromColumn(col, 0)
 		case "blob_gas_used":
-			if fCol, ok := col.(*array.Uint64); ok {
-				val := fCol.Value(0)
-				toReturn.BlobGasUsed = &val
-			}
+			toReturn.BlobGasUsed, err = uint64FromColumn(col, 0)
 		case "excess_blob_gas":
-			if fCol, ok := col.(*array.Uint64); ok {
-				val := fCol.Value(0)
-				toReturn.ExcessBlobGas = &val
-			}
+			toReturn.ExcessBlobGas, err = uint64FromColumn(col, 0)
 		case "parent_beacon_block_root":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				hash := common.BytesToHash(val)
-				toReturn.ParentBeaconBlockRoot = &hash
-			}
+			toReturn.ParentBeaconBlockRoot, err = hashFromColumn(col, 0)
 		case "withdrawals_root":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				hash := common.BytesToHash(val)
-				toReturn.WithdrawalsRoot = &hash
-			}
+			toReturn.WithdrawalsRoot, err = hashFromColumn(col, 0)
 		case "withdrawals":
-			if fCol, ok := col.(*array.List); ok {
-				withdrawals := make([]Withdrawal, fCol.Len())
-				/*				for j := 0; j < fCol.Len(); j++ {
-								withdrawalStruct := fCol.Value(j).(*array.Struct)
-								withdrawal := Withdrawal{
-									Index:  withdrawalStruct.Field(0).(*array.Uint64).Value(0),
-									ValidatorIndex: withdrawalStruct.Field(1).(*array.Uint64).Value(0),
-									Address: common.BytesToAddress(withdrawalStruct.Field(2).(*array.Binary).Value(0)),
-									Amount:  big.NewInt(0).SetUint64(withdrawalStruct.Field(3).(*array.Uint64).Value(0)),
-								}
-								withdrawals[j] = withdrawal
-							}*/
-				toReturn.Withdrawals = &withdrawals
-			}
+			toReturn.Withdrawals, err = withdrawalsFromColumn(col, 0)
+		case "requests_hash":
+			toReturn.RequestsHash, err = hashFromColumn(col, 0)
 		case "l1_block_number":
-			if fCol, ok := col.(*array.Uint64); ok {
-				toReturn.L1BlockNumber = big.NewInt(0).SetUint64(fCol.Value(0))
-			}
+			toReturn.L1BlockNumber, err = bigIntFromColumn(col, 0)
 		case "send_count":
-			if fCol, ok := col.(*array.Uint64); ok {
-				toReturn.SendCount = big.NewInt(0).SetUint64(fCol.Value(0))
-			}
+			toReturn.SendCount, err = bigIntFromColumn(col, 0)
 		case "send_root":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				hash := common.BytesToHash(val)
-				toReturn.SendRoot = &hash
-			}
+			toReturn.SendRoot, err = hashFromColumn(col, 0)
 		case "mix_hash":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				hash := common.BytesToHash(val)
-				toReturn.MixHash = &hash
-			}
+			toReturn.MixHash, err = hashFromColumn(col, 0)
 		default:
-			return nil, errors.New("unsupported field: " + field.Name)
+			err = &UnknownColumnError{Name: field.Name}
+		}
+		if err = d.check("block", field, err); err != nil {
+			return nil, err
 		}
 	}
 
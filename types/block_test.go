@@ -0,0 +1,158 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	errorshs "github.com/enviodev/hypersync-client-go/errors"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockToHeader(t *testing.T) {
+	baseFee := big.NewInt(7e9)
+	withdrawalsRoot := common.HexToHash("0x5eed")
+	blobGasUsed, excessBlobGas := uint64(262144), uint64(131072)
+	beaconRoot := common.HexToHash("0xbeac")
+	requestsHash := types.EmptyRequestsHash
+
+	tests := []struct {
+		name   string
+		header *types.Header
+	}{
+		{
+			name: "Frontier",
+			header: &types.Header{
+				ParentHash:  common.HexToHash("0x01"),
+				UncleHash:   types.EmptyUncleHash,
+				Coinbase:    common.HexToAddress("0x05a56e2d52c817161883f50c441c3228cfe54d9f"),
+				Root:        common.HexToHash("0x02"),
+				TxHash:      types.EmptyTxsHash,
+				ReceiptHash: types.EmptyReceiptsHash,
+				Difficulty:  big.NewInt(17171480576),
+				Number:      big.NewInt(1),
+				GasLimit:    5000,
+				Time:        1438269988,
+				Extra:       common.FromHex("0x476574682f76312e302e302f6c696e75782f676f312e342e32"),
+				MixDigest:   common.HexToHash("0x03"),
+				Nonce:       types.EncodeNonce(0x539bd4979fef1ec4),
+			},
+		},
+		{
+			name: "Cancun",
+			header: &types.Header{
+				ParentHash:       common.HexToHash("0x11"),
+				UncleHash:        types.EmptyUncleHash,
+				Coinbase:         common.HexToAddress("0x95222290dd7278aa3ddd389cc1e1d165cc4bafe5"),
+				Root:             common.HexToHash("0x12"),
+				TxHash:           common.HexToHash("0x13"),
+				ReceiptHash:      common.HexToHash("0x14"),
+				Bloom:            types.BytesToBloom([]byte{0x01, 0x02}),
+				Difficulty:       big.NewInt(0),
+				Number:           big.NewInt(19426587),
+				GasLimit:         30000000,
+				GasUsed:          12345678,
+				Time:             1710338135,
+				Extra:            []byte("beaverbuild.org"),
+				MixDigest:        common.HexToHash("0x15"),
+				BaseFee:          baseFee,
+				WithdrawalsHash:  &withdrawalsRoot,
+				BlobGasUsed:      &blobGasUsed,
+				ExcessBlobGas:    &excessBlobGas,
+				ParentBeaconRoot: &beaconRoot,
+			},
+		},
+		{
+			name: "Prague",
+			header: &types.Header{
+				ParentHash:       common.HexToHash("0x21"),
+				UncleHash:        types.EmptyUncleHash,
+				Coinbase:         common.HexToAddress("0x4838b106fce9647bdf1e7877bf73ce8b0bad5f97"),
+				Root:             common.HexToHash("0x22"),
+				TxHash:           common.HexToHash("0x23"),
+				ReceiptHash:      common.HexToHash("0x24"),
+				Difficulty:       big.NewInt(0),
+				Number:           big.NewInt(22431084),
+				GasLimit:         36000000,
+				GasUsed:          17000000,
+				Time:             1746612311,
+				Extra:            []byte("Titan (titanbuilder.xyz)"),
+				MixDigest:        common.HexToHash("0x25"),
+				BaseFee:          baseFee,
+				WithdrawalsHash:  &withdrawalsRoot,
+				BlobGasUsed:      &blobGasUsed,
+				ExcessBlobGas:    &excessBlobGas,
+				ParentBeaconRoot: &beaconRoot,
+				RequestsHash:     &requestsHash,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			block := NewBlockFromHeader(tt.header)
+
+			header, err := block.ToHeader()
+			require.NoError(t, err)
+			require.Equal(t, tt.header.Hash(), header.Hash())
+			require.Equal(t, *block.Hash, header.Hash())
+
+			gethBlock, err := block.ToGeth(nil)
+			require.NoError(t, err)
+			require.Equal(t, *block.Hash, gethBlock.Hash())
+			require.Equal(t, tt.header.ParentBeaconRoot, gethBlock.BeaconRoot())
+			require.Equal(t, tt.header.RequestsHash, gethBlock.RequestsHash())
+		})
+	}
+}
+
+func TestBlockToHeaderMissingFields(t *testing.T) {
+	var missing *MissingFieldsError
+
+	_, err := (&Block{Number: big.NewInt(1)}).ToHeader()
+	require.ErrorAs(t, err, &missing)
+	require.Equal(t, "block", missing.Entity)
+	require.NotContains(t, missing.Fields, "number")
+	require.Contains(t, missing.Fields, "extra_data")
+	require.NotContains(t, missing.Fields, "base_fee_per_gas")
+	require.EqualError(t, (&MissingFieldsError{Entity: "block", Fields: []string{"nonce", "mix_hash"}}), "block is missing required fields: nonce, mix_hash")
+}
+
+func TestBlockToGethUnsupportedTransactions(t *testing.T) {
+	header := &types.Header{
+		UncleHash:  types.EmptyUncleHash,
+		TxHash:     common.HexToHash("0x7702"),
+		Difficulty: big.NewInt(0),
+		Number:     big.NewInt(21000000),
+	}
+	block := NewBlockFromHeader(header)
+
+	kind := DepositTxType
+	_, err := block.ToGeth([]Transaction{{Kind: &kind}})
+	require.ErrorIs(t, err, errorshs.ErrUnsupportedTransactionType)
+
+	gethBlock, err := block.ToGethSkipUnsupported([]Transaction{{Kind: &kind}})
+	require.NoError(t, err)
+	require.Equal(t, header.Hash(), gethBlock.Hash())
+	require.Empty(t, gethBlock.Transactions())
+
+	_, err = (&Transaction{Kind: &kind}).ToGeth()
+	require.ErrorIs(t, err, errorshs.ErrUnsupportedTransactionType)
+}
+
+func TestBlockToCommon(t *testing.T) {
+	header := &types.Header{
+		UncleHash:  types.EmptyUncleHash,
+		TxHash:     types.EmptyTxsHash,
+		Difficulty: big.NewInt(1),
+		Number:     big.NewInt(46147),
+	}
+	block := NewBlockFromHeader(header)
+	require.Equal(t, header.Hash(), block.ToCommonHeader().Hash())
+	require.Equal(t, header.Hash(), block.ToCommon().Hash())
+
+	partial := &Block{Number: big.NewInt(46147)}
+	require.Equal(t, big.NewInt(46147), partial.ToCommonHeader().Number)
+	require.Equal(t, uint64(46147), partial.ToCommon().NumberU64())
+}
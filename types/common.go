@@ -3,9 +3,11 @@ package types
 import (
 	"fmt"
 	"math/big"
+	"strings"
 
 	"github.com/apache/arrow/go/v10/arrow"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 type DataType uint8
@@ -52,8 +54,30 @@ type Data string
 type BlockNumber uint64
 type TransactionIndex uint64
 type LogIndex uint64
-type Withdrawal struct{}
 type TransactionType string
+
+// Withdrawal represents a validator withdrawal from the consensus layer. See EIP-4895.
+type Withdrawal struct {
+	// Index is the monotonically increasing identifier of the withdrawal.
+	Index uint64 `json:"index"`
+	// ValidatorIndex is the index of the withdrawing validator.
+	ValidatorIndex uint64 `json:"validator_index"`
+	// Address is the recipient of the withdrawn ether.
+	Address common.Address `json:"address"`
+	// Amount is the withdrawn value, in Gwei.
+	Amount uint64 `json:"amount"`
+}
+
+// ToGeth converts the withdrawal into a go-ethereum withdrawal.
+func (w Withdrawal) ToGeth() *types.Withdrawal {
+	return &types.Withdrawal{
+		Index:     w.Index,
+		Validator: w.ValidatorIndex,
+		Address:   w.Address,
+		Amount:    w.Amount,
+	}
+}
+
 type TransactionStatus string
 
 type JoinMode string
@@ -66,6 +90,12 @@ func addrDT() arrow.DataType {
 	return &arrow.FixedSizeBinaryType{ByteWidth: 20}
 }
 
+// quantityDT is the type of quantities, such as wei values and gas, encoded as big-endian binary as they
+// may exceed 64 bits.
+func quantityDT() arrow.DataType {
+	return arrow.BinaryTypes.Binary
+}
+
 const (
 	Default     JoinMode = "Default"
 	JoinAll     JoinMode = "JoinAll"
@@ -87,3 +117,32 @@ type AccessList struct {
 	Address     *Address `json:"address,omitempty"`
 	StorageKeys *[]Hash  `json:"storageKeys,omitempty"`
 }
+
+// MissingFieldsError is returned by the conversions into go-ethereum types when fields they require were not
+// selected in the query field selection.
+type MissingFieldsError struct {
+	// Entity is the converted entity, such as block, transaction or log.
+	Entity string
+	// Fields are the names of the missing fields, as used in the field selection.
+	Fields []string
+}
+
+// Error returns the missing fields of the entity.
+func (e *MissingFieldsError) Error() string {
+	return fmt.Sprintf("%s is missing required fields: %s", e.Entity, strings.Join(e.Fields, ", "))
+}
+
+// require records the field as missing unless present.
+func (e *MissingFieldsError) require(field string, present bool) {
+	if !present {
+		e.Fields = append(e.Fields, field)
+	}
+}
+
+// orNil returns the error when at least one field is missing and nil otherwise.
+func (e *MissingFieldsError) orNil() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+	return e
+}
@@ -0,0 +1,222 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow/go/v10/arrow"
+	"github.com/apache/arrow/go/v10/arrow/array"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// DecodeMode controls how record decoders handle columns that do not match the expected schema.
+type DecodeMode int
+
+const (
+	// DecodeLenient records schema mismatches as warnings in the DecodeReport and leaves the fields unset.
+	DecodeLenient DecodeMode = iota
+	// DecodeStrict fails on the first column of an unexpected type or with an unknown name.
+	DecodeStrict
+)
+
+// String returns the name of the decode mode.
+func (m DecodeMode) String() string {
+	switch m {
+	case DecodeLenient:
+		return "lenient"
+	case DecodeStrict:
+		return "strict"
+	default:
+		return fmt.Sprintf("DecodeMode(%d)", int(m))
+	}
+}
+
+// ColumnTypeError is returned when a column has a type the field cannot be decoded from.
+type ColumnTypeError struct {
+	// Expected describes the value the field holds, such as "hash" or "quantity".
+	Expected string
+	// Type is the type of the column.
+	Type arrow.DataType
+}
+
+func (e *ColumnTypeError) Error() string {
+	return fmt.Sprintf("unsupported column type for %s: %s", e.Expected, e.Type)
+}
+
+// UnknownColumnError is returned when a record holds a column no field is decoded from.
+type UnknownColumnError struct {
+	// Name is the name of the column.
+	Name string
+}
+
+func (e *UnknownColumnError) Error() string {
+	return "unsupported field: " + e.Name
+}
+
+// DecodeWarning describes a column skipped while decoding in lenient mode.
+type DecodeWarning struct {
+	// Entity is the decoded entity, one of "block", "transaction", "log" or "trace".
+	Entity string `json:"entity"`
+	// Field is the name of the skipped column.
+	Field string `json:"field"`
+	// Message describes the mismatch.
+	Message string `json:"message"`
+	// Count is the number of rows the column was skipped for.
+	Count int `json:"count"`
+}
+
+// String returns a human-readable description of the warning.
+func (w DecodeWarning) String() string {
+	return fmt.Sprintf("%s.%s: %s (%d rows)", w.Entity, w.Field, w.Message, w.Count)
+}
+
+// DecodeReport collects the warnings of a lenient decoding. Identical warnings are reported once, with the
+// number of rows they occurred for.
+type DecodeReport struct {
+	Warnings []DecodeWarning `json:"warnings"`
+}
+
+// Empty reports whether no warning was recorded. It is safe to call on a nil report.
+func (r *DecodeReport) Empty() bool {
+	return r == nil || len(r.Warnings) == 0
+}
+
+// Merge adds the warnings of other to the report.
+func (r *DecodeReport) Merge(other *DecodeReport) {
+	if other.Empty() {
+		return
+	}
+	for _, warning := range other.Warnings {
+		r.add(warning.Entity, warning.Field, warning.Message, warning.Count)
+	}
+}
+
+// add records count occurrences of a warning.
+func (r *DecodeReport) add(entity string, field string, message string, count int) {
+	for i, warning := range r.Warnings {
+		if warning.Entity == entity && warning.Field == field && warning.Message == message {
+			r.Warnings[i].Count += count
+			return
+		}
+	}
+	r.Warnings = append(r.Warnings, DecodeWarning{Entity: entity, Field: field, Message: message, Count: count})
+}
+
+// Decoder decodes Arrow records into blocks, transactions, logs and traces according to its DecodeMode.
+// A Decoder is not safe for concurrent use.
+type Decoder struct {
+	mode   DecodeMode
+	report *DecodeReport
+}
+
+// NewDecoder creates a Decoder with the given mode.
+func NewDecoder(mode DecodeMode) *Decoder {
+	return &Decoder{
+		mode:   mode,
+		report: &DecodeReport{},
+	}
+}
+
+// Mode returns the decode mode.
+func (d *Decoder) Mode() DecodeMode {
+	return d.mode
+}
+
+// Report returns the warnings recorded so far. It is always empty in strict mode.
+func (d *Decoder) Report() *DecodeReport {
+	return d.report
+}
+
+// check applies the decode mode to the error of a column. Schema mismatches fail in strict mode and are
+// recorded as warnings in lenient mode, while any other error fails in both modes.
+func (d *Decoder) check(entity string, field arrow.Field, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var typeErr *ColumnTypeError
+	var unknownErr *UnknownColumnError
+	isUnknown := errors.As(err, &unknownErr)
+	if d.mode == DecodeLenient && (isUnknown || errors.As(err, &typeErr)) {
+		d.report.add(entity, field.Name, err.Error(), 1)
+		return nil
+	}
+
+	if isUnknown {
+		return err
+	}
+	return errors.Wrapf(err, "failed to decode field: %s", field.Name)
+}
+
+// hashFromColumn reads the 32-byte hash at row i of a binary column. It returns nil when the value is null.
+func hashFromColumn(col arrow.Array, i int) (*common.Hash, error) {
+	if col.IsNull(i) {
+		return nil, nil
+	}
+	val, ok := binaryValue(col, i)
+	if !ok {
+		return nil, &ColumnTypeError{Expected: "hash", Type: col.DataType()}
+	}
+	hash := common.BytesToHash(val)
+	return &hash, nil
+}
+
+// addressFromColumn reads the 20-byte address at row i of a binary column. It returns nil when the value is null.
+func addressFromColumn(col arrow.Array, i int) (*common.Address, error) {
+	if col.IsNull(i) {
+		return nil, nil
+	}
+	val, ok := binaryValue(col, i)
+	if !ok {
+		return nil, &ColumnTypeError{Expected: "address", Type: col.DataType()}
+	}
+	addr := common.BytesToAddress(val)
+	return &addr, nil
+}
+
+// boolFromColumn reads the value at row i of a boolean column. It returns nil when the value is null.
+func boolFromColumn(col arrow.Array, i int) (*bool, error) {
+	if col.IsNull(i) {
+		return nil, nil
+	}
+	fCol, ok := col.(*array.Boolean)
+	if !ok {
+		return nil, &ColumnTypeError{Expected: "boolean", Type: col.DataType()}
+	}
+	val := fCol.Value(i)
+	return &val, nil
+}
+
+// stringFromColumn reads the value at row i of a string column. It returns nil when the value is null.
+func stringFromColumn(col arrow.Array, i int) (*string, error) {
+	if col.IsNull(i) {
+		return nil, nil
+	}
+	switch fCol := col.(type) {
+	case *array.String:
+		val := fCol.Value(i)
+		return &val, nil
+	case *array.LargeString:
+		val := fCol.Value(i)
+		return &val, nil
+	default:
+		return nil, &ColumnTypeError{Expected: "string", Type: col.DataType()}
+	}
+}
+
+// float64FromColumn reads the value at row i of a floating point column. It returns nil when the value is null.
+func float64FromColumn(col arrow.Array, i int) (*float64, error) {
+	if col.IsNull(i) {
+		return nil, nil
+	}
+	switch fCol := col.(type) {
+	case *array.Float64:
+		val := fCol.Value(i)
+		return &val, nil
+	case *array.Float32:
+		val := float64(fCol.Value(i))
+		return &val, nil
+	default:
+		return nil, &ColumnTypeError{Expected: "float", Type: col.DataType()}
+	}
+}
@@ -0,0 +1,144 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/v10/arrow"
+	"github.com/apache/arrow/go/v10/arrow/array"
+	"github.com/apache/arrow/go/v10/arrow/memory"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoderFixedAndVariableBinary(t *testing.T) {
+	address := common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+	topic := common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "address", Type: addrDT()},
+		{Name: "topic0", Type: arrow.BinaryTypes.Binary},
+		{Name: "transaction_hash", Type: hashDT()},
+		{Name: "data", Type: arrow.BinaryTypes.Binary},
+	}, nil)
+
+	record := array.NewRecord(schema, []arrow.Array{
+		newFixedSizeBinaryColumn(address.Bytes()),
+		newBinaryColumn(topic.Bytes()),
+		newFixedSizeBinaryColumn(topic.Bytes()),
+		newBinaryColumn([]byte{0x01}),
+	}, 1)
+	defer record.Release()
+
+	log, err := NewLogFromRecord(schema, record)
+	require.NoError(t, err)
+	require.Equal(t, address, *log.Address)
+	require.Equal(t, topic, *log.Topic0)
+	require.Equal(t, topic, *log.TransactionHash)
+	require.Equal(t, []byte{0x01}, *log.Data)
+}
+
+func TestDecoderModes(t *testing.T) {
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "log_index", Type: arrow.PrimitiveTypes.Uint64},
+		{Name: "address", Type: arrow.BinaryTypes.String},
+		{Name: "unknown_column", Type: arrow.BinaryTypes.Binary},
+	}, nil)
+
+	newRecord := func() arrow.Record {
+		return array.NewRecord(schema, []arrow.Array{
+			newUint64Column(3),
+			newStringColumn("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"),
+			newBinaryColumn([]byte{0x01}),
+		}, 1)
+	}
+
+	t.Run("Strict", func(t *testing.T) {
+		record := newRecord()
+		defer record.Release()
+
+		decoder := NewDecoder(DecodeStrict)
+		_, err := decoder.Log(schema, record)
+		require.EqualError(t, err, "failed to decode field: address: unsupported column type for address: utf8")
+
+		var typeErr *ColumnTypeError
+		require.ErrorAs(t, err, &typeErr)
+		require.Equal(t, "address", typeErr.Expected)
+		require.True(t, decoder.Report().Empty())
+	})
+
+	t.Run("Strict unknown column", func(t *testing.T) {
+		unknownSchema := arrow.NewSchema([]arrow.Field{{Name: "unknown_column", Type: arrow.BinaryTypes.Binary}}, nil)
+		record := array.NewRecord(unknownSchema, []arrow.Array{newBinaryColumn([]byte{0x01})}, 1)
+		defer record.Release()
+
+		_, err := NewLogFromRecordWithMode(unknownSchema, record, DecodeStrict)
+		require.EqualError(t, err, "unsupported field: unknown_column")
+	})
+
+	t.Run("Lenient by default", func(t *testing.T) {
+		record := newRecord()
+		defer record.Release()
+
+		log, err := NewLogFromRecord(schema, record)
+		require.NoError(t, err)
+		require.Equal(t, uint64(3), *log.LogIndex)
+		require.Nil(t, log.Address)
+	})
+
+	t.Run("Lenient", func(t *testing.T) {
+		decoder := NewDecoder(DecodeLenient)
+		for i := 0; i < 2; i++ {
+			record := newRecord()
+			log, err := decoder.Log(schema, record)
+			record.Release()
+			require.NoError(t, err)
+			require.Equal(t, uint64(3), *log.LogIndex)
+			require.Nil(t, log.Address)
+		}
+
+		require.Equal(t, []DecodeWarning{
+			{Entity: "log", Field: "address", Message: "unsupported column type for address: utf8", Count: 2},
+			{Entity: "log", Field: "unknown_column", Message: "unsupported field: unknown_column", Count: 2},
+		}, decoder.Report().Warnings)
+	})
+
+	t.Run("Lenient value errors", func(t *testing.T) {
+		overflowSchema := arrow.NewSchema([]arrow.Field{{Name: "type", Type: arrow.PrimitiveTypes.Uint64}}, nil)
+		record := array.NewRecord(overflowSchema, []arrow.Array{newUint64Column(256)}, 1)
+		defer record.Release()
+
+		_, err := NewDecoder(DecodeLenient).Transaction(overflowSchema, record)
+		require.EqualError(t, err, "failed to decode field: type: quantity 256 overflows uint8")
+	})
+}
+
+func TestDecodeReportMerge(t *testing.T) {
+	report := &DecodeReport{}
+	report.Merge(nil)
+	require.True(t, report.Empty())
+
+	report.Merge(&DecodeReport{Warnings: []DecodeWarning{{Entity: "block", Field: "size", Message: "m", Count: 2}}})
+	report.Merge(&DecodeReport{Warnings: []DecodeWarning{
+		{Entity: "block", Field: "size", Message: "m", Count: 3},
+		{Entity: "trace", Field: "error", Message: "m", Count: 1},
+	}})
+
+	require.Equal(t, []DecodeWarning{
+		{Entity: "block", Field: "size", Message: "m", Count: 5},
+		{Entity: "trace", Field: "error", Message: "m", Count: 1},
+	}, report.Warnings)
+}
+
+func newFixedSizeBinaryColumn(val []byte) arrow.Array {
+	builder := array.NewFixedSizeBinaryBuilder(memory.NewGoAllocator(), &arrow.FixedSizeBinaryType{ByteWidth: len(val)})
+	defer builder.Release()
+	builder.Append(val)
+	return builder.NewArray()
+}
+
+func newStringColumn(val string) arrow.Array {
+	builder := array.NewStringBuilder(memory.NewGoAllocator())
+	defer builder.Release()
+	builder.Append(val)
+	return builder.NewArray()
+}
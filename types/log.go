@@ -2,8 +2,8 @@ package types
 
 import (
 	"github.com/apache/arrow/go/v10/arrow"
-	"github.com/apache/arrow/go/v10/arrow/array"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/pkg/errors"
 	"math/big"
 )
@@ -77,7 +77,83 @@ func (l *Log) GetData() []byte {
 	return *l.Data
 }
 
+// NewLogFromGeth converts a go-ethereum log into a log holding the fields HyperSync returns for it, the
+// inverse of ToGeth. The values are copied, and topics beyond the fourth are left out.
+func NewLogFromGeth(log *types.Log) *Log {
+	removed := log.Removed
+	index, txIndex := uint64(log.Index), uint64(log.TxIndex)
+	txHash, blockHash, address := log.TxHash, log.BlockHash, log.Address
+	data := append([]byte{}, log.Data...)
+
+	toReturn := &Log{
+		Removed:          &removed,
+		LogIndex:         &index,
+		TransactionIndex: &txIndex,
+		TransactionHash:  &txHash,
+		BlockHash:        &blockHash,
+		BlockNumber:      new(big.Int).SetUint64(log.BlockNumber),
+		Address:          &address,
+		Data:             &data,
+	}
+
+	topics := []**common.Hash{&toReturn.Topic0, &toReturn.Topic1, &toReturn.Topic2, &toReturn.Topic3}
+	for i := 0; i < len(log.Topics) && i < len(topics); i++ {
+		topic := log.Topics[i]
+		*topics[i] = &topic
+	}
+
+	return toReturn
+}
+
+// ToGeth converts the log into a go-ethereum log. The address and data must have been selected, otherwise
+// a *MissingFieldsError is returned; topics that were not selected are left out of the log. The position
+// fields (block, transaction and log index) are zero when not selected.
+func (l *Log) ToGeth() (*types.Log, error) {
+	missing := &MissingFieldsError{Entity: "log"}
+	missing.require("address", l.Address != nil)
+	missing.require("data", l.Data != nil)
+	if err := missing.orNil(); err != nil {
+		return nil, err
+	}
+
+	log := &types.Log{
+		Address: *l.Address,
+		Topics:  l.Topics(),
+		Data:    append([]byte{}, *l.Data...),
+		TxIndex: l.TxIndex(),
+		Index:   l.Index(),
+	}
+
+	if l.BlockNumber != nil {
+		log.BlockNumber = l.BlockNumber.Uint64()
+	}
+	if l.TransactionHash != nil {
+		log.TxHash = *l.TransactionHash
+	}
+	if l.BlockHash != nil {
+		log.BlockHash = *l.BlockHash
+	}
+	if l.Removed != nil {
+		log.Removed = *l.Removed
+	}
+
+	return log, nil
+}
+
+// NewLogFromRecord decodes the first row of the record into a log. It decodes in lenient mode, skipping
+// columns of an unexpected type or with an unknown name; use NewLogFromRecordWithMode to decode strictly.
 func NewLogFromRecord(schema *arrow.Schema, record arrow.Record) (*Log, error) {
+	return NewLogFromRecordWithMode(schema, record, DecodeLenient)
+}
+
+// NewLogFromRecordWithMode decodes the first row of the record into a log in the given mode. Use a Decoder
+// to read the warnings of lenient decoding.
+func NewLogFromRecordWithMode(schema *arrow.Schema, record arrow.Record, mode DecodeMode) (*Log, error) {
+	return NewDecoder(mode).Log(schema, record)
+}
+
+// Log decodes the first row of the record into a log according to the decoder mode.
+func (d *Decoder) Log(schema *arrow.Schema, record arrow.Record) (*Log, error) {
 	if record.NumCols() != int64(len(schema.Fields())) {
 		return nil, errors.New("number of columns in record does not match schema")
 	}
@@ -89,76 +165,37 @@ func NewLogFromRecord(schema *arrow.Schema, record arrow.Record) (*Log, error) {
 		if col.Len() == 0 {
 			continue
 		}
+		var err error
 		switch field.Name {
 		case "removed":
-			if fCol, ok := col.(*array.Boolean); ok {
-				val := fCol.Value(0)
-				toReturn.Removed = &val
-			}
+			toReturn.Removed, err = boolFromColumn(col, 0)
 		case "log_index":
-			if fCol, ok := col.(*array.Uint64); ok {
-				val := fCol.Value(0)
-				toReturn.LogIndex = &val
-			}
+			toReturn.LogIndex, err = uint64FromColumn(col, 0)
 		case "transaction_index":
-			if fCol, ok := col.(*array.Uint64); ok {
-				val := fCol.Value(0)
-				toReturn.TransactionIndex = &val
-			}
+			toReturn.TransactionIndex, err = uint64FromColumn(col, 0)
 		case "transaction_hash":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				hash := common.BytesToHash(val)
-				toReturn.TransactionHash = &hash
-			}
+			toReturn.TransactionHash, err = hashFromColumn(col, 0)
 		case "block_hash":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				blockHash := common.BytesToHash(val)
-				toReturn.BlockHash = &blockHash
-			}
+			toReturn.BlockHash, err = hashFromColumn(col, 0)
 		case "block_number":
-			if fCol, ok := col.(*array.Uint64); ok {
-				val := fCol.Value(0)
-				toReturn.BlockNumber = big.NewInt(0).SetUint64(val)
-			}
+			toReturn.BlockNumber, err = bigIntFromColumn(col, 0)
 		case "address":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				address := common.BytesToAddress(val)
-				toReturn.Address = &address
-			}
+			toReturn.Address, err = addressFromColumn(col, 0)
 		case "data":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				toReturn.Data = &val
-			}
+			toReturn.Data, err = bytesFromColumn(col, 0)
 		case "topic0":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				topic := common.BytesToHash(val)
-				toReturn.Topic0 = &topic
-			}
+			toReturn.Topic0, err = hashFromColumn(col, 0)
 		case "topic1":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				topic := common.BytesToHash(val)
-				toReturn.Topic1 = &topic
-			}
+			toReturn.Topic1, err = hashFromColumn(col, 0)
 		case "topic2":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				topic := common.BytesToHash(val)
-				toReturn.Topic2 = &topic
-			}
+			toReturn.Topic2, err = hashFromColumn(col, 0)
 		case "topic3":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				topic := common.BytesToHash(val)
-				toReturn.Topic3 = &topic
-			}
+			toReturn.Topic3, err = hashFromColumn(col, 0)
 		default:
-			return nil, errors.New("unsupported field: " + field.Name)
+			err = &UnknownColumnError{Name: field.Name}
+		}
+		if err = d.check("log", field, err); err != nil {
+			return nil, err
 		}
 	}
 
@@ -0,0 +1,555 @@
+package types
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/apache/arrow/go/v10/arrow"
+	"github.com/apache/arrow/go/v10/arrow/array"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+// Nested columns, such as withdrawals or access lists, are returned either as native Arrow lists and
+// structs or as binary values. Binary lists of fixed size elements, like uncles and blob versioned hashes,
+// are the concatenation of their elements. Other binary nested values are encoded with bincode: little-endian
+// fixed-size integers, u64 length prefixes for sequences and one byte tags for optional values. Byte values
+// inside them are length prefixed and either raw or 0x-prefixed hex strings.
+
+// bytesFromColumn reads the value at row i of a binary column. It returns nil when the value is null.
+func bytesFromColumn(col arrow.Array, i int) (*[]byte, error) {
+	if col.IsNull(i) {
+		return nil, nil
+	}
+	val, ok := binaryValue(col, i)
+	if !ok {
+		return nil, &ColumnTypeError{Expected: "bytes", Type: col.DataType()}
+	}
+	toReturn := append([]byte{}, val...)
+	return &toReturn, nil
+}
+
+// hashesFromColumn reads a list of 32-byte hashes at row i, from a list column or from the concatenation
+// of the hashes in a binary column. It returns nil when the value is null.
+func hashesFromColumn(col arrow.Array, i int) (*[]common.Hash, error) {
+	if col.IsNull(i) {
+		return nil, nil
+	}
+
+	if list, ok := col.(*array.List); ok {
+		start, end := list.ValueOffsets(i)
+		hashes := make([]common.Hash, 0, end-start)
+		for j := int(start); j < int(end); j++ {
+			val, vOk := binaryValue(list.ListValues(), j)
+			if !vOk || len(val) != common.HashLength {
+				return nil, fmt.Errorf("invalid hash list element %d", j-int(start))
+			}
+			hashes = append(hashes, common.BytesToHash(val))
+		}
+		return &hashes, nil
+	}
+
+	val, ok := binaryValue(col, i)
+	if !ok {
+		return nil, &ColumnTypeError{Expected: "hash list", Type: col.DataType()}
+	}
+	if len(val)%common.HashLength != 0 {
+		return nil, fmt.Errorf("hash list of %d bytes is not a multiple of %d", len(val), common.HashLength)
+	}
+
+	hashes := make([]common.Hash, 0, len(val)/common.HashLength)
+	for j := 0; j < len(val); j += common.HashLength {
+		hashes = append(hashes, common.BytesToHash(val[j:j+common.HashLength]))
+	}
+	return &hashes, nil
+}
+
+// uint64sFromColumn reads a list of integers at row i, such as a trace address, from a list column or from a
+// bincode encoded binary column. It returns nil when the value is null.
+func uint64sFromColumn(col arrow.Array, i int) (*[]uint64, error) {
+	if col.IsNull(i) {
+		return nil, nil
+	}
+
+	if list, ok := col.(*array.List); ok {
+		start, end := list.ValueOffsets(i)
+		values := make([]uint64, 0, end-start)
+		for j := int(start); j < int(end); j++ {
+			val, err := uint64FromColumn(list.ListValues(), j)
+			if err != nil {
+				return nil, err
+			}
+			if val == nil {
+				return nil, fmt.Errorf("null list element %d", j-int(start))
+			}
+			values = append(values, *val)
+		}
+		return &values, nil
+	}
+
+	val, ok := binaryValue(col, i)
+	if !ok {
+		return nil, &ColumnTypeError{Expected: "integer list", Type: col.DataType()}
+	}
+
+	r := &bincodeReader{buf: val}
+	length, err := r.length()
+	if err != nil {
+		return nil, err
+	}
+	values := make([]uint64, 0, length)
+	for j := 0; j < length; j++ {
+		v, vErr := r.u64()
+		if vErr != nil {
+			return nil, vErr
+		}
+		values = append(values, v)
+	}
+	return &values, r.done()
+}
+
+// withdrawalsFromColumn reads the withdrawals at row i, from a list of structs with index, validator_index,
+// address and amount fields or from a bincode encoded binary column. It returns nil when the value is null.
+func withdrawalsFromColumn(col arrow.Array, i int) (*[]Withdrawal, error) {
+	if col.IsNull(i) {
+		return nil, nil
+	}
+
+	if list, ok := col.(*array.List); ok {
+		fields, fErr := structFields(list.ListValues(), "index", "validator_index", "address", "amount")
+		if fErr != nil {
+			return nil, fErr
+		}
+
+		start, end := list.ValueOffsets(i)
+		withdrawals := make([]Withdrawal, 0, end-start)
+		for j := int(start); j < int(end); j++ {
+			var withdrawal Withdrawal
+			if err := withdrawal.fromColumns(fields, j); err != nil {
+				return nil, errors.Wrapf(err, "invalid withdrawal %d", j-int(start))
+			}
+			withdrawals = append(withdrawals, withdrawal)
+		}
+		return &withdrawals, nil
+	}
+
+	val, ok := binaryValue(col, i)
+	if !ok {
+		return nil, &ColumnTypeError{Expected: "withdrawals", Type: col.DataType()}
+	}
+
+	r := &bincodeReader{buf: val}
+	length, err := r.length()
+	if err != nil {
+		return nil, err
+	}
+	withdrawals := make([]Withdrawal, 0, length)
+	for j := 0; j < length; j++ {
+		var withdrawal Withdrawal
+		if wErr := withdrawal.fromBincode(r); wErr != nil {
+			return nil, errors.Wrapf(wErr, "invalid withdrawal %d", j)
+		}
+		withdrawals = append(withdrawals, withdrawal)
+	}
+	return &withdrawals, r.done()
+}
+
+// fromColumns reads the withdrawal at row j of the index, validator_index, address and amount columns.
+func (w *Withdrawal) fromColumns(fields []arrow.Array, j int) error {
+	if err := setUint64FromColumn(fields[0], j, &w.Index); err != nil {
+		return err
+	}
+	if err := setUint64FromColumn(fields[1], j, &w.ValidatorIndex); err != nil {
+		return err
+	}
+	if !fields[2].IsNull(j) {
+		addr, ok := binaryValue(fields[2], j)
+		if !ok || len(addr) != common.AddressLength {
+			return errors.New("invalid withdrawal address")
+		}
+		w.Address = common.BytesToAddress(addr)
+	}
+	return setUint64FromColumn(fields[3], j, &w.Amount)
+}
+
+// fromBincode reads a withdrawal of optional index, validator index, address and amount.
+func (w *Withdrawal) fromBincode(r *bincodeReader) error {
+	if err := r.optionalQuantity(&w.Index); err != nil {
+		return err
+	}
+	if err := r.optionalQuantity(&w.ValidatorIndex); err != nil {
+		return err
+	}
+
+	present, err := r.option()
+	if err != nil {
+		return err
+	}
+	if present {
+		addr, aErr := r.bytes()
+		if aErr != nil {
+			return aErr
+		}
+		if len(addr) != common.AddressLength {
+			return fmt.Errorf("invalid withdrawal address of %d bytes", len(addr))
+		}
+		w.Address = common.BytesToAddress(addr)
+	}
+
+	return r.optionalQuantity(&w.Amount)
+}
+
+// accessListFromColumn reads the access list at row i, from a list of structs with address and storage_keys
+// fields or from a bincode encoded binary column. It returns nil when the value is null.
+func accessListFromColumn(col arrow.Array, i int) (*types.AccessList, error) {
+	if col.IsNull(i) {
+		return nil, nil
+	}
+
+	if list, ok := col.(*array.List); ok {
+		fields, fErr := structFields(list.ListValues(), "address", "storage_keys")
+		if fErr != nil {
+			return nil, fErr
+		}
+
+		start, end := list.ValueOffsets(i)
+		accessList := make(types.AccessList, 0, end-start)
+		for j := int(start); j < int(end); j++ {
+			tuple := types.AccessTuple{StorageKeys: []common.Hash{}}
+			if !fields[0].IsNull(j) {
+				addr, aOk := binaryValue(fields[0], j)
+				if !aOk || len(addr) != common.AddressLength {
+					return nil, fmt.Errorf("invalid access list address %d", j-int(start))
+				}
+				tuple.Address = common.BytesToAddress(addr)
+			}
+			keys, kErr := hashesFromColumn(fields[1], j)
+			if kErr != nil {
+				return nil, errors.Wrapf(kErr, "invalid access list storage keys %d", j-int(start))
+			}
+			if keys != nil {
+				tuple.StorageKeys = *keys
+			}
+			accessList = append(accessList, tuple)
+		}
+		return &accessList, nil
+	}
+
+	val, ok := binaryValue(col, i)
+	if !ok {
+		return nil, &ColumnTypeError{Expected: "access list", Type: col.DataType()}
+	}
+
+	r := &bincodeReader{buf: val}
+	length, err := r.length()
+	if err != nil {
+		return nil, err
+	}
+	accessList := make(types.AccessList, 0, length)
+	for j := 0; j < length; j++ {
+		tuple, tErr := accessTupleFromBincode(r)
+		if tErr != nil {
+			return nil, errors.Wrapf(tErr, "invalid access list entry %d", j)
+		}
+		accessList = append(accessList, tuple)
+	}
+	return &accessList, r.done()
+}
+
+// accessTupleFromBincode reads an access list entry of optional address and optional storage keys.
+func accessTupleFromBincode(r *bincodeReader) (types.AccessTuple, error) {
+	tuple := types.AccessTuple{StorageKeys: []common.Hash{}}
+
+	present, err := r.option()
+	if err != nil {
+		return tuple, err
+	}
+	if present {
+		addr, aErr := r.bytes()
+		if aErr != nil {
+			return tuple, aErr
+		}
+		if len(addr) != common.AddressLength {
+			return tuple, fmt.Errorf("invalid address of %d bytes", len(addr))
+		}
+		tuple.Address = common.BytesToAddress(addr)
+	}
+
+	if present, err = r.option(); err != nil || !present {
+		return tuple, err
+	}
+	length, err := r.length()
+	if err != nil {
+		return tuple, err
+	}
+	for k := 0; k < length; k++ {
+		key, kErr := r.bytes()
+		if kErr != nil {
+			return tuple, kErr
+		}
+		if len(key) != common.HashLength {
+			return tuple, fmt.Errorf("invalid storage key of %d bytes", len(key))
+		}
+		tuple.StorageKeys = append(tuple.StorageKeys, common.BytesToHash(key))
+	}
+	return tuple, nil
+}
+
+// setUint64FromColumn sets target to the value at row j of a numeric column, unless it is null.
+func setUint64FromColumn(col arrow.Array, j int, target *uint64) error {
+	val, err := uint64FromColumn(col, j)
+	if err != nil || val == nil {
+		return err
+	}
+	*target = *val
+	return nil
+}
+
+// authorizationListFromColumn reads the EIP-7702 authorizations at row i, from a list of structs with chain_id,
+// address, nonce, y_parity, r and s fields or from a bincode encoded binary column. It returns nil when the
+// value is null.
+func authorizationListFromColumn(col arrow.Array, i int) (*[]Authorization, error) {
+	if col.IsNull(i) {
+		return nil, nil
+	}
+
+	if list, ok := col.(*array.List); ok {
+		fields, fErr := structFields(list.ListValues(), "chain_id", "address", "nonce", "y_parity", "r", "s")
+		if fErr != nil {
+			return nil, fErr
+		}
+
+		start, end := list.ValueOffsets(i)
+		authorizations := make([]Authorization, 0, end-start)
+		for j := int(start); j < int(end); j++ {
+			var authorization Authorization
+			if err := authorization.fromColumns(fields, j); err != nil {
+				return nil, errors.Wrapf(err, "invalid authorization %d", j-int(start))
+			}
+			authorizations = append(authorizations, authorization)
+		}
+		return &authorizations, nil
+	}
+
+	val, ok := binaryValue(col, i)
+	if !ok {
+		return nil, &ColumnTypeError{Expected: "authorization list", Type: col.DataType()}
+	}
+
+	r := &bincodeReader{buf: val}
+	length, err := r.length()
+	if err != nil {
+		return nil, err
+	}
+	authorizations := make([]Authorization, 0, length)
+	for j := 0; j < length; j++ {
+		var authorization Authorization
+		if aErr := authorization.fromBincode(r); aErr != nil {
+			return nil, errors.Wrapf(aErr, "invalid authorization %d", j)
+		}
+		authorizations = append(authorizations, authorization)
+	}
+	return &authorizations, r.done()
+}
+
+// fromColumns reads the authorization at row j of the chain_id, address, nonce, y_parity, r and s columns.
+func (a *Authorization) fromColumns(fields []arrow.Array, j int) error {
+	var err error
+	if a.ChainID, err = bigIntFromColumn(fields[0], j); err != nil {
+		return err
+	}
+	if !fields[1].IsNull(j) {
+		addr, ok := binaryValue(fields[1], j)
+		if !ok || len(addr) != common.AddressLength {
+			return errors.New("invalid authorization address")
+		}
+		a.Address = common.BytesToAddress(addr)
+	}
+	if err = setUint64FromColumn(fields[2], j, &a.Nonce); err != nil {
+		return err
+	}
+	var yParity uint64
+	if err = setUint64FromColumn(fields[3], j, &yParity); err != nil {
+		return err
+	}
+	if yParity > 1 {
+		return fmt.Errorf("invalid authorization y parity: %d", yParity)
+	}
+	a.YParity = uint8(yParity)
+	if a.R, err = bigIntFromColumn(fields[4], j); err != nil {
+		return err
+	}
+	a.S, err = bigIntFromColumn(fields[5], j)
+	return err
+}
+
+// fromBincode reads an authorization of chain id, address, nonce, y parity, r and s.
+func (a *Authorization) fromBincode(r *bincodeReader) error {
+	values := make([][]byte, 0, 6)
+	for k := 0; k < 6; k++ {
+		val, err := r.bytes()
+		if err != nil {
+			return err
+		}
+		values = append(values, val)
+	}
+
+	if len(values[1]) != common.AddressLength {
+		return fmt.Errorf("invalid authorization address of %d bytes", len(values[1]))
+	}
+	a.Address = common.BytesToAddress(values[1])
+
+	quantities := make([]*big.Int, 6)
+	for _, k := range []int{0, 2, 3, 4, 5} {
+		quantity, err := bigIntFromBytes(values[k])
+		if err != nil {
+			return err
+		}
+		quantities[k] = quantity
+	}
+
+	if !quantities[2].IsUint64() {
+		return fmt.Errorf("authorization nonce %s overflows uint64", quantities[2])
+	}
+	if quantities[3].Cmp(big.NewInt(1)) > 0 {
+		return fmt.Errorf("invalid authorization y parity: %s", quantities[3])
+	}
+
+	a.ChainID = quantities[0]
+	a.Nonce = quantities[2].Uint64()
+	a.YParity = uint8(quantities[3].Uint64())
+	a.R = quantities[4]
+	a.S = quantities[5]
+	return nil
+}
+
+// binaryValue returns the value at row i of a binary column of any width.
+func binaryValue(col arrow.Array, i int) ([]byte, bool) {
+	switch fCol := col.(type) {
+	case *array.Binary:
+		return fCol.Value(i), true
+	case *array.LargeBinary:
+		return fCol.Value(i), true
+	case *array.FixedSizeBinary:
+		return fCol.Value(i), true
+	default:
+		return nil, false
+	}
+}
+
+// structFields returns the named fields of a struct column, in the given order.
+func structFields(col arrow.Array, names ...string) ([]arrow.Array, error) {
+	structCol, ok := col.(*array.Struct)
+	if !ok {
+		return nil, fmt.Errorf("unsupported list element type: %s", col.DataType())
+	}
+	structType := structCol.DataType().(*arrow.StructType)
+
+	fields := make([]arrow.Array, 0, len(names))
+	for _, name := range names {
+		idx, found := structType.FieldIdx(name)
+		if !found {
+			return nil, fmt.Errorf("struct is missing field: %s", name)
+		}
+		fields = append(fields, structCol.Field(idx))
+	}
+	return fields, nil
+}
+
+// bincodeReader reads bincode encoded values.
+type bincodeReader struct {
+	buf []byte
+}
+
+// u64 reads a little-endian unsigned 64-bit integer.
+func (r *bincodeReader) u64() (uint64, error) {
+	if len(r.buf) < 8 {
+		return 0, errors.New("unexpected end of bincode data")
+	}
+	val := binary.LittleEndian.Uint64(r.buf)
+	r.buf = r.buf[8:]
+	return val, nil
+}
+
+// length reads a sequence length, bounded by the remaining data.
+func (r *bincodeReader) length() (int, error) {
+	length, err := r.u64()
+	if err != nil {
+		return 0, err
+	}
+	if length > uint64(len(r.buf)) {
+		return 0, fmt.Errorf("bincode length %d exceeds remaining %d bytes", length, len(r.buf))
+	}
+	return int(length), nil
+}
+
+// option reads the tag of an optional value and reports whether the value is present.
+func (r *bincodeReader) option() (bool, error) {
+	if len(r.buf) < 1 {
+		return false, errors.New("unexpected end of bincode data")
+	}
+	tag := r.buf[0]
+	r.buf = r.buf[1:]
+	switch tag {
+	case 0:
+		return false, nil
+	case 1:
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid bincode option tag: %d", tag)
+	}
+}
+
+// bytes reads length prefixed bytes, decoding them when they are a 0x-prefixed hex string.
+func (r *bincodeReader) bytes() ([]byte, error) {
+	length, err := r.length()
+	if err != nil {
+		return nil, err
+	}
+	val := r.buf[:length]
+	r.buf = r.buf[length:]
+
+	// Raw values that merely start with "0x" are kept as is, as they are not valid hex strings.
+	if len(val) >= 2 && val[0] == '0' && (val[1] == 'x' || val[1] == 'X') {
+		digits := string(val[2:])
+		if len(digits)%2 == 1 {
+			digits = "0" + digits
+		}
+		if decoded, hErr := hex.DecodeString(digits); hErr == nil {
+			return decoded, nil
+		}
+	}
+	return append([]byte{}, val...), nil
+}
+
+// optionalQuantity reads an optional big-endian quantity into target, which must fit in 64 bits.
+func (r *bincodeReader) optionalQuantity(target *uint64) error {
+	present, err := r.option()
+	if err != nil || !present {
+		return err
+	}
+	val, err := r.bytes()
+	if err != nil {
+		return err
+	}
+	quantity, err := bigIntFromBytes(val)
+	if err != nil {
+		return err
+	}
+	if !quantity.IsUint64() {
+		return fmt.Errorf("quantity %s overflows uint64", quantity)
+	}
+	*target = quantity.Uint64()
+	return nil
+}
+
+// done fails when data is left after the decoded value.
+func (r *bincodeReader) done() error {
+	if len(r.buf) > 0 {
+		return fmt.Errorf("%d trailing bytes after bincode value", len(r.buf))
+	}
+	return nil
+}
@@ -0,0 +1,419 @@
+package types
+
+import (
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	"github.com/apache/arrow/go/v10/arrow"
+	"github.com/apache/arrow/go/v10/arrow/array"
+	"github.com/apache/arrow/go/v10/arrow/memory"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+var testWithdrawals = []Withdrawal{
+	{Index: 41006127, ValidatorIndex: 1001871, Address: common.HexToAddress("0xb9d7934878b5fb9610b3fe8a5e441e8fad7e293f"), Amount: 19038285},
+	{Index: 41006128, ValidatorIndex: 1001872, Address: common.HexToAddress("0x4d6e1f4e0b1f0e3d3d3a9d4b6bc4d1f2e9f7c6a5"), Amount: 0},
+}
+
+var testAccessList = types.AccessList{
+	{Address: common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7"), StorageKeys: []common.Hash{common.HexToHash("0x01"), common.HexToHash("0x02")}},
+	{Address: common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"), StorageKeys: []common.Hash{}},
+}
+
+func TestWithdrawalsFromColumn(t *testing.T) {
+	tests := []struct {
+		name string
+		col  arrow.Array
+	}{
+		{name: "Bincode hex strings", col: newBinaryColumn(encodeBincodeWithdrawals(testWithdrawals, true))},
+		{name: "Bincode raw bytes", col: newBinaryColumn(encodeBincodeWithdrawals(testWithdrawals, false))},
+		{name: "Arrow list of structs", col: newWithdrawalsListColumn(testWithdrawals)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer tt.col.Release()
+
+			withdrawals, err := withdrawalsFromColumn(tt.col, 0)
+			require.NoError(t, err)
+			require.Equal(t, testWithdrawals, *withdrawals)
+		})
+	}
+
+	t.Run("Trailing bytes", func(t *testing.T) {
+		col := newBinaryColumn(append(encodeBincodeWithdrawals(testWithdrawals, true), 0x00))
+		defer col.Release()
+		_, err := withdrawalsFromColumn(col, 0)
+		require.EqualError(t, err, "1 trailing bytes after bincode value")
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		encoded := encodeBincodeWithdrawals(testWithdrawals, true)
+		col := newBinaryColumn(encoded[:len(encoded)-3])
+		defer col.Release()
+		_, err := withdrawalsFromColumn(col, 0)
+		require.Error(t, err)
+	})
+
+	t.Run("Null", func(t *testing.T) {
+		col := newBinaryColumn(nil)
+		defer col.Release()
+		withdrawals, err := withdrawalsFromColumn(col, 0)
+		require.NoError(t, err)
+		require.Nil(t, withdrawals)
+	})
+}
+
+func TestAccessListFromColumn(t *testing.T) {
+	tests := []struct {
+		name string
+		col  arrow.Array
+	}{
+		{name: "Bincode hex strings", col: newBinaryColumn(encodeBincodeAccessList(testAccessList, true))},
+		{name: "Bincode raw bytes", col: newBinaryColumn(encodeBincodeAccessList(testAccessList, false))},
+		{name: "Arrow list of structs", col: newAccessListColumn(testAccessList)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer tt.col.Release()
+
+			accessList, err := accessListFromColumn(tt.col, 0)
+			require.NoError(t, err)
+			require.Equal(t, testAccessList, *accessList)
+		})
+	}
+}
+
+func TestHashesFromColumn(t *testing.T) {
+	hashes := []common.Hash{common.HexToHash("0x01aa"), common.HexToHash("0x01bb"), common.HexToHash("0x01cc")}
+
+	concatenated := make([]byte, 0)
+	for _, hash := range hashes {
+		concatenated = append(concatenated, hash.Bytes()...)
+	}
+
+	binaryCol := newBinaryColumn(concatenated)
+	defer binaryCol.Release()
+	decoded, err := hashesFromColumn(binaryCol, 0)
+	require.NoError(t, err)
+	require.Equal(t, hashes, *decoded)
+
+	listCol := newHashListColumn(hashes)
+	defer listCol.Release()
+	decoded, err = hashesFromColumn(listCol, 0)
+	require.NoError(t, err)
+	require.Equal(t, hashes, *decoded)
+
+	empty := newBinaryColumn([]byte{})
+	defer empty.Release()
+	decoded, err = hashesFromColumn(empty, 0)
+	require.NoError(t, err)
+	require.Empty(t, *decoded)
+
+	invalid := newBinaryColumn(concatenated[:40])
+	defer invalid.Release()
+	_, err = hashesFromColumn(invalid, 0)
+	require.EqualError(t, err, "hash list of 40 bytes is not a multiple of 32")
+}
+
+func TestUint64sFromColumn(t *testing.T) {
+	traceAddress := []uint64{0, 3, 1}
+
+	encoded := binary.LittleEndian.AppendUint64(nil, uint64(len(traceAddress)))
+	for _, v := range traceAddress {
+		encoded = binary.LittleEndian.AppendUint64(encoded, v)
+	}
+
+	binaryCol := newBinaryColumn(encoded)
+	defer binaryCol.Release()
+	decoded, err := uint64sFromColumn(binaryCol, 0)
+	require.NoError(t, err)
+	require.Equal(t, traceAddress, *decoded)
+
+	builder := array.NewListBuilder(memory.NewGoAllocator(), arrow.PrimitiveTypes.Uint64)
+	defer builder.Release()
+	builder.Append(true)
+	for _, v := range traceAddress {
+		builder.ValueBuilder().(*array.Uint64Builder).Append(v)
+	}
+	listCol := builder.NewArray()
+	defer listCol.Release()
+	decoded, err = uint64sFromColumn(listCol, 0)
+	require.NoError(t, err)
+	require.Equal(t, traceAddress, *decoded)
+}
+
+func TestNestedRecordRoundTrip(t *testing.T) {
+	t.Run("Block withdrawals", func(t *testing.T) {
+		schema := arrow.NewSchema([]arrow.Field{{Name: "withdrawals", Type: arrow.BinaryTypes.Binary}}, nil)
+		record := array.NewRecord(schema, []arrow.Array{newBinaryColumn(encodeBincodeWithdrawals(testWithdrawals, true))}, 1)
+		defer record.Release()
+
+		block, err := NewBlockFromRecord(schema, record)
+		require.NoError(t, err)
+
+		expected := make(types.Withdrawals, 0, len(testWithdrawals))
+		for _, withdrawal := range testWithdrawals {
+			expected = append(expected, &types.Withdrawal{Index: withdrawal.Index, Validator: withdrawal.ValidatorIndex, Address: withdrawal.Address, Amount: withdrawal.Amount})
+		}
+
+		converted := make(types.Withdrawals, 0, len(*block.Withdrawals))
+		for _, withdrawal := range *block.Withdrawals {
+			converted = append(converted, withdrawal.ToGeth())
+		}
+		require.Equal(t, types.DeriveSha(expected, NewTrieHasher()), types.DeriveSha(converted, NewTrieHasher()))
+	})
+
+	t.Run("Transaction access list and blob hashes", func(t *testing.T) {
+		hashes := []common.Hash{common.HexToHash("0x01aa"), common.HexToHash("0x01bb")}
+
+		schema := arrow.NewSchema([]arrow.Field{
+			{Name: "access_list", Type: arrow.BinaryTypes.Binary},
+			{Name: "blob_versioned_hashes", Type: arrow.BinaryTypes.Binary},
+		}, nil)
+		record := array.NewRecord(schema, []arrow.Array{
+			newBinaryColumn(encodeBincodeAccessList(testAccessList, true)),
+			newBinaryColumn(append(hashes[0].Bytes(), hashes[1].Bytes()...)),
+		}, 1)
+		defer record.Release()
+
+		tx, err := NewTransactionFromRecord(schema, record)
+		require.NoError(t, err)
+		require.Equal(t, testAccessList, *tx.AccessList)
+		require.Equal(t, hashes, *tx.BlobVersionedHashes)
+	})
+
+	t.Run("Trace code and output", func(t *testing.T) {
+		code := hexutil.MustDecode("0x608060405234801561001057600080fd5b50610150806100206000396000f3fe6080604052")
+		output := hexutil.MustDecode("0x0000000000000000000000000000000000000000000000000000000000000001ff")
+
+		schema := arrow.NewSchema([]arrow.Field{
+			{Name: "init", Type: arrow.BinaryTypes.Binary},
+			{Name: "code", Type: arrow.BinaryTypes.Binary},
+			{Name: "output", Type: arrow.BinaryTypes.Binary},
+			{Name: "value", Type: arrow.BinaryTypes.Binary},
+		}, nil)
+		record := array.NewRecord(schema, []arrow.Array{
+			newBinaryColumn(code),
+			newBinaryColumn(code),
+			newBinaryColumn(output),
+			newBinaryColumn(big.NewInt(1).Bytes()),
+		}, 1)
+		defer record.Release()
+
+		trace, err := NewTraceFromRecord(schema, record)
+		require.NoError(t, err)
+		require.Equal(t, code, *trace.Init)
+		require.Equal(t, code, *trace.Code)
+		require.Equal(t, output, *trace.Output)
+	})
+}
+
+// bincodeWriter encodes values the way HyperSync encodes nested binary columns.
+type bincodeWriter struct {
+	buf []byte
+	hex bool // Encodes byte values as 0x-prefixed hex strings rather than raw bytes.
+}
+
+func (w *bincodeWriter) u64(v uint64) {
+	w.buf = binary.LittleEndian.AppendUint64(w.buf, v)
+}
+
+func (w *bincodeWriter) some() {
+	w.buf = append(w.buf, 1)
+}
+
+func (w *bincodeWriter) bytes(v []byte) {
+	if w.hex {
+		v = []byte(hexutil.Encode(v))
+	}
+	w.u64(uint64(len(v)))
+	w.buf = append(w.buf, v...)
+}
+
+func (w *bincodeWriter) quantity(v uint64) {
+	if w.hex {
+		encoded := []byte(hexutil.EncodeUint64(v))
+		w.u64(uint64(len(encoded)))
+		w.buf = append(w.buf, encoded...)
+		return
+	}
+	w.bytes(new(big.Int).SetUint64(v).Bytes())
+}
+
+func encodeBincodeWithdrawals(withdrawals []Withdrawal, hex bool) []byte {
+	w := &bincodeWriter{hex: hex}
+	w.u64(uint64(len(withdrawals)))
+	for _, withdrawal := range withdrawals {
+		w.some()
+		w.quantity(withdrawal.Index)
+		w.some()
+		w.quantity(withdrawal.ValidatorIndex)
+		w.some()
+		w.bytes(withdrawal.Address.Bytes())
+		w.some()
+		w.quantity(withdrawal.Amount)
+	}
+	return w.buf
+}
+
+func encodeBincodeAccessList(accessList types.AccessList, hex bool) []byte {
+	w := &bincodeWriter{hex: hex}
+	w.u64(uint64(len(accessList)))
+	for _, tuple := range accessList {
+		w.some()
+		w.bytes(tuple.Address.Bytes())
+		w.some()
+		w.u64(uint64(len(tuple.StorageKeys)))
+		for _, key := range tuple.StorageKeys {
+			w.bytes(key.Bytes())
+		}
+	}
+	return w.buf
+}
+
+func newWithdrawalsListColumn(withdrawals []Withdrawal) arrow.Array {
+	structType := arrow.StructOf(
+		arrow.Field{Name: "index", Type: arrow.PrimitiveTypes.Uint64},
+		arrow.Field{Name: "validator_index", Type: arrow.PrimitiveTypes.Uint64},
+		arrow.Field{Name: "address", Type: addrDT()},
+		arrow.Field{Name: "amount", Type: arrow.BinaryTypes.Binary},
+	)
+
+	builder := array.NewListBuilder(memory.NewGoAllocator(), structType)
+	defer builder.Release()
+	builder.Append(true)
+
+	values := builder.ValueBuilder().(*array.StructBuilder)
+	for _, withdrawal := range withdrawals {
+		values.Append(true)
+		values.FieldBuilder(0).(*array.Uint64Builder).Append(withdrawal.Index)
+		values.FieldBuilder(1).(*array.Uint64Builder).Append(withdrawal.ValidatorIndex)
+		values.FieldBuilder(2).(*array.FixedSizeBinaryBuilder).Append(withdrawal.Address.Bytes())
+		values.FieldBuilder(3).(*array.BinaryBuilder).Append(new(big.Int).SetUint64(withdrawal.Amount).Bytes())
+	}
+	return builder.NewArray()
+}
+
+func newAccessListColumn(accessList types.AccessList) arrow.Array {
+	structType := arrow.StructOf(
+		arrow.Field{Name: "address", Type: addrDT()},
+		arrow.Field{Name: "storage_keys", Type: arrow.ListOf(hashDT())},
+	)
+
+	builder := array.NewListBuilder(memory.NewGoAllocator(), structType)
+	defer builder.Release()
+	builder.Append(true)
+
+	values := builder.ValueBuilder().(*array.StructBuilder)
+	for _, tuple := range accessList {
+		values.Append(true)
+		values.FieldBuilder(0).(*array.FixedSizeBinaryBuilder).Append(tuple.Address.Bytes())
+		keys := values.FieldBuilder(1).(*array.ListBuilder)
+		keys.Append(true)
+		for _, key := range tuple.StorageKeys {
+			keys.ValueBuilder().(*array.FixedSizeBinaryBuilder).Append(key.Bytes())
+		}
+	}
+	return builder.NewArray()
+}
+
+func newHashListColumn(hashes []common.Hash) arrow.Array {
+	builder := array.NewListBuilder(memory.NewGoAllocator(), hashDT())
+	defer builder.Release()
+	builder.Append(true)
+	for _, hash := range hashes {
+		builder.ValueBuilder().(*array.FixedSizeBinaryBuilder).Append(hash.Bytes())
+	}
+	return builder.NewArray()
+}
+
+var testAuthorizations = []Authorization{
+	{ChainID: big.NewInt(1), Address: common.HexToAddress("0x63c0c19a282a1B52b07dD5a65b58948A07DAE32B"), Nonce: 7, YParity: 1, R: big.NewInt(0x1234), S: big.NewInt(0x5678)},
+	{ChainID: big.NewInt(0), Address: common.HexToAddress("0x000000009B1D0aF20D8C6d0A44e162d11F9b8f00"), Nonce: 0, YParity: 0, R: big.NewInt(1), S: big.NewInt(2)},
+}
+
+func TestAuthorizationListFromColumn(t *testing.T) {
+	tests := []struct {
+		name string
+		col  arrow.Array
+	}{
+		{name: "Bincode hex strings", col: newBinaryColumn(encodeBincodeAuthorizations(testAuthorizations, true))},
+		{name: "Bincode raw bytes", col: newBinaryColumn(encodeBincodeAuthorizations(testAuthorizations, false))},
+		{name: "Arrow list of structs", col: newAuthorizationListColumn(testAuthorizations)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer tt.col.Release()
+
+			authorizations, err := authorizationListFromColumn(tt.col, 0)
+			require.NoError(t, err)
+			require.Len(t, *authorizations, len(testAuthorizations))
+			for i, expected := range testAuthorizations {
+				actual := (*authorizations)[i]
+				require.Equal(t, expected.Address, actual.Address)
+				require.Equal(t, expected.Nonce, actual.Nonce)
+				require.Equal(t, expected.YParity, actual.YParity)
+				require.Zero(t, expected.ChainID.Cmp(actual.ChainID))
+				require.Zero(t, expected.R.Cmp(actual.R))
+				require.Zero(t, expected.S.Cmp(actual.S))
+			}
+		})
+	}
+
+	t.Run("Invalid y parity", func(t *testing.T) {
+		invalid := []Authorization{testAuthorizations[0]}
+		invalid[0].YParity = 2
+		col := newBinaryColumn(encodeBincodeAuthorizations(invalid, true))
+		defer col.Release()
+		_, err := authorizationListFromColumn(col, 0)
+		require.EqualError(t, err, "invalid authorization 0: invalid authorization y parity: 2")
+	})
+}
+
+func encodeBincodeAuthorizations(authorizations []Authorization, hex bool) []byte {
+	w := &bincodeWriter{hex: hex}
+	w.u64(uint64(len(authorizations)))
+	for _, authorization := range authorizations {
+		w.bytes(authorization.ChainID.Bytes())
+		w.bytes(authorization.Address.Bytes())
+		w.quantity(authorization.Nonce)
+		w.quantity(uint64(authorization.YParity))
+		w.bytes(authorization.R.Bytes())
+		w.bytes(authorization.S.Bytes())
+	}
+	return w.buf
+}
+
+func newAuthorizationListColumn(authorizations []Authorization) arrow.Array {
+	structType := arrow.StructOf(
+		arrow.Field{Name: "chain_id", Type: arrow.BinaryTypes.Binary},
+		arrow.Field{Name: "address", Type: addrDT()},
+		arrow.Field{Name: "nonce", Type: arrow.PrimitiveTypes.Uint64},
+		arrow.Field{Name: "y_parity", Type: arrow.PrimitiveTypes.Uint8},
+		arrow.Field{Name: "r", Type: arrow.BinaryTypes.Binary},
+		arrow.Field{Name: "s", Type: arrow.BinaryTypes.Binary},
+	)
+
+	builder := array.NewListBuilder(memory.NewGoAllocator(), structType)
+	defer builder.Release()
+	builder.Append(true)
+
+	values := builder.ValueBuilder().(*array.StructBuilder)
+	for _, authorization := range authorizations {
+		values.Append(true)
+		values.FieldBuilder(0).(*array.BinaryBuilder).Append(authorization.ChainID.Bytes())
+		values.FieldBuilder(1).(*array.FixedSizeBinaryBuilder).Append(authorization.Address.Bytes())
+		values.FieldBuilder(2).(*array.Uint64Builder).Append(authorization.Nonce)
+		values.FieldBuilder(3).(*array.Uint8Builder).Append(authorization.YParity)
+		values.FieldBuilder(4).(*array.BinaryBuilder).Append(authorization.R.Bytes())
+		values.FieldBuilder(5).(*array.BinaryBuilder).Append(authorization.S.Bytes())
+	}
+	return builder.NewArray()
+}
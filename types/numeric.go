@@ -0,0 +1,96 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/apache/arrow/go/v10/arrow"
+	"github.com/apache/arrow/go/v10/arrow/array"
+)
+
+// maxQuantityBytes is the size of the largest quantity HyperSync returns, a 256-bit unsigned integer.
+const maxQuantityBytes = 32
+
+// bigIntFromColumn reads the value at row i of a numeric column. HyperSync encodes quantities either as
+// big-endian binary or as unsigned integers, so the encoding is picked from the column type. It returns nil
+// when the value is null.
+func bigIntFromColumn(col arrow.Array, i int) (*big.Int, error) {
+	if col.IsNull(i) {
+		return nil, nil
+	}
+
+	switch fCol := col.(type) {
+	case *array.Binary:
+		return bigIntFromBytes(fCol.Value(i))
+	case *array.LargeBinary:
+		return bigIntFromBytes(fCol.Value(i))
+	case *array.FixedSizeBinary:
+		return bigIntFromBytes(fCol.Value(i))
+	case *array.Uint8:
+		return new(big.Int).SetUint64(uint64(fCol.Value(i))), nil
+	case *array.Uint16:
+		return new(big.Int).SetUint64(uint64(fCol.Value(i))), nil
+	case *array.Uint32:
+		return new(big.Int).SetUint64(uint64(fCol.Value(i))), nil
+	case *array.Uint64:
+		return new(big.Int).SetUint64(fCol.Value(i)), nil
+	case *array.Int8:
+		return bigIntFromInt64(int64(fCol.Value(i)))
+	case *array.Int16:
+		return bigIntFromInt64(int64(fCol.Value(i)))
+	case *array.Int32:
+		return bigIntFromInt64(int64(fCol.Value(i)))
+	case *array.Int64:
+		return bigIntFromInt64(fCol.Value(i))
+	default:
+		return nil, &ColumnTypeError{Expected: "quantity", Type: col.DataType()}
+	}
+}
+
+// uint64FromColumn reads the value at row i of a numeric column into a uint64, failing when it does not fit.
+// It returns nil when the value is null.
+func uint64FromColumn(col arrow.Array, i int) (*uint64, error) {
+	val, err := bigIntFromColumn(col, i)
+	if err != nil || val == nil {
+		return nil, err
+	}
+	if !val.IsUint64() {
+		return nil, fmt.Errorf("quantity %s overflows uint64", val)
+	}
+	toReturn := val.Uint64()
+	return &toReturn, nil
+}
+
+// uint8FromColumn reads the value at row i of a numeric column into a uint8, failing when it does not fit.
+// It returns nil when the value is null.
+func uint8FromColumn(col arrow.Array, i int) (*uint8, error) {
+	val, err := uint64FromColumn(col, i)
+	if err != nil || val == nil {
+		return nil, err
+	}
+	if *val > math.MaxUint8 {
+		return nil, fmt.Errorf("quantity %d overflows uint8", *val)
+	}
+	toReturn := uint8(*val)
+	return &toReturn, nil
+}
+
+// bigIntFromBytes decodes a big-endian unsigned integer of at most 256 bits.
+func bigIntFromBytes(val []byte) (*big.Int, error) {
+	for len(val) > 0 && val[0] == 0 {
+		val = val[1:]
+	}
+	if len(val) > maxQuantityBytes {
+		return nil, fmt.Errorf("quantity of %d bytes overflows 256 bits", len(val))
+	}
+	return new(big.Int).SetBytes(val), nil
+}
+
+// bigIntFromInt64 converts a signed integer column value, which must not be negative for a quantity.
+func bigIntFromInt64(val int64) (*big.Int, error) {
+	if val < 0 {
+		return nil, fmt.Errorf("negative quantity: %d", val)
+	}
+	return big.NewInt(val), nil
+}
@@ -0,0 +1,165 @@
+package types
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/apache/arrow/go/v10/arrow"
+	"github.com/apache/arrow/go/v10/arrow/array"
+	"github.com/apache/arrow/go/v10/arrow/memory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBigIntFromColumn(t *testing.T) {
+	pow := func(exp uint) *big.Int {
+		return new(big.Int).Lsh(big.NewInt(1), exp)
+	}
+	minus := func(v *big.Int, d int64) *big.Int {
+		return new(big.Int).Sub(v, big.NewInt(d))
+	}
+
+	tests := []struct {
+		name      string
+		col       arrow.Array
+		expected  *big.Int
+		expectErr string
+	}{
+		{name: "Binary max int64", col: newBinaryColumn(minus(pow(63), 1).Bytes()), expected: minus(pow(63), 1)},
+		{name: "Binary above int64", col: newBinaryColumn(pow(63).Bytes()), expected: pow(63)},
+		{name: "Binary max uint64", col: newBinaryColumn(minus(pow(64), 1).Bytes()), expected: minus(pow(64), 1)},
+		{name: "Binary above uint64", col: newBinaryColumn(pow(64).Bytes()), expected: pow(64)},
+		{name: "Binary max uint256", col: newBinaryColumn(minus(pow(256), 1).Bytes()), expected: minus(pow(256), 1)},
+		{name: "Binary leading zeros", col: newBinaryColumn(append(make([]byte, 8), 0x01, 0x00)), expected: big.NewInt(256)},
+		{name: "Binary empty", col: newBinaryColumn([]byte{}), expected: big.NewInt(0)},
+		{name: "Binary above uint256", col: newBinaryColumn(pow(256).Bytes()), expectErr: "quantity of 33 bytes overflows 256 bits"},
+		{name: "Uint64 max", col: newUint64Column(math.MaxUint64), expected: minus(pow(64), 1)},
+		{name: "Int64 max", col: newInt64Column(math.MaxInt64), expected: minus(pow(63), 1)},
+		{name: "Int64 negative", col: newInt64Column(-1), expectErr: "negative quantity: -1"},
+		{name: "Null", col: newBinaryColumn(nil), expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer tt.col.Release()
+
+			val, err := bigIntFromColumn(tt.col, 0)
+			if tt.expectErr != "" {
+				require.EqualError(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, val)
+		})
+	}
+}
+
+func TestUint64FromColumn(t *testing.T) {
+	maxUint64 := new(big.Int).SetUint64(math.MaxUint64)
+
+	col := newBinaryColumn(maxUint64.Bytes())
+	defer col.Release()
+	val, err := uint64FromColumn(col, 0)
+	require.NoError(t, err)
+	require.Equal(t, uint64(math.MaxUint64), *val)
+
+	overflow := newBinaryColumn(new(big.Int).Add(maxUint64, big.NewInt(1)).Bytes())
+	defer overflow.Release()
+	_, err = uint64FromColumn(overflow, 0)
+	require.EqualError(t, err, "quantity 18446744073709551616 overflows uint64")
+
+	null := newBinaryColumn(nil)
+	defer null.Release()
+	val, err = uint64FromColumn(null, 0)
+	require.NoError(t, err)
+	require.Nil(t, val)
+
+	unsupported := array.NewNull(1)
+	defer unsupported.Release()
+	_, err = uint64FromColumn(unsupported, 0)
+	require.Error(t, err)
+}
+
+func TestNewTransactionFromRecordQuantities(t *testing.T) {
+	value, _ := new(big.Int).SetString("1000000000000000000000000", 10) // 10^24 wei, above 2^64.
+	gasPrice := new(big.Int).SetUint64(math.MaxUint64)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "value", Type: arrow.BinaryTypes.Binary},
+		{Name: "gas_price", Type: arrow.PrimitiveTypes.Uint64},
+		{Name: "gas", Type: arrow.BinaryTypes.Binary},
+		{Name: "max_fee_per_gas", Type: arrow.BinaryTypes.Binary, Nullable: true},
+	}, nil)
+
+	columns := []arrow.Array{
+		newBinaryColumn(value.Bytes()),
+		newUint64Column(gasPrice.Uint64()),
+		newBinaryColumn([]byte{0x52, 0x08}),
+		newBinaryColumn(nil),
+	}
+	record := array.NewRecord(schema, columns, 1)
+	defer record.Release()
+	for _, col := range columns {
+		col.Release()
+	}
+
+	tx, err := NewTransactionFromRecord(schema, record)
+	require.NoError(t, err)
+	require.Equal(t, value, tx.Value)
+	require.Equal(t, gasPrice, tx.GasPrice)
+	require.Equal(t, uint64(21000), *tx.Gas)
+	require.Nil(t, tx.MaxFeePerGas)
+
+	overflowSchema := arrow.NewSchema([]arrow.Field{{Name: "gas", Type: arrow.BinaryTypes.Binary}}, nil)
+	overflowRecord := array.NewRecord(overflowSchema, []arrow.Array{newBinaryColumn(value.Bytes())}, 1)
+	defer overflowRecord.Release()
+
+	_, err = NewTransactionFromRecord(overflowSchema, overflowRecord)
+	require.ErrorContains(t, err, "failed to decode field: gas")
+}
+
+func TestNewBlockFromRecordTimestamp(t *testing.T) {
+	// 2^32 + 1 seconds: the first 4 bytes alone would decode to 1.
+	timestamp := uint64(1)<<32 + 1
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "timestamp", Type: arrow.BinaryTypes.Binary},
+		{Name: "difficulty", Type: arrow.BinaryTypes.Binary},
+	}, nil)
+	record := array.NewRecord(schema, []arrow.Array{
+		newBinaryColumn(new(big.Int).SetUint64(timestamp).Bytes()),
+		newBinaryColumn([]byte{0x03, 0xff, 0xf8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}),
+	}, 1)
+	defer record.Release()
+
+	block, err := NewBlockFromRecord(schema, record)
+	require.NoError(t, err)
+	require.Equal(t, int64(timestamp), block.Timestamp.Unix())
+	require.Equal(t, "0x3fff8000000000000", "0x"+block.Difficulty.Text(16))
+}
+
+// newBinaryColumn returns a single row binary column, null when val is nil.
+func newBinaryColumn(val []byte) arrow.Array {
+	builder := array.NewBinaryBuilder(memory.NewGoAllocator(), arrow.BinaryTypes.Binary)
+	defer builder.Release()
+	if val == nil {
+		builder.AppendNull()
+	} else {
+		builder.Append(val)
+	}
+	return builder.NewArray()
+}
+
+func newUint64Column(val uint64) arrow.Array {
+	builder := array.NewUint64Builder(memory.NewGoAllocator())
+	defer builder.Release()
+	builder.Append(val)
+	return builder.NewArray()
+}
+
+func newInt64Column(val int64) arrow.Array {
+	builder := array.NewInt64Builder(memory.NewGoAllocator())
+	defer builder.Release()
+	builder.Append(val)
+	return builder.NewArray()
+}
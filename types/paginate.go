@@ -0,0 +1,79 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// PageFn fetches a single page of a query, such as the GetArrow method of the client.
+type PageFn func(ctx context.Context, query *Query) (*QueryResponse, error)
+
+// PageHandler handles a page of a paginated query. It returns true to stop the pagination.
+type PageHandler func(response *QueryResponse) (bool, error)
+
+// Paginate fetches the pages of the query one after the other, starting each page at the next block of the
+// previous one, and passes them to handle. It stops when handle returns true, the to block of the query is
+// reached or the archive height is reached, and returns the next block to fetch from then on. The query
+// itself is not modified.
+//
+// Example:
+//
+//	logs := make([]types.Log, 0)
+//	_, err := types.Paginate(ctx, query, client.GetArrow, func(response *types.QueryResponse) (bool, error) {
+//	    logs = append(logs, response.Data.Logs...)
+//	    return false, nil
+//	})
+func Paginate(ctx context.Context, query *Query, fetch PageFn, handle PageHandler) (*big.Int, error) {
+	page := *query
+	for {
+		response, err := fetch(ctx, &page)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to query from block: %s", page.FromBlock)
+		}
+
+		done, hErr := handle(response)
+		if hErr != nil {
+			return nil, hErr
+		}
+
+		switch {
+		case !response.HasNextBlock() || (page.ToBlock != nil && response.NextBlock.Cmp(page.ToBlock) >= 0):
+			return page.ToBlock, nil
+		case done:
+			return response.NextBlock, nil
+		case page.FromBlock != nil && response.NextBlock.Cmp(page.FromBlock) <= 0:
+			return nil, fmt.Errorf("query made no progress at block: %s", page.FromBlock)
+		case response.ArchiveHeight != nil && response.NextBlock.Cmp(response.ArchiveHeight) >= 0:
+			return response.NextBlock, nil
+		}
+		page.FromBlock = response.NextBlock
+	}
+}
+
+// SortTransactionsByIndex sorts the transactions of a block by transaction index. Transactions without an
+// index come first, in their original order.
+func SortTransactionsByIndex(transactions []Transaction) {
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return indexOf(transactions[i].TransactionIndex) < indexOf(transactions[j].TransactionIndex)
+	})
+}
+
+// SortLogsByIndex sorts the logs of a block by log index. Logs without an index come first, in their
+// original order.
+func SortLogsByIndex(logs []Log) {
+	sort.SliceStable(logs, func(i, j int) bool {
+		return indexOf(logs[i].LogIndex) < indexOf(logs[j].LogIndex)
+	})
+}
+
+// indexOf returns the index, or 0 when it is not set.
+func indexOf(index *uint64) uint64 {
+	if index == nil {
+		return 0
+	}
+	return *index
+}
@@ -0,0 +1,96 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newPageFn serves pages of pageSize blocks up to the archive height and records the requested from blocks.
+func newPageFn(pageSize int64, height int64, froms *[]int64) PageFn {
+	return func(ctx context.Context, query *Query) (*QueryResponse, error) {
+		*froms = append(*froms, query.FromBlock.Int64())
+		next := min(query.FromBlock.Int64()+pageSize, query.ToBlock.Int64(), height)
+		return &QueryResponse{NextBlock: big.NewInt(next), ArchiveHeight: big.NewInt(height)}, nil
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	ctx := context.Background()
+	all := func(response *QueryResponse) (bool, error) {
+		return false, nil
+	}
+
+	t.Run("Up to the to block", func(t *testing.T) {
+		froms := make([]int64, 0)
+		query := &Query{FromBlock: big.NewInt(10), ToBlock: big.NewInt(35)}
+		next, err := Paginate(ctx, query, newPageFn(10, 100, &froms), all)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(35), next)
+		require.Equal(t, []int64{10, 20, 30}, froms)
+		require.Equal(t, big.NewInt(10), query.FromBlock)
+	})
+
+	t.Run("Up to the archive height", func(t *testing.T) {
+		froms := make([]int64, 0)
+		query := &Query{FromBlock: big.NewInt(10), ToBlock: big.NewInt(100)}
+		next, err := Paginate(ctx, query, newPageFn(10, 25, &froms), all)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(25), next)
+		require.Equal(t, []int64{10, 20}, froms)
+	})
+
+	t.Run("Stopped by the handler", func(t *testing.T) {
+		froms := make([]int64, 0)
+		query := &Query{FromBlock: big.NewInt(10), ToBlock: big.NewInt(100)}
+		next, err := Paginate(ctx, query, newPageFn(10, 100, &froms), func(response *QueryResponse) (bool, error) {
+			return response.NextBlock.Int64() >= 30, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(30), next)
+		require.Equal(t, []int64{10, 20}, froms)
+	})
+
+	t.Run("Handler error", func(t *testing.T) {
+		froms := make([]int64, 0)
+		query := &Query{FromBlock: big.NewInt(10), ToBlock: big.NewInt(100)}
+		_, err := Paginate(ctx, query, newPageFn(10, 100, &froms), func(response *QueryResponse) (bool, error) {
+			return false, errors.New("bad page")
+		})
+		require.EqualError(t, err, "bad page")
+	})
+
+	t.Run("Fetch error", func(t *testing.T) {
+		failing := func(ctx context.Context, query *Query) (*QueryResponse, error) {
+			return nil, errors.New("unavailable")
+		}
+		query := &Query{FromBlock: big.NewInt(10), ToBlock: big.NewInt(100)}
+		_, err := Paginate(ctx, query, failing, all)
+		require.EqualError(t, err, "failed to query from block: 10: unavailable")
+	})
+
+	t.Run("No progress", func(t *testing.T) {
+		stalled := func(ctx context.Context, query *Query) (*QueryResponse, error) {
+			return &QueryResponse{NextBlock: big.NewInt(10)}, nil
+		}
+		query := &Query{FromBlock: big.NewInt(10), ToBlock: big.NewInt(100)}
+		_, err := Paginate(ctx, query, stalled, all)
+		require.EqualError(t, err, "query made no progress at block: 10")
+	})
+}
+
+func TestSortByIndex(t *testing.T) {
+	index := func(i uint64) *uint64 { return &i }
+
+	transactions := []Transaction{{TransactionIndex: index(2)}, {TransactionIndex: index(0)}, {TransactionIndex: index(1)}}
+	SortTransactionsByIndex(transactions)
+	require.Equal(t, []uint64{0, 1, 2}, []uint64{*transactions[0].TransactionIndex, *transactions[1].TransactionIndex, *transactions[2].TransactionIndex})
+
+	logs := []Log{{LogIndex: index(5)}, {}, {LogIndex: index(3)}}
+	SortLogsByIndex(logs)
+	require.Nil(t, logs[0].LogIndex)
+	require.Equal(t, []uint64{3, 5}, []uint64{*logs[1].LogIndex, *logs[2].LogIndex})
+}
@@ -34,6 +34,8 @@ type QueryResponse struct {
 	Data DataResponse `json:"data"`
 	// Rollback guard
 	RollbackGuard *RollbackGuard `json:"rollback_guard"`
+	// Warnings recorded while decoding the response leniently, nil when there were none.
+	DecodeReport *DecodeReport `json:"decode_report,omitempty"`
 }
 
 func (qr *QueryResponse) GetData() DataResponse {
@@ -84,6 +86,10 @@ func (qr *QueryResponse) GetRollbackGuard() *RollbackGuard {
 	return qr.RollbackGuard
 }
 
+func (qr *QueryResponse) SetDecodeReport(report *DecodeReport) {
+	qr.DecodeReport = report
+}
+
 func (qr *QueryResponse) GetBlocks() []Block {
 	return qr.Data.Blocks
 }
@@ -0,0 +1,754 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// The RPC types mirror the objects returned by the Ethereum JSON-RPC API: camelCase keys, hex encoded
+// quantities and data. Fields that were not selected in the query are omitted, and fields HyperSync returns
+// that have no JSON-RPC counterpart, such as the sighash, are dropped.
+
+// RPCWithdrawal is the JSON-RPC representation of a withdrawal.
+type RPCWithdrawal struct {
+	Index          hexutil.Uint64 `json:"index"`
+	ValidatorIndex hexutil.Uint64 `json:"validatorIndex"`
+	Address        common.Address `json:"address"`
+	Amount         hexutil.Uint64 `json:"amount"`
+}
+
+// RPCBlock is the JSON-RPC representation of a block, as returned by eth_getBlockByNumber.
+type RPCBlock struct {
+	Number                *hexutil.Big      `json:"number,omitempty"`
+	Hash                  *common.Hash      `json:"hash,omitempty"`
+	ParentHash            *common.Hash      `json:"parentHash,omitempty"`
+	Nonce                 *types.BlockNonce `json:"nonce,omitempty"`
+	Sha3Uncles            *common.Hash      `json:"sha3Uncles,omitempty"`
+	LogsBloom             *types.Bloom      `json:"logsBloom,omitempty"`
+	TransactionsRoot      *common.Hash      `json:"transactionsRoot,omitempty"`
+	StateRoot             *common.Hash      `json:"stateRoot,omitempty"`
+	ReceiptsRoot          *common.Hash      `json:"receiptsRoot,omitempty"`
+	Miner                 *common.Address   `json:"miner,omitempty"`
+	Difficulty            *hexutil.Big      `json:"difficulty,omitempty"`
+	TotalDifficulty       *hexutil.Big      `json:"totalDifficulty,omitempty"`
+	ExtraData             *hexutil.Bytes    `json:"extraData,omitempty"`
+	Size                  *hexutil.Uint64   `json:"size,omitempty"`
+	GasLimit              *hexutil.Uint64   `json:"gasLimit,omitempty"`
+	GasUsed               *hexutil.Uint64   `json:"gasUsed,omitempty"`
+	Timestamp             *hexutil.Uint64   `json:"timestamp,omitempty"`
+	Uncles                *[]common.Hash    `json:"uncles,omitempty"`
+	BaseFeePerGas         *hexutil.Big      `json:"baseFeePerGas,omitempty"`
+	BlobGasUsed           *hexutil.Uint64   `json:"blobGasUsed,omitempty"`
+	ExcessBlobGas         *hexutil.Uint64   `json:"excessBlobGas,omitempty"`
+	ParentBeaconBlockRoot *common.Hash      `json:"parentBeaconBlockRoot,omitempty"`
+	WithdrawalsRoot       *common.Hash      `json:"withdrawalsRoot,omitempty"`
+	Withdrawals           *[]RPCWithdrawal  `json:"withdrawals,omitempty"`
+	RequestsHash          *common.Hash      `json:"requestsHash,omitempty"`
+	L1BlockNumber         *hexutil.Big      `json:"l1BlockNumber,omitempty"`
+	SendCount             *hexutil.Big      `json:"sendCount,omitempty"`
+	SendRoot              *common.Hash      `json:"sendRoot,omitempty"`
+	MixHash               *common.Hash      `json:"mixHash,omitempty"`
+	Transactions          *RPCTransactions  `json:"transactions,omitempty"`
+}
+
+// RPCTransactions holds the transactions of an RPC block, either as hashes or as full objects.
+type RPCTransactions struct {
+	// Hashes are the transaction hashes, used when Full is nil.
+	Hashes []common.Hash
+	// Full are the full transaction objects.
+	Full []*RPCTransaction
+}
+
+// MarshalJSON encodes the full transactions when set, the hashes otherwise.
+func (t RPCTransactions) MarshalJSON() ([]byte, error) {
+	if t.Full != nil {
+		return json.Marshal(t.Full)
+	}
+	if t.Hashes == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(t.Hashes)
+}
+
+// UnmarshalJSON decodes either a list of transaction hashes or a list of transaction objects.
+func (t *RPCTransactions) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	if len(raw) > 0 && bytes.HasPrefix(bytes.TrimSpace(raw[0]), []byte("{")) {
+		t.Full = make([]*RPCTransaction, 0, len(raw))
+		return json.Unmarshal(data, &t.Full)
+	}
+
+	t.Hashes = make([]common.Hash, 0, len(raw))
+	return json.Unmarshal(data, &t.Hashes)
+}
+
+// RPCAuthorization is the JSON-RPC representation of an EIP-7702 authorization.
+type RPCAuthorization struct {
+	ChainID *hexutil.Big   `json:"chainId"`
+	Address common.Address `json:"address"`
+	Nonce   hexutil.Uint64 `json:"nonce"`
+	YParity hexutil.Uint64 `json:"yParity"`
+	R       *hexutil.Big   `json:"r"`
+	S       *hexutil.Big   `json:"s"`
+}
+
+// RPCTransaction is the JSON-RPC representation of a transaction, as returned by eth_getTransactionByHash,
+// extended with the receipt fields of eth_getTransactionReceipt that HyperSync returns with transactions.
+type RPCTransaction struct {
+	BlockHash             *common.Hash        `json:"blockHash,omitempty"`
+	BlockNumber           *hexutil.Big        `json:"blockNumber,omitempty"`
+	From                  *common.Address     `json:"from,omitempty"`
+	Gas                   *hexutil.Uint64     `json:"gas,omitempty"`
+	GasPrice              *hexutil.Big        `json:"gasPrice,omitempty"`
+	Hash                  *common.Hash        `json:"hash,omitempty"`
+	Input                 *hexutil.Bytes      `json:"input,omitempty"`
+	Nonce                 *hexutil.Uint64     `json:"nonce,omitempty"`
+	To                    *common.Address     `json:"to,omitempty"`
+	TransactionIndex      *hexutil.Uint64     `json:"transactionIndex,omitempty"`
+	Value                 *hexutil.Big        `json:"value,omitempty"`
+	V                     *hexutil.Big        `json:"v,omitempty"`
+	R                     *hexutil.Big        `json:"r,omitempty"`
+	S                     *hexutil.Big        `json:"s,omitempty"`
+	YParity               *hexutil.Big        `json:"yParity,omitempty"`
+	MaxPriorityFeePerGas  *hexutil.Big        `json:"maxPriorityFeePerGas,omitempty"`
+	MaxFeePerGas          *hexutil.Big        `json:"maxFeePerGas,omitempty"`
+	ChainID               *hexutil.Big        `json:"chainId,omitempty"`
+	AccessList            *types.AccessList   `json:"accessList,omitempty"`
+	MaxFeePerBlobGas      *hexutil.Big        `json:"maxFeePerBlobGas,omitempty"`
+	BlobVersionedHashes   *[]common.Hash      `json:"blobVersionedHashes,omitempty"`
+	AuthorizationList     *[]RPCAuthorization `json:"authorizationList,omitempty"`
+	Type                  *hexutil.Uint64     `json:"type,omitempty"`
+	SourceHash            *common.Hash        `json:"sourceHash,omitempty"`
+	Mint                  *hexutil.Big        `json:"mint,omitempty"`
+	IsSystemTx            *bool               `json:"isSystemTx,omitempty"`
+	CumulativeGasUsed     *hexutil.Uint64     `json:"cumulativeGasUsed,omitempty"`
+	EffectiveGasPrice     *hexutil.Big        `json:"effectiveGasPrice,omitempty"`
+	GasUsed               *hexutil.Uint64     `json:"gasUsed,omitempty"`
+	ContractAddress       *common.Address     `json:"contractAddress,omitempty"`
+	LogsBloom             *hexutil.Bytes      `json:"logsBloom,omitempty"`
+	Root                  *hexutil.Bytes      `json:"root,omitempty"`
+	Status                *hexutil.Uint64     `json:"status,omitempty"`
+	BlobGasUsed           *hexutil.Uint64     `json:"blobGasUsed,omitempty"`
+	BlobGasPrice          *hexutil.Big        `json:"blobGasPrice,omitempty"`
+	DepositNonce          *hexutil.Uint64     `json:"depositNonce,omitempty"`
+	DepositReceiptVersion *hexutil.Uint64     `json:"depositReceiptVersion,omitempty"`
+	L1Fee                 *hexutil.Big        `json:"l1Fee,omitempty"`
+	L1GasPrice            *hexutil.Big        `json:"l1GasPrice,omitempty"`
+	L1GasUsed             *hexutil.Uint64     `json:"l1GasUsed,omitempty"`
+	L1FeeScalar           *float64            `json:"l1FeeScalar,omitempty,string"`
+	L1BaseFeeScalar       *hexutil.Uint64     `json:"l1BaseFeeScalar,omitempty"`
+	L1BlobBaseFee         *hexutil.Big        `json:"l1BlobBaseFee,omitempty"`
+	L1BlobBaseFeeScalar   *hexutil.Uint64     `json:"l1BlobBaseFeeScalar,omitempty"`
+	GasUsedForL1          *hexutil.Uint64     `json:"gasUsedForL1,omitempty"`
+	L1BlockNumber         *hexutil.Big        `json:"l1BlockNumber,omitempty"`
+}
+
+// RPCLog is the JSON-RPC representation of a log, as returned by eth_getLogs.
+type RPCLog struct {
+	Address          *common.Address `json:"address,omitempty"`
+	Topics           []common.Hash   `json:"topics"`
+	Data             *hexutil.Bytes  `json:"data,omitempty"`
+	BlockNumber      *hexutil.Big    `json:"blockNumber,omitempty"`
+	TransactionHash  *common.Hash    `json:"transactionHash,omitempty"`
+	TransactionIndex *hexutil.Uint64 `json:"transactionIndex,omitempty"`
+	BlockHash        *common.Hash    `json:"blockHash,omitempty"`
+	LogIndex         *hexutil.Uint64 `json:"logIndex,omitempty"`
+	Removed          *bool           `json:"removed,omitempty"`
+}
+
+// RPCTraceAction is the action of a JSON-RPC trace.
+type RPCTraceAction struct {
+	From       *common.Address `json:"from,omitempty"`
+	To         *common.Address `json:"to,omitempty"`
+	CallType   *string         `json:"callType,omitempty"`
+	Gas        *hexutil.Uint64 `json:"gas,omitempty"`
+	Input      *hexutil.Bytes  `json:"input,omitempty"`
+	Init       *hexutil.Bytes  `json:"init,omitempty"`
+	Value      *hexutil.Big    `json:"value,omitempty"`
+	Author     *common.Address `json:"author,omitempty"`
+	RewardType *string         `json:"rewardType,omitempty"`
+	Address    *common.Address `json:"address,omitempty"`
+}
+
+// RPCTraceResult is the result of a JSON-RPC trace.
+type RPCTraceResult struct {
+	GasUsed *hexutil.Uint64 `json:"gasUsed,omitempty"`
+	Output  *hexutil.Bytes  `json:"output,omitempty"`
+	Code    *hexutil.Bytes  `json:"code,omitempty"`
+}
+
+// RPCTrace is the JSON-RPC representation of a trace, as returned by trace_block and trace_filter. As in
+// those responses, block numbers, positions and subtrace counts are plain numbers.
+type RPCTrace struct {
+	Action              RPCTraceAction  `json:"action"`
+	Result              *RPCTraceResult `json:"result,omitempty"`
+	BlockHash           *common.Hash    `json:"blockHash,omitempty"`
+	BlockNumber         *uint64         `json:"blockNumber,omitempty"`
+	Subtraces           *uint64         `json:"subtraces,omitempty"`
+	TraceAddress        *[]uint64       `json:"traceAddress,omitempty"`
+	TransactionHash     *common.Hash    `json:"transactionHash,omitempty"`
+	TransactionPosition *uint64         `json:"transactionPosition,omitempty"`
+	Type                *string         `json:"type,omitempty"`
+	Error               *string         `json:"error,omitempty"`
+}
+
+// MarshalRPC encodes blocks, transactions, logs and traces in the Ethereum JSON-RPC format. It accepts
+// a value, a pointer or a slice of Block, Transaction, Log or Trace.
+//
+// Example:
+//
+//	data, err := types.MarshalRPC(response.Data.Logs)
+//	if err != nil {
+//	    log.Fatalf("Failed to encode logs: %v", err)
+//	}
+func MarshalRPC(v any) ([]byte, error) {
+	switch val := v.(type) {
+	case Block:
+		return json.Marshal(val.ToRPC())
+	case *Block:
+		return json.Marshal(val.ToRPC())
+	case []Block:
+		return json.Marshal(mapSlice(val, (*Block).ToRPC))
+	case Transaction:
+		return json.Marshal(val.ToRPC())
+	case *Transaction:
+		return json.Marshal(val.ToRPC())
+	case []Transaction:
+		return json.Marshal(mapSlice(val, (*Transaction).ToRPC))
+	case Log:
+		return json.Marshal(val.ToRPC())
+	case *Log:
+		return json.Marshal(val.ToRPC())
+	case []Log:
+		return json.Marshal(mapSlice(val, (*Log).ToRPC))
+	case Trace:
+		return json.Marshal(val.ToRPC())
+	case *Trace:
+		return json.Marshal(val.ToRPC())
+	case []Trace:
+		return json.Marshal(mapSlice(val, (*Trace).ToRPC))
+	default:
+		return nil, fmt.Errorf("unsupported type for rpc encoding: %T", v)
+	}
+}
+
+// UnmarshalRPC decodes blocks, transactions, logs and traces from the Ethereum JSON-RPC format. It is the
+// inverse of MarshalRPC and accepts a pointer to a Block, Transaction, Log or Trace or to a slice of them.
+// Block transactions are not decoded into the block.
+//
+// Example:
+//
+//	var logs []types.Log
+//	if err := types.UnmarshalRPC(data, &logs); err != nil {
+//	    log.Fatalf("Failed to decode logs: %v", err)
+//	}
+func UnmarshalRPC(data []byte, v any) error {
+	switch val := v.(type) {
+	case *Block:
+		return unmarshalRPC(data, val, (*RPCBlock).ToBlock)
+	case *[]Block:
+		return unmarshalRPCSlice(data, val, (*RPCBlock).ToBlock)
+	case *Transaction:
+		return unmarshalRPC(data, val, (*RPCTransaction).ToTransaction)
+	case *[]Transaction:
+		return unmarshalRPCSlice(data, val, (*RPCTransaction).ToTransaction)
+	case *Log:
+		return unmarshalRPC(data, val, (*RPCLog).ToLog)
+	case *[]Log:
+		return unmarshalRPCSlice(data, val, (*RPCLog).ToLog)
+	case *Trace:
+		return unmarshalRPC(data, val, (*RPCTrace).ToTrace)
+	case *[]Trace:
+		return unmarshalRPCSlice(data, val, (*RPCTrace).ToTrace)
+	default:
+		return fmt.Errorf("unsupported type for rpc decoding: %T", v)
+	}
+}
+
+// unmarshalRPC decodes the RPC representation R of a value and converts it into the target.
+func unmarshalRPC[T any, R any](data []byte, target *T, convert func(*R) *T) error {
+	var rpc R
+	if err := json.Unmarshal(data, &rpc); err != nil {
+		return err
+	}
+	*target = *convert(&rpc)
+	return nil
+}
+
+// unmarshalRPCSlice decodes a list of RPC representations R and converts them into the target.
+func unmarshalRPCSlice[T any, R any](data []byte, target *[]T, convert func(*R) *T) error {
+	var rpc []R
+	if err := json.Unmarshal(data, &rpc); err != nil {
+		return err
+	}
+	toReturn := make([]T, len(rpc))
+	for i := range rpc {
+		toReturn[i] = *convert(&rpc[i])
+	}
+	*target = toReturn
+	return nil
+}
+
+// mapSlice converts every element of values.
+func mapSlice[T any, R any](values []T, convert func(*T) *R) []*R {
+	toReturn := make([]*R, len(values))
+	for i := range values {
+		toReturn[i] = convert(&values[i])
+	}
+	return toReturn
+}
+
+// ToRPC converts the block into its JSON-RPC representation, without transactions.
+func (b *Block) ToRPC() *RPCBlock {
+	toReturn := &RPCBlock{
+		Number:                hexBig(b.Number),
+		Hash:                  b.Hash,
+		ParentHash:            b.ParentHash,
+		Nonce:                 b.Nonce,
+		Sha3Uncles:            b.Sha3Uncles,
+		LogsBloom:             b.LogsBloom,
+		TransactionsRoot:      b.TransactionsRoot,
+		StateRoot:             b.StateRoot,
+		ReceiptsRoot:          b.ReceiptsRoot,
+		Miner:                 b.Miner,
+		Difficulty:            hexBig(b.Difficulty),
+		TotalDifficulty:       hexBig(b.TotalDifficulty),
+		ExtraData:             hexBytes(b.ExtraData),
+		Size:                  hexUint64(b.Size),
+		GasLimit:              hexUint64(b.GasLimit),
+		GasUsed:               hexUint64(b.GasUsed),
+		Uncles:                b.Uncles,
+		BaseFeePerGas:         hexBig(b.BaseFeePerGas),
+		BlobGasUsed:           hexUint64(b.BlobGasUsed),
+		ExcessBlobGas:         hexUint64(b.ExcessBlobGas),
+		ParentBeaconBlockRoot: b.ParentBeaconBlockRoot,
+		WithdrawalsRoot:       b.WithdrawalsRoot,
+		RequestsHash:          b.RequestsHash,
+		L1BlockNumber:         hexBig(b.L1BlockNumber),
+		SendCount:             hexBig(b.SendCount),
+		SendRoot:              b.SendRoot,
+		MixHash:               b.MixHash,
+	}
+
+	if b.Timestamp != nil {
+		timestamp := hexutil.Uint64(b.Timestamp.Unix())
+		toReturn.Timestamp = &timestamp
+	}
+
+	if b.Withdrawals != nil {
+		withdrawals := make([]RPCWithdrawal, len(*b.Withdrawals))
+		for i, w := range *b.Withdrawals {
+			withdrawals[i] = RPCWithdrawal{
+				Index:          hexutil.Uint64(w.Index),
+				ValidatorIndex: hexutil.Uint64(w.ValidatorIndex),
+				Address:        w.Address,
+				Amount:         hexutil.Uint64(w.Amount),
+			}
+		}
+		toReturn.Withdrawals = &withdrawals
+	}
+
+	return toReturn
+}
+
+// NewRPCBlock converts the block and its transactions into their JSON-RPC representation. The transactions
+// are included as full objects when full is set, and as hashes otherwise.
+func NewRPCBlock(block *Block, transactions []Transaction, full bool) *RPCBlock {
+	toReturn := block.ToRPC()
+	toReturn.Transactions = &RPCTransactions{}
+	for i := range transactions {
+		if full {
+			toReturn.Transactions.Full = append(toReturn.Transactions.Full, transactions[i].ToRPC())
+		} else if transactions[i].Hash != nil {
+			toReturn.Transactions.Hashes = append(toReturn.Transactions.Hashes, *transactions[i].Hash)
+		}
+	}
+	if full && toReturn.Transactions.Full == nil {
+		toReturn.Transactions.Full = []*RPCTransaction{}
+	}
+	return toReturn
+}
+
+// ToBlock converts the JSON-RPC representation back into a block.
+func (r *RPCBlock) ToBlock() *Block {
+	toReturn := &Block{
+		Number:                bigFromHex(r.Number),
+		Hash:                  r.Hash,
+		ParentHash:            r.ParentHash,
+		Nonce:                 r.Nonce,
+		Sha3Uncles:            r.Sha3Uncles,
+		LogsBloom:             r.LogsBloom,
+		TransactionsRoot:      r.TransactionsRoot,
+		StateRoot:             r.StateRoot,
+		ReceiptsRoot:          r.ReceiptsRoot,
+		Miner:                 r.Miner,
+		Difficulty:            bigFromHex(r.Difficulty),
+		TotalDifficulty:       bigFromHex(r.TotalDifficulty),
+		ExtraData:             bytesFromHex(r.ExtraData),
+		Size:                  uint64FromHex(r.Size),
+		GasLimit:              uint64FromHex(r.GasLimit),
+		GasUsed:               uint64FromHex(r.GasUsed),
+		Uncles:                r.Uncles,
+		BaseFeePerGas:         bigFromHex(r.BaseFeePerGas),
+		BlobGasUsed:           uint64FromHex(r.BlobGasUsed),
+		ExcessBlobGas:         uint64FromHex(r.ExcessBlobGas),
+		ParentBeaconBlockRoot: r.ParentBeaconBlockRoot,
+		WithdrawalsRoot:       r.WithdrawalsRoot,
+		RequestsHash:          r.RequestsHash,
+		L1BlockNumber:         bigFromHex(r.L1BlockNumber),
+		SendCount:             bigFromHex(r.SendCount),
+		SendRoot:              r.SendRoot,
+		MixHash:               r.MixHash,
+	}
+
+	if r.Timestamp != nil {
+		timestamp := time.Unix(int64(*r.Timestamp), 0)
+		toReturn.Timestamp = &timestamp
+	}
+
+	if r.Withdrawals != nil {
+		withdrawals := make([]Withdrawal, len(*r.Withdrawals))
+		for i, w := range *r.Withdrawals {
+			withdrawals[i] = Withdrawal{
+				Index:          uint64(w.Index),
+				ValidatorIndex: uint64(w.ValidatorIndex),
+				Address:        w.Address,
+				Amount:         uint64(w.Amount),
+			}
+		}
+		toReturn.Withdrawals = &withdrawals
+	}
+
+	return toReturn
+}
+
+// ToRPC converts the transaction into its JSON-RPC representation.
+func (t *Transaction) ToRPC() *RPCTransaction {
+	toReturn := &RPCTransaction{
+		BlockHash:             t.BlockHash,
+		BlockNumber:           hexBig(t.BlockNumber),
+		From:                  t.From,
+		Gas:                   hexUint64(t.Gas),
+		GasPrice:              hexBig(t.GasPrice),
+		Hash:                  t.Hash,
+		Input:                 hexBytes(t.Input),
+		Nonce:                 hexUint64(t.Nonce),
+		To:                    t.To,
+		TransactionIndex:      hexUint64(t.TransactionIndex),
+		Value:                 hexBig(t.Value),
+		V:                     hexBig(t.V),
+		R:                     hexBig(t.R),
+		S:                     hexBig(t.S),
+		YParity:               hexBig(t.YParity),
+		MaxPriorityFeePerGas:  hexBig(t.MaxPriorityFeePerGas),
+		MaxFeePerGas:          hexBig(t.MaxFeePerGas),
+		ChainID:               hexBig(t.ChainID),
+		AccessList:            t.AccessList,
+		MaxFeePerBlobGas:      hexBig(t.MaxFeePerBlobGas),
+		BlobVersionedHashes:   t.BlobVersionedHashes,
+		SourceHash:            t.SourceHash,
+		Mint:                  hexBig(t.Mint),
+		IsSystemTx:            t.IsSystemTx,
+		CumulativeGasUsed:     hexUint64(t.CumulativeGasUsed),
+		EffectiveGasPrice:     hexBig(t.EffectiveGasPrice),
+		GasUsed:               hexUint64(t.GasUsed),
+		ContractAddress:       t.ContractAddress,
+		BlobGasUsed:           hexUint64(t.BlobGasUsed),
+		BlobGasPrice:          hexBig(t.BlobGasPrice),
+		DepositNonce:          hexUint64(t.DepositNonce),
+		DepositReceiptVersion: hexUint64(t.DepositReceiptVersion),
+		L1Fee:                 hexBig(t.L1Fee),
+		L1GasPrice:            hexBig(t.L1GasPrice),
+		L1GasUsed:             hexUint64(t.L1GasUsed),
+		L1FeeScalar:           t.L1FeeScalar,
+		L1BaseFeeScalar:       hexUint64(t.L1BaseFeeScalar),
+		L1BlobBaseFee:         hexBig(t.L1BlobBaseFee),
+		L1BlobBaseFeeScalar:   hexUint64(t.L1BlobBaseFeeScalar),
+		GasUsedForL1:          hexUint64(t.GasUsedForL1),
+		L1BlockNumber:         hexBig(t.L1BlockNumber),
+	}
+
+	if t.Kind != nil {
+		kind := hexutil.Uint64(*t.Kind)
+		toReturn.Type = &kind
+	}
+	if t.Status != nil {
+		status := hexutil.Uint64(*t.Status)
+		toReturn.Status = &status
+	}
+	if t.LogsBloom != nil {
+		bloom := hexutil.Bytes(*t.LogsBloom)
+		toReturn.LogsBloom = &bloom
+	}
+	if t.Root != nil {
+		root := hexutil.Bytes(t.Root.Bytes())
+		toReturn.Root = &root
+	}
+
+	if t.AuthorizationList != nil {
+		authorizations := make([]RPCAuthorization, len(*t.AuthorizationList))
+		for i, a := range *t.AuthorizationList {
+			authorizations[i] = RPCAuthorization{
+				ChainID: hexBig(a.ChainID),
+				Address: a.Address,
+				Nonce:   hexutil.Uint64(a.Nonce),
+				YParity: hexutil.Uint64(a.YParity),
+				R:       hexBig(a.R),
+				S:       hexBig(a.S),
+			}
+		}
+		toReturn.AuthorizationList = &authorizations
+	}
+
+	return toReturn
+}
+
+// ToTransaction converts the JSON-RPC representation back into a transaction.
+func (r *RPCTransaction) ToTransaction() *Transaction {
+	toReturn := &Transaction{
+		BlockHash:             r.BlockHash,
+		BlockNumber:           bigFromHex(r.BlockNumber),
+		From:                  r.From,
+		Gas:                   uint64FromHex(r.Gas),
+		GasPrice:              bigFromHex(r.GasPrice),
+		Hash:                  r.Hash,
+		Input:                 bytesFromHex(r.Input),
+		Nonce:                 uint64FromHex(r.Nonce),
+		To:                    r.To,
+		TransactionIndex:      uint64FromHex(r.TransactionIndex),
+		Value:                 bigFromHex(r.Value),
+		V:                     bigFromHex(r.V),
+		R:                     bigFromHex(r.R),
+		S:                     bigFromHex(r.S),
+		YParity:               bigFromHex(r.YParity),
+		MaxPriorityFeePerGas:  bigFromHex(r.MaxPriorityFeePerGas),
+		MaxFeePerGas:          bigFromHex(r.MaxFeePerGas),
+		ChainID:               bigFromHex(r.ChainID),
+		AccessList:            r.AccessList,
+		MaxFeePerBlobGas:      bigFromHex(r.MaxFeePerBlobGas),
+		BlobVersionedHashes:   r.BlobVersionedHashes,
+		SourceHash:            r.SourceHash,
+		Mint:                  bigFromHex(r.Mint),
+		IsSystemTx:            r.IsSystemTx,
+		CumulativeGasUsed:     uint64FromHex(r.CumulativeGasUsed),
+		EffectiveGasPrice:     bigFromHex(r.EffectiveGasPrice),
+		GasUsed:               uint64FromHex(r.GasUsed),
+		ContractAddress:       r.ContractAddress,
+		BlobGasUsed:           uint64FromHex(r.BlobGasUsed),
+		BlobGasPrice:          bigFromHex(r.BlobGasPrice),
+		DepositNonce:          uint64FromHex(r.DepositNonce),
+		DepositReceiptVersion: uint64FromHex(r.DepositReceiptVersion),
+		L1Fee:                 bigFromHex(r.L1Fee),
+		L1GasPrice:            bigFromHex(r.L1GasPrice),
+		L1GasUsed:             uint64FromHex(r.L1GasUsed),
+		L1FeeScalar:           r.L1FeeScalar,
+		L1BaseFeeScalar:       uint64FromHex(r.L1BaseFeeScalar),
+		L1BlobBaseFee:         bigFromHex(r.L1BlobBaseFee),
+		L1BlobBaseFeeScalar:   uint64FromHex(r.L1BlobBaseFeeScalar),
+		GasUsedForL1:          uint64FromHex(r.GasUsedForL1),
+		L1BlockNumber:         bigFromHex(r.L1BlockNumber),
+	}
+
+	if r.Type != nil {
+		kind := uint8(*r.Type)
+		toReturn.Kind = &kind
+	}
+	if r.Status != nil {
+		status := uint8(*r.Status)
+		toReturn.Status = &status
+	}
+	if r.LogsBloom != nil {
+		bloom := BloomFilter(*r.LogsBloom)
+		toReturn.LogsBloom = &bloom
+	}
+	if r.Root != nil {
+		root := common.BytesToHash(*r.Root)
+		toReturn.Root = &root
+	}
+
+	if r.AuthorizationList != nil {
+		authorizations := make([]Authorization, len(*r.AuthorizationList))
+		for i, a := range *r.AuthorizationList {
+			authorizations[i] = Authorization{
+				ChainID: bigFromHex(a.ChainID),
+				Address: a.Address,
+				Nonce:   uint64(a.Nonce),
+				YParity: uint8(a.YParity),
+				R:       bigFromHex(a.R),
+				S:       bigFromHex(a.S),
+			}
+		}
+		toReturn.AuthorizationList = &authorizations
+	}
+
+	return toReturn
+}
+
+// ToRPC converts the log into its JSON-RPC representation.
+func (l *Log) ToRPC() *RPCLog {
+	return &RPCLog{
+		Address:          l.Address,
+		Topics:           l.Topics(),
+		Data:             hexBytes(l.Data),
+		BlockNumber:      hexBig(l.BlockNumber),
+		TransactionHash:  l.TransactionHash,
+		TransactionIndex: hexUint64(l.TransactionIndex),
+		BlockHash:        l.BlockHash,
+		LogIndex:         hexUint64(l.LogIndex),
+		Removed:          l.Removed,
+	}
+}
+
+// ToLog converts the JSON-RPC representation back into a log.
+func (r *RPCLog) ToLog() *Log {
+	toReturn := &Log{
+		Address:          r.Address,
+		Data:             bytesFromHex(r.Data),
+		BlockNumber:      bigFromHex(r.BlockNumber),
+		TransactionHash:  r.TransactionHash,
+		TransactionIndex: uint64FromHex(r.TransactionIndex),
+		BlockHash:        r.BlockHash,
+		LogIndex:         uint64FromHex(r.LogIndex),
+		Removed:          r.Removed,
+	}
+
+	topics := []**common.Hash{&toReturn.Topic0, &toReturn.Topic1, &toReturn.Topic2, &toReturn.Topic3}
+	for i := 0; i < len(r.Topics) && i < len(topics); i++ {
+		topic := r.Topics[i]
+		*topics[i] = &topic
+	}
+
+	return toReturn
+}
+
+// ToRPC converts the trace into its JSON-RPC representation.
+func (t *Trace) ToRPC() *RPCTrace {
+	toReturn := &RPCTrace{
+		Action: RPCTraceAction{
+			From:       t.From,
+			To:         t.To,
+			CallType:   t.CallType,
+			Gas:        hexUint64(t.Gas),
+			Input:      hexBytes(t.Input),
+			Init:       hexBytes(t.Init),
+			Value:      hexBig(t.Value),
+			Author:     t.Author,
+			RewardType: t.RewardType,
+			Address:    t.AddressDestroyed,
+		},
+		BlockHash:           t.BlockHash,
+		Subtraces:           t.Subtraces,
+		TraceAddress:        t.TraceAddress,
+		TransactionHash:     t.TransactionHash,
+		TransactionPosition: t.TransactionPosition,
+		Type:                t.Kind,
+		Error:               t.Error,
+	}
+
+	if t.BlockNumber != nil && t.BlockNumber.IsUint64() {
+		number := t.BlockNumber.Uint64()
+		toReturn.BlockNumber = &number
+	}
+
+	if t.GasUsed != nil || t.Output != nil || t.Code != nil {
+		toReturn.Result = &RPCTraceResult{
+			GasUsed: hexUint64(t.GasUsed),
+			Output:  hexBytes(t.Output),
+			Code:    hexBytes(t.Code),
+		}
+	}
+
+	return toReturn
+}
+
+// ToTrace converts the JSON-RPC representation back into a trace.
+func (r *RPCTrace) ToTrace() *Trace {
+	toReturn := &Trace{
+		From:                r.Action.From,
+		To:                  r.Action.To,
+		CallType:            r.Action.CallType,
+		Gas:                 uint64FromHex(r.Action.Gas),
+		Input:               bytesFromHex(r.Action.Input),
+		Init:                bytesFromHex(r.Action.Init),
+		Value:               bigFromHex(r.Action.Value),
+		Author:              r.Action.Author,
+		RewardType:          r.Action.RewardType,
+		AddressDestroyed:    r.Action.Address,
+		BlockHash:           r.BlockHash,
+		Subtraces:           r.Subtraces,
+		TraceAddress:        r.TraceAddress,
+		TransactionHash:     r.TransactionHash,
+		TransactionPosition: r.TransactionPosition,
+		Kind:                r.Type,
+		Error:               r.Error,
+	}
+
+	if r.BlockNumber != nil {
+		toReturn.BlockNumber = new(big.Int).SetUint64(*r.BlockNumber)
+	}
+
+	if r.Result != nil {
+		toReturn.GasUsed = uint64FromHex(r.Result.GasUsed)
+		toReturn.Output = bytesFromHex(r.Result.Output)
+		toReturn.Code = bytesFromHex(r.Result.Code)
+	}
+
+	return toReturn
+}
+
+func hexBig(val *big.Int) *hexutil.Big {
+	if val == nil {
+		return nil
+	}
+	return (*hexutil.Big)(new(big.Int).Set(val))
+}
+
+func bigFromHex(val *hexutil.Big) *big.Int {
+	if val == nil {
+		return nil
+	}
+	return new(big.Int).Set(val.ToInt())
+}
+
+func hexUint64(val *uint64) *hexutil.Uint64 {
+	if val == nil {
+		return nil
+	}
+	toReturn := hexutil.Uint64(*val)
+	return &toReturn
+}
+
+func uint64FromHex(val *hexutil.Uint64) *uint64 {
+	if val == nil {
+		return nil
+	}
+	toReturn := uint64(*val)
+	return &toReturn
+}
+
+func hexBytes(val *[]byte) *hexutil.Bytes {
+	if val == nil {
+		return nil
+	}
+	toReturn := hexutil.Bytes(append([]byte{}, *val...))
+	return &toReturn
+}
+
+func bytesFromHex(val *hexutil.Bytes) *[]byte {
+	if val == nil {
+		return nil
+	}
+	toReturn := append([]byte{}, *val...)
+	return &toReturn
+}
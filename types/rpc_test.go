@@ -0,0 +1,223 @@
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalRPCLogMatchesGeth(t *testing.T) {
+	removed := false
+	logIndex, txIndex := uint64(7), uint64(2)
+	data := []byte{0x00, 0x01, 0xff}
+	address := common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+	topic0 := common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	topic1 := common.HexToHash("0x01")
+	txHash := common.HexToHash("0xaa")
+	blockHash := common.HexToHash("0xbb")
+
+	log := Log{
+		Removed:          &removed,
+		LogIndex:         &logIndex,
+		TransactionIndex: &txIndex,
+		TransactionHash:  &txHash,
+		BlockHash:        &blockHash,
+		BlockNumber:      big.NewInt(19000000),
+		Address:          &address,
+		Data:             &data,
+		Topic0:           &topic0,
+		Topic1:           &topic1,
+	}
+
+	encoded, err := MarshalRPC(log)
+	require.NoError(t, err)
+
+	gethLog, err := log.ToGeth()
+	require.NoError(t, err)
+	expected, err := json.Marshal(gethLog)
+	require.NoError(t, err)
+	require.JSONEq(t, string(expected), string(encoded))
+
+	var decoded Log
+	require.NoError(t, UnmarshalRPC(encoded, &decoded))
+	require.Equal(t, log, decoded)
+}
+
+func TestMarshalRPCBlock(t *testing.T) {
+	number := big.NewInt(0x10)
+	hash := common.HexToHash("0x01")
+	gasUsed := uint64(21000)
+	timestamp := time.Unix(1700000000, 0)
+	nonce := types.EncodeNonce(0x42)
+	extraData := []byte("hypersync")
+	withdrawals := []Withdrawal{{Index: 1, ValidatorIndex: 2, Address: common.HexToAddress("0x03"), Amount: 4}}
+
+	block := Block{
+		Number:        number,
+		Hash:          &hash,
+		Nonce:         &nonce,
+		GasUsed:       &gasUsed,
+		Timestamp:     &timestamp,
+		ExtraData:     &extraData,
+		BaseFeePerGas: big.NewInt(1000000000),
+		Withdrawals:   &withdrawals,
+	}
+
+	encoded, err := MarshalRPC([]Block{block})
+	require.NoError(t, err)
+	require.JSONEq(t, `[{
+		"number": "0x10",
+		"hash": "0x0000000000000000000000000000000000000000000000000000000000000001",
+		"nonce": "0x0000000000000042",
+		"gasUsed": "0x5208",
+		"timestamp": "0x6553f100",
+		"extraData": "0x687970657273796e63",
+		"baseFeePerGas": "0x3b9aca00",
+		"withdrawals": [{"index": "0x1", "validatorIndex": "0x2", "address": "0x0000000000000000000000000000000000000003", "amount": "0x4"}]
+	}]`, string(encoded))
+
+	var decoded []Block
+	require.NoError(t, UnmarshalRPC(encoded, &decoded))
+	require.Len(t, decoded, 1)
+	require.Equal(t, block.Timestamp.Unix(), decoded[0].Timestamp.Unix())
+	decoded[0].Timestamp = block.Timestamp
+	require.Equal(t, block, decoded[0])
+}
+
+func TestNewRPCBlockTransactions(t *testing.T) {
+	hash := common.HexToHash("0xaa")
+	nonce := uint64(3)
+	block := &Block{Number: big.NewInt(1)}
+	transactions := []Transaction{{Hash: &hash, Nonce: &nonce}}
+
+	encoded, err := json.Marshal(NewRPCBlock(block, transactions, false))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"number":"0x1","transactions":["0x00000000000000000000000000000000000000000000000000000000000000aa"]}`, string(encoded))
+
+	var hashes RPCBlock
+	require.NoError(t, json.Unmarshal(encoded, &hashes))
+	require.Equal(t, []common.Hash{hash}, hashes.Transactions.Hashes)
+
+	encoded, err = json.Marshal(NewRPCBlock(block, transactions, true))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"number":"0x1","transactions":[{"hash":"0x00000000000000000000000000000000000000000000000000000000000000aa","nonce":"0x3"}]}`, string(encoded))
+
+	var full RPCBlock
+	require.NoError(t, json.Unmarshal(encoded, &full))
+	require.Len(t, full.Transactions.Full, 1)
+	require.Equal(t, transactions[0], *full.Transactions.Full[0].ToTransaction())
+
+	encoded, err = json.Marshal(NewRPCBlock(block, nil, true))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"number":"0x1","transactions":[]}`, string(encoded))
+}
+
+func TestMarshalRPCTransaction(t *testing.T) {
+	kind, status := uint8(2), uint8(1)
+	gas, index := uint64(21000), uint64(0)
+	scalar := 0.684
+	input := []byte{0xa9, 0x05, 0x9c, 0xbb}
+	bloom := BloomFilter(make([]byte, 256))
+	accessList := types.AccessList{{Address: common.HexToAddress("0x01"), StorageKeys: []common.Hash{common.HexToHash("0x02")}}}
+	authorizations := []Authorization{{ChainID: big.NewInt(1), Address: common.HexToAddress("0x04"), Nonce: 5, YParity: 1, R: big.NewInt(6), S: big.NewInt(7)}}
+
+	tx := Transaction{
+		Gas:               &gas,
+		TransactionIndex:  &index,
+		Input:             &input,
+		Value:             big.NewInt(0),
+		MaxFeePerGas:      big.NewInt(30000000000),
+		Kind:              &kind,
+		Status:            &status,
+		LogsBloom:         &bloom,
+		AccessList:        &accessList,
+		AuthorizationList: &authorizations,
+		L1FeeScalar:       &scalar,
+		L1BlockNumber:     big.NewInt(19000000),
+	}
+
+	encoded, err := MarshalRPC(&tx)
+	require.NoError(t, err)
+
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal(encoded, &fields))
+	require.Equal(t, "0x5208", fields["gas"])
+	require.Equal(t, "0x0", fields["transactionIndex"])
+	require.Equal(t, "0xa9059cbb", fields["input"])
+	require.Equal(t, "0x0", fields["value"])
+	require.Equal(t, "0x6fc23ac00", fields["maxFeePerGas"])
+	require.Equal(t, "0x2", fields["type"])
+	require.Equal(t, "0x1", fields["status"])
+	require.Equal(t, "0.684", fields["l1FeeScalar"])
+	require.Equal(t, "0x121eac0", fields["l1BlockNumber"])
+	require.Equal(t, []any{map[string]any{
+		"address":     "0x0000000000000000000000000000000000000001",
+		"storageKeys": []any{"0x0000000000000000000000000000000000000000000000000000000000000002"},
+	}}, fields["accessList"])
+	require.Equal(t, []any{map[string]any{
+		"chainId": "0x1", "address": "0x0000000000000000000000000000000000000004", "nonce": "0x5", "yParity": "0x1", "r": "0x6", "s": "0x7",
+	}}, fields["authorizationList"])
+
+	var decoded Transaction
+	require.NoError(t, UnmarshalRPC(encoded, &decoded))
+	require.Equal(t, tx, decoded)
+}
+
+func TestMarshalRPCTrace(t *testing.T) {
+	from := common.HexToAddress("0x01")
+	to := common.HexToAddress("0x02")
+	callType, kind := "call", "call"
+	gas, gasUsed, subtraces, position := uint64(0x1000), uint64(0x200), uint64(1), uint64(3)
+	traceAddress := []uint64{0, 1}
+	output := []byte{0x01}
+
+	trace := Trace{
+		From:                &from,
+		To:                  &to,
+		CallType:            &callType,
+		Gas:                 &gas,
+		Value:               big.NewInt(0),
+		BlockNumber:         big.NewInt(19000000),
+		GasUsed:             &gasUsed,
+		Output:              &output,
+		Subtraces:           &subtraces,
+		TraceAddress:        &traceAddress,
+		TransactionPosition: &position,
+		Kind:                &kind,
+	}
+
+	encoded, err := MarshalRPC([]Trace{trace})
+	require.NoError(t, err)
+	require.JSONEq(t, `[{
+		"action": {
+			"from": "0x0000000000000000000000000000000000000001",
+			"to": "0x0000000000000000000000000000000000000002",
+			"callType": "call",
+			"gas": "0x1000",
+			"value": "0x0"
+		},
+		"result": {"gasUsed": "0x200", "output": "0x01"},
+		"blockNumber": 19000000,
+		"subtraces": 1,
+		"traceAddress": [0, 1],
+		"transactionPosition": 3,
+		"type": "call"
+	}]`, string(encoded))
+
+	var decoded []Trace
+	require.NoError(t, UnmarshalRPC(encoded, &decoded))
+	require.Equal(t, []Trace{trace}, decoded)
+}
+
+func TestMarshalRPCUnsupportedType(t *testing.T) {
+	_, err := MarshalRPC("block")
+	require.EqualError(t, err, "unsupported type for rpc encoding: string")
+
+	var block Block
+	require.EqualError(t, UnmarshalRPC([]byte(`{}`), block), "unsupported type for rpc decoding: types.Block")
+}
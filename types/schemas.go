@@ -14,22 +14,23 @@ func BlockHeaderSchema(metadata *arrow.Metadata) *arrow.Schema {
 		{Name: "state_root", Type: hashDT(), Nullable: false},
 		{Name: "receipts_root", Type: hashDT(), Nullable: false},
 		{Name: "miner", Type: addrDT(), Nullable: false},
-		{Name: "difficulty", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
-		{Name: "total_difficulty", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		{Name: "difficulty", Type: quantityDT(), Nullable: true},
+		{Name: "total_difficulty", Type: quantityDT(), Nullable: true},
 		{Name: "extra_data", Type: arrow.BinaryTypes.Binary, Nullable: false},
-		{Name: "size", Type: arrow.PrimitiveTypes.Int64, Nullable: false},
-		{Name: "gas_limit", Type: arrow.PrimitiveTypes.Int64, Nullable: false},
-		{Name: "gas_used", Type: arrow.PrimitiveTypes.Int64, Nullable: false},
-		{Name: "timestamp", Type: arrow.PrimitiveTypes.Int64, Nullable: false},
+		{Name: "size", Type: quantityDT(), Nullable: false},
+		{Name: "gas_limit", Type: quantityDT(), Nullable: false},
+		{Name: "gas_used", Type: quantityDT(), Nullable: false},
+		{Name: "timestamp", Type: quantityDT(), Nullable: false},
 		{Name: "uncles", Type: arrow.BinaryTypes.Binary, Nullable: true},
-		{Name: "base_fee_per_gas", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
-		{Name: "blob_gas_used", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
-		{Name: "excess_blob_gas", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		{Name: "base_fee_per_gas", Type: quantityDT(), Nullable: true},
+		{Name: "blob_gas_used", Type: quantityDT(), Nullable: true},
+		{Name: "excess_blob_gas", Type: quantityDT(), Nullable: true},
 		{Name: "parent_beacon_block_root", Type: hashDT(), Nullable: true},
 		{Name: "withdrawals_root", Type: hashDT(), Nullable: true},
 		{Name: "withdrawals", Type: arrow.BinaryTypes.Binary, Nullable: true},
+		{Name: "requests_hash", Type: hashDT(), Nullable: true},
 		{Name: "l1_block_number", Type: arrow.PrimitiveTypes.Uint64, Nullable: true},
-		{Name: "send_count", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		{Name: "send_count", Type: quantityDT(), Nullable: true},
 		{Name: "send_root", Type: hashDT(), Nullable: true},
 		{Name: "mix_hash", Type: hashDT(), Nullable: true},
 	}
@@ -50,38 +51,50 @@ func TransactionSchema(metadata *arrow.Metadata) *arrow.Schema {
 		{Name: "block_hash", Type: hashDT(), Nullable: false},
 		{Name: "block_number", Type: arrow.PrimitiveTypes.Uint64, Nullable: false},
 		{Name: "from", Type: addrDT(), Nullable: true},
-		{Name: "gas", Type: arrow.PrimitiveTypes.Int64, Nullable: false},
-		{Name: "gas_price", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		{Name: "gas", Type: quantityDT(), Nullable: false},
+		{Name: "gas_price", Type: quantityDT(), Nullable: true},
 		{Name: "hash", Type: hashDT(), Nullable: false},
 		{Name: "input", Type: arrow.BinaryTypes.Binary, Nullable: false},
-		{Name: "nonce", Type: arrow.PrimitiveTypes.Int64, Nullable: false},
+		{Name: "nonce", Type: quantityDT(), Nullable: false},
 		{Name: "to", Type: addrDT(), Nullable: true},
 		{Name: "transaction_index", Type: arrow.PrimitiveTypes.Uint64, Nullable: false},
-		{Name: "value", Type: arrow.PrimitiveTypes.Int64, Nullable: false},
-		{Name: "v", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
-		{Name: "r", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
-		{Name: "s", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
-		{Name: "max_priority_fee_per_gas", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
-		{Name: "max_fee_per_gas", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
-		{Name: "chain_id", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
-		{Name: "cumulative_gas_used", Type: arrow.PrimitiveTypes.Int64, Nullable: false},
-		{Name: "effective_gas_price", Type: arrow.PrimitiveTypes.Int64, Nullable: false},
-		{Name: "gas_used", Type: arrow.PrimitiveTypes.Int64, Nullable: false},
+		{Name: "value", Type: quantityDT(), Nullable: false},
+		{Name: "v", Type: quantityDT(), Nullable: true},
+		{Name: "r", Type: quantityDT(), Nullable: true},
+		{Name: "s", Type: quantityDT(), Nullable: true},
+		{Name: "max_priority_fee_per_gas", Type: quantityDT(), Nullable: true},
+		{Name: "max_fee_per_gas", Type: quantityDT(), Nullable: true},
+		{Name: "chain_id", Type: quantityDT(), Nullable: true},
+		{Name: "cumulative_gas_used", Type: quantityDT(), Nullable: false},
+		{Name: "effective_gas_price", Type: quantityDT(), Nullable: false},
+		{Name: "gas_used", Type: quantityDT(), Nullable: false},
 		{Name: "contract_address", Type: addrDT(), Nullable: true},
 		{Name: "logs_bloom", Type: arrow.BinaryTypes.Binary, Nullable: false},
 		{Name: "type", Type: arrow.PrimitiveTypes.Uint8, Nullable: true},
 		{Name: "root", Type: hashDT(), Nullable: true},
 		{Name: "status", Type: arrow.PrimitiveTypes.Uint8, Nullable: true},
 		{Name: "sighash", Type: arrow.BinaryTypes.Binary, Nullable: true},
-		{Name: "y_parity", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		{Name: "y_parity", Type: quantityDT(), Nullable: true},
 		{Name: "access_list", Type: arrow.BinaryTypes.Binary, Nullable: true},
-		{Name: "l1_fee", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
-		{Name: "l1_gas_price", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
-		{Name: "l1_gas_used", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
-		{Name: "l1_fee_scalar", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
-		{Name: "gas_used_for_l1", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
-		{Name: "max_fee_per_blob_gas", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		{Name: "l1_fee", Type: quantityDT(), Nullable: true},
+		{Name: "l1_gas_price", Type: quantityDT(), Nullable: true},
+		{Name: "l1_gas_used", Type: quantityDT(), Nullable: true},
+		{Name: "l1_fee_scalar", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: "gas_used_for_l1", Type: quantityDT(), Nullable: true},
+		{Name: "max_fee_per_blob_gas", Type: quantityDT(), Nullable: true},
 		{Name: "blob_versioned_hashes", Type: arrow.BinaryTypes.Binary, Nullable: true},
+		{Name: "authorization_list", Type: arrow.BinaryTypes.Binary, Nullable: true},
+		{Name: "blob_gas_used", Type: quantityDT(), Nullable: true},
+		{Name: "blob_gas_price", Type: quantityDT(), Nullable: true},
+		{Name: "source_hash", Type: hashDT(), Nullable: true},
+		{Name: "mint", Type: quantityDT(), Nullable: true},
+		{Name: "is_system_tx", Type: arrow.FixedWidthTypes.Boolean, Nullable: true},
+		{Name: "deposit_nonce", Type: quantityDT(), Nullable: true},
+		{Name: "deposit_receipt_version", Type: quantityDT(), Nullable: true},
+		{Name: "l1_base_fee_scalar", Type: quantityDT(), Nullable: true},
+		{Name: "l1_blob_base_fee", Type: quantityDT(), Nullable: true},
+		{Name: "l1_blob_base_fee_scalar", Type: quantityDT(), Nullable: true},
+		{Name: "l1_block_number", Type: quantityDT(), Nullable: true},
 	}
 	return arrow.NewSchema(fields, metadata)
 }
@@ -127,17 +140,17 @@ func TraceSchema(metadata *arrow.Metadata) *arrow.Schema {
 		{Name: "from", Type: addrDT(), Nullable: true},
 		{Name: "to", Type: addrDT(), Nullable: true},
 		{Name: "call_type", Type: arrow.BinaryTypes.String, Nullable: true},
-		{Name: "gas", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		{Name: "gas", Type: quantityDT(), Nullable: true},
 		{Name: "input", Type: arrow.BinaryTypes.Binary, Nullable: true},
 		{Name: "init", Type: arrow.BinaryTypes.Binary, Nullable: true},
-		{Name: "value", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		{Name: "value", Type: quantityDT(), Nullable: true},
 		{Name: "author", Type: addrDT(), Nullable: true},
 		{Name: "reward_type", Type: arrow.BinaryTypes.String, Nullable: true},
 		{Name: "block_hash", Type: arrow.BinaryTypes.Binary, Nullable: false},
 		{Name: "block_number", Type: arrow.PrimitiveTypes.Uint64, Nullable: false},
 		{Name: "address", Type: addrDT(), Nullable: true},
 		{Name: "code", Type: arrow.BinaryTypes.Binary, Nullable: true},
-		{Name: "gas_used", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		{Name: "gas_used", Type: quantityDT(), Nullable: true},
 		{Name: "output", Type: arrow.BinaryTypes.Binary, Nullable: true},
 		{Name: "subtraces", Type: arrow.PrimitiveTypes.Uint64, Nullable: true},
 		{Name: "trace_address", Type: arrow.BinaryTypes.Binary, Nullable: true},
@@ -2,7 +2,6 @@ package types
 
 import (
 	"github.com/apache/arrow/go/v10/arrow"
-	"github.com/apache/arrow/go/v10/arrow/array"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
 	"math/big"
@@ -33,7 +32,7 @@ type Trace struct {
 	// The optional input data sent with the transaction, usually used to interact with smart contracts.
 	Input *[]byte `json:"input,omitempty"`
 	// The init code.
-	Init *[]common.Hash `json:"init,omitempty"`
+	Init *[]byte `json:"init,omitempty"`
 	// The value of the native token transferred along with the transaction, in Wei.
 	Value *big.Int `json:"value,omitempty"`
 	// The address of the receiver for reward transaction.
@@ -47,11 +46,11 @@ type Trace struct {
 	// Destroyed address.
 	AddressDestroyed *common.Address `json:"address,omitempty"`
 	// Contract code.
-	Code *common.Hash `json:"code,omitempty"`
+	Code *[]byte `json:"code,omitempty"`
 	// The total used gas by the call, encoded as hexadecimal.
 	GasUsed *uint64 `json:"gas_used,omitempty"`
 	// The return value of the call, encoded as a hexadecimal string.
-	Output *common.Hash `json:"output,omitempty"`
+	Output *[]byte `json:"output,omitempty"`
 	// The number of sub-traces created during execution. When a transaction is executed on the EVM, it may trigger additional sub-executions, such as when a smart contract calls another smart contract or when an external account is accessed.
 	Subtraces *uint64 `json:"subtraces,omitempty"`
 	// An array that indicates the position of the transaction in the trace.
@@ -66,7 +65,20 @@ type Trace struct {
 	Error *string `json:"error,omitempty"`
 }
 
+// NewTraceFromRecord decodes the first row of the record into a trace. It decodes in lenient mode, skipping
+// columns of an unexpected type or with an unknown name; use NewTraceFromRecordWithMode to decode strictly.
 func NewTraceFromRecord(schema *arrow.Schema, record arrow.Record) (*Trace, error) {
+	return NewTraceFromRecordWithMode(schema, record, DecodeLenient)
+}
+
+// NewTraceFromRecordWithMode decodes the first row of the record into a trace in the given mode. Use a Decoder
+// to read the warnings of lenient decoding.
+func NewTraceFromRecordWithMode(schema *arrow.Schema, record arrow.Record, mode DecodeMode) (*Trace, error) {
+	return NewDecoder(mode).Trace(schema, record)
+}
+
+// Trace decodes the first row of the record into a trace according to the decoder mode.
+func (d *Decoder) Trace(schema *arrow.Schema, record arrow.Record) (*Trace, error) {
 	if record.NumCols() != int64(len(schema.Fields())) {
 		return nil, errors.New("number of columns in record does not match schema")
 	}
@@ -78,136 +90,57 @@ func NewTraceFromRecord(schema *arrow.Schema, record arrow.Record) (*Trace, erro
 		if col.Len() == 0 {
 			continue
 		}
+		var err error
 		switch field.Name {
 		case "from":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				from := common.BytesToAddress(val)
-				toReturn.From = &from
-			}
+			toReturn.From, err = addressFromColumn(col, 0)
 		case "to":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				to := common.BytesToAddress(val)
-				toReturn.To = &to
-			}
+			toReturn.To, err = addressFromColumn(col, 0)
 		case "sighash":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				hash := common.BytesToHash(val)
-				toReturn.SigHash = &hash
-			}
+			toReturn.SigHash, err = hashFromColumn(col, 0)
 		case "call_type":
-			if fCol, ok := col.(*array.String); ok {
-				val := fCol.Value(0)
-				toReturn.CallType = &val
-			}
+			toReturn.CallType, err = stringFromColumn(col, 0)
 		case "gas":
-			if fCol, ok := col.(*array.Uint64); ok {
-				val := fCol.Value(0)
-				toReturn.Gas = &val
-			}
+			toReturn.Gas, err = uint64FromColumn(col, 0)
 		case "input":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				toReturn.Input = &val
-			}
+			toReturn.Input, err = bytesFromColumn(col, 0)
 		case "init":
-			if fCol, ok := col.(*array.List); ok {
-				list := fCol.ListValues()
-				init := make([]common.Hash, list.Len())
-				/*				for j := 0; j < list.Len(); j++ {
-								init[j] = common.BytesToHash(list.Value(j).([]byte))
-							}*/
-				toReturn.Init = &init
-			}
+			toReturn.Init, err = bytesFromColumn(col, 0)
 		case "value":
-			if fCol, ok := col.(*array.Uint64); ok {
-				val := fCol.Value(0)
-				toReturn.Value = big.NewInt(0).SetUint64(val)
-			}
+			toReturn.Value, err = bigIntFromColumn(col, 0)
 		case "author":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				author := common.BytesToAddress(val)
-				toReturn.Author = &author
-			}
+			toReturn.Author, err = addressFromColumn(col, 0)
 		case "reward_type":
-			if fCol, ok := col.(*array.String); ok {
-				val := fCol.Value(0)
-				toReturn.RewardType = &val
-			}
+			toReturn.RewardType, err = stringFromColumn(col, 0)
 		case "block_hash":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				blockHash := common.BytesToHash(val)
-				toReturn.BlockHash = &blockHash
-			}
+			toReturn.BlockHash, err = hashFromColumn(col, 0)
 		case "block_number":
-			if fCol, ok := col.(*array.Uint64); ok {
-				val := fCol.Value(0)
-				toReturn.BlockNumber = big.NewInt(0).SetUint64(val)
-			}
+			toReturn.BlockNumber, err = bigIntFromColumn(col, 0)
 		case "address":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				address := common.BytesToAddress(val)
-				toReturn.AddressDestroyed = &address
-			}
+			toReturn.AddressDestroyed, err = addressFromColumn(col, 0)
 		case "code":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				code := common.BytesToHash(val)
-				toReturn.Code = &code
-			}
+			toReturn.Code, err = bytesFromColumn(col, 0)
 		case "gas_used":
-			if fCol, ok := col.(*array.Uint64); ok {
-				val := fCol.Value(0)
-				toReturn.GasUsed = &val
-			}
+			toReturn.GasUsed, err = uint64FromColumn(col, 0)
 		case "output":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				output := common.BytesToHash(val)
-				toReturn.Output = &output
-			}
+			toReturn.Output, err = bytesFromColumn(col, 0)
 		case "subtraces":
-			if fCol, ok := col.(*array.Uint64); ok {
-				val := fCol.Value(0)
-				toReturn.Subtraces = &val
-			}
+			toReturn.Subtraces, err = uint64FromColumn(col, 0)
 		case "trace_address":
-			if fCol, ok := col.(*array.List); ok {
-				list := fCol.ListValues()
-				traceAddress := make([]uint64, list.Len())
-				/*				for j := 0; j < list.Len(); j++ {
-								traceAddress[j] = list.Value(j).(uint64)
-							}*/
-				toReturn.TraceAddress = &traceAddress
-			}
+			toReturn.TraceAddress, err = uint64sFromColumn(col, 0)
 		case "transaction_hash":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				transactionHash := common.BytesToHash(val)
-				toReturn.TransactionHash = &transactionHash
-			}
+			toReturn.TransactionHash, err = hashFromColumn(col, 0)
 		case "transaction_position":
-			if fCol, ok := col.(*array.Uint64); ok {
-				val := fCol.Value(0)
-				toReturn.TransactionPosition = &val
-			}
+			toReturn.TransactionPosition, err = uint64FromColumn(col, 0)
 		case "type":
-			if fCol, ok := col.(*array.String); ok {
-				val := fCol.Value(0)
-				toReturn.Kind = &val
-			}
+			toReturn.Kind, err = stringFromColumn(col, 0)
 		case "error":
-			if fCol, ok := col.(*array.String); ok {
-				val := fCol.Value(0)
-				toReturn.Error = &val
-			}
+			toReturn.Error, err = stringFromColumn(col, 0)
 		default:
-			return nil, errors.New("unsupported field: " + field.Name)
+			err = &UnknownColumnError{Name: field.Name}
+		}
+		if err = d.check("trace", field, err); err != nil {
+			return nil, err
 		}
 	}
 
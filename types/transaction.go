@@ -1,21 +1,44 @@
 package types
 
 import (
+	"fmt"
+	"math/big"
+	"sort"
+
 	"github.com/apache/arrow/go/v10/arrow"
-	"github.com/apache/arrow/go/v10/arrow/array"
+	errorshs "github.com/enviodev/hypersync-client-go/errors"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
 	"github.com/pkg/errors"
-	"math/big"
+)
+
+const (
+	// SetCodeTxType is the type of EIP-7702 set code transactions.
+	SetCodeTxType uint8 = 0x04
+	// DepositTxType is the type of OP-stack deposit transactions.
+	DepositTxType uint8 = 0x7e
 )
 
 type TransactionSelection struct {
+	// Hash matches transactions by hash.
+	Hash            []common.Hash    `json:"hash,omitempty"`
 	From            []common.Address `json:"from,omitempty"`
 	To              []common.Address `json:"to,omitempty"`
 	SigHash         []SigHash        `json:"sighash,omitempty"`
 	Status          *uint8           `json:"status,omitempty"`
 	Kind            []uint8          `json:"type,omitempty"`
 	ContractAddress []common.Address `json:"contract_address,omitempty"`
+	// AuthorizationList matches EIP-7702 transactions carrying any of the selected authorizations.
+	AuthorizationList []AuthorizationSelection `json:"authorization_list,omitempty"`
+}
+
+// AuthorizationSelection selects EIP-7702 authorizations by chain id and delegated address.
+// An empty list matches any value.
+type AuthorizationSelection struct {
+	ChainID []uint64         `json:"chain_id,omitempty"`
+	Address []common.Address `json:"address,omitempty"`
 }
 
 // Transaction represents an Ethereum transaction object.
@@ -59,7 +82,7 @@ type Transaction struct {
 	// Added as EIP-155: Simple replay attack protection
 	ChainID *big.Int `json:"chain_id,omitempty"`
 	// The accessList specifies a list of addresses and storage keys; these addresses and storage keys are added into the `accessed_addresses` and `accessed_storage_keys` global sets (introduced in EIP-2929). A gas cost is charged, though at a discount relative to the cost of accessing outside the list.
-	AccessList *[]types.AccessList `json:"access_list,omitempty"`
+	AccessList *types.AccessList `json:"access_list,omitempty"`
 	// Max fee per data gas aka BlobFeeCap or blobGasFeeCap
 	MaxFeePerBlobGas *big.Int `json:"max_fee_per_blob_gas,omitempty"`
 	// It contains a vector of fixed size hash(32 bytes)
@@ -90,13 +113,447 @@ type Transaction struct {
 	L1FeeScalar *float64 `json:"l1_fee_scalar,omitempty"`
 	// Amount of gas spent on L1 calldata in units of L2 gas.
 	GasUsedForL1 *uint64 `json:"gas_used_for_l1,omitempty"`
+	// The EIP-7702 authorizations delegating the code of their signers to a contract.
+	AuthorizationList *[]Authorization `json:"authorization_list,omitempty"`
+	// The blob gas used by an EIP-4844 transaction.
+	BlobGasUsed *uint64 `json:"blob_gas_used,omitempty"`
+	// The price paid per unit of blob gas by an EIP-4844 transaction.
+	BlobGasPrice *big.Int `json:"blob_gas_price,omitempty"`
+	// OP-stack deposit transactions: the hash uniquely identifying the origin of the deposit.
+	SourceHash *common.Hash `json:"source_hash,omitempty"`
+	// OP-stack deposit transactions: the ETH value minted on L2.
+	Mint *big.Int `json:"mint,omitempty"`
+	// OP-stack deposit transactions: whether the transaction is a system transaction, disabled since Regolith.
+	IsSystemTx *bool `json:"is_system_tx,omitempty"`
+	// OP-stack deposit receipts: the nonce of the deposit sender, since Regolith.
+	DepositNonce *uint64 `json:"deposit_nonce,omitempty"`
+	// OP-stack deposit receipts: the receipt version, since Canyon.
+	DepositReceiptVersion *uint64 `json:"deposit_receipt_version,omitempty"`
+	// OP-stack receipts: the L1 base fee scalar, since Ecotone.
+	L1BaseFeeScalar *uint64 `json:"l1_base_fee_scalar,omitempty"`
+	// OP-stack receipts: the L1 blob base fee, since Ecotone.
+	L1BlobBaseFee *big.Int `json:"l1_blob_base_fee,omitempty"`
+	// OP-stack receipts: the L1 blob base fee scalar, since Ecotone.
+	L1BlobBaseFeeScalar *uint64 `json:"l1_blob_base_fee_scalar,omitempty"`
+	// Arbitrum receipts: the L1 block number the transaction was sequenced against.
+	L1BlockNumber *big.Int `json:"l1_block_number,omitempty"`
+}
+
+// Authorization is an EIP-7702 authorization, signed by an account to delegate its code to Address.
+type Authorization struct {
+	// ChainID is the chain the authorization is valid on, zero for any chain.
+	ChainID *big.Int `json:"chain_id"`
+	// Address is the contract the signer delegates its code to.
+	Address common.Address `json:"address"`
+	// Nonce is the nonce of the signer the authorization is valid at.
+	Nonce uint64 `json:"nonce"`
+	// YParity is the parity of the signature y coordinate.
+	YParity uint8 `json:"y_parity"`
+	// R is the R field of the signature.
+	R *big.Int `json:"r"`
+	// S is the S field of the signature.
+	S *big.Int `json:"s"`
 }
 
 func (t *Transaction) Data() []byte {
 	return *t.Input
 }
 
+// ToGeth converts the transaction into a signed go-ethereum transaction of its type: legacy, EIP-2930,
+// EIP-1559, EIP-4844 or EIP-7702. Other types, such as OP-stack deposits, fail with an error wrapping
+// errorshs.ErrUnsupportedTransactionType. The fields the transaction type signs over and the signature must
+// have been selected, otherwise a *MissingFieldsError listing the missing ones is returned. A missing access
+// list is treated as empty, so it must be selected for the hash of transactions carrying one to match.
+func (t *Transaction) ToGeth() (*types.Transaction, error) {
+	if t.Kind == nil {
+		return nil, &MissingFieldsError{Entity: "transaction", Fields: []string{"type"}}
+	}
+
+	missing := &MissingFieldsError{Entity: "transaction"}
+	missing.require("nonce", t.Nonce != nil)
+	missing.require("gas", t.Gas != nil)
+	missing.require("value", t.Value != nil)
+	missing.require("input", t.Input != nil)
+	missing.require("r", t.R != nil)
+	missing.require("s", t.S != nil)
+
+	kind := *t.Kind
+	switch kind {
+	case types.LegacyTxType:
+		missing.require("gas_price", t.GasPrice != nil)
+		missing.require("v", t.V != nil)
+	case types.AccessListTxType:
+		missing.require("chain_id", t.ChainID != nil)
+		missing.require("gas_price", t.GasPrice != nil)
+		missing.require("y_parity", t.yParity() != nil)
+	case types.DynamicFeeTxType:
+		missing.require("chain_id", t.ChainID != nil)
+		missing.require("max_priority_fee_per_gas", t.MaxPriorityFeePerGas != nil)
+		missing.require("max_fee_per_gas", t.MaxFeePerGas != nil)
+		missing.require("y_parity", t.yParity() != nil)
+	case types.BlobTxType:
+		missing.require("chain_id", t.ChainID != nil)
+		missing.require("to", t.To != nil)
+		missing.require("max_priority_fee_per_gas", t.MaxPriorityFeePerGas != nil)
+		missing.require("max_fee_per_gas", t.MaxFeePerGas != nil)
+		missing.require("max_fee_per_blob_gas", t.MaxFeePerBlobGas != nil)
+		missing.require("blob_versioned_hashes", t.BlobVersionedHashes != nil)
+		missing.require("y_parity", t.yParity() != nil)
+	case types.SetCodeTxType:
+		missing.require("chain_id", t.ChainID != nil)
+		missing.require("to", t.To != nil)
+		missing.require("max_priority_fee_per_gas", t.MaxPriorityFeePerGas != nil)
+		missing.require("max_fee_per_gas", t.MaxFeePerGas != nil)
+		missing.require("authorization_list", t.AuthorizationList != nil)
+		missing.require("y_parity", t.yParity() != nil)
+	default:
+		return nil, fmt.Errorf("%w: %d", errorshs.ErrUnsupportedTransactionType, kind)
+	}
+
+	if err := missing.orNil(); err != nil {
+		return nil, err
+	}
+
+	data := append([]byte{}, *t.Input...)
+	accessList := types.AccessList{}
+	if t.AccessList != nil {
+		accessList = append(accessList, *t.AccessList...)
+	}
+
+	var inner types.TxData
+	switch kind {
+	case types.LegacyTxType:
+		inner = &types.LegacyTx{
+			Nonce:    *t.Nonce,
+			GasPrice: new(big.Int).Set(t.GasPrice),
+			Gas:      *t.Gas,
+			To:       copyAddress(t.To),
+			Value:    new(big.Int).Set(t.Value),
+			Data:     data,
+			V:        new(big.Int).Set(t.V),
+			R:        new(big.Int).Set(t.R),
+			S:        new(big.Int).Set(t.S),
+		}
+	case types.AccessListTxType:
+		inner = &types.AccessListTx{
+			ChainID:    new(big.Int).Set(t.ChainID),
+			Nonce:      *t.Nonce,
+			GasPrice:   new(big.Int).Set(t.GasPrice),
+			Gas:        *t.Gas,
+			To:         copyAddress(t.To),
+			Value:      new(big.Int).Set(t.Value),
+			Data:       data,
+			AccessList: accessList,
+			V:          new(big.Int).Set(t.yParity()),
+			R:          new(big.Int).Set(t.R),
+			S:          new(big.Int).Set(t.S),
+		}
+	case types.DynamicFeeTxType:
+		inner = &types.DynamicFeeTx{
+			ChainID:    new(big.Int).Set(t.ChainID),
+			Nonce:      *t.Nonce,
+			GasTipCap:  new(big.Int).Set(t.MaxPriorityFeePerGas),
+			GasFeeCap:  new(big.Int).Set(t.MaxFeePerGas),
+			Gas:        *t.Gas,
+			To:         copyAddress(t.To),
+			Value:      new(big.Int).Set(t.Value),
+			Data:       data,
+			AccessList: accessList,
+			V:          new(big.Int).Set(t.yParity()),
+			R:          new(big.Int).Set(t.R),
+			S:          new(big.Int).Set(t.S),
+		}
+	case types.BlobTxType:
+		values, err := toUint256(map[string]*big.Int{
+			"chain_id":                 t.ChainID,
+			"max_priority_fee_per_gas": t.MaxPriorityFeePerGas,
+			"max_fee_per_gas":          t.MaxFeePerGas,
+			"max_fee_per_blob_gas":     t.MaxFeePerBlobGas,
+			"value":                    t.Value,
+			"y_parity":                 t.yParity(),
+			"r":                        t.R,
+			"s":                        t.S,
+		})
+		if err != nil {
+			return nil, err
+		}
+		inner = &types.BlobTx{
+			ChainID:    values["chain_id"],
+			Nonce:      *t.Nonce,
+			GasTipCap:  values["max_priority_fee_per_gas"],
+			GasFeeCap:  values["max_fee_per_gas"],
+			Gas:        *t.Gas,
+			To:         *t.To,
+			Value:      values["value"],
+			Data:       data,
+			AccessList: accessList,
+			BlobFeeCap: values["max_fee_per_blob_gas"],
+			BlobHashes: append([]common.Hash{}, *t.BlobVersionedHashes...),
+			V:          values["y_parity"],
+			R:          values["r"],
+			S:          values["s"],
+		}
+	case types.SetCodeTxType:
+		values, err := toUint256(map[string]*big.Int{
+			"chain_id":                 t.ChainID,
+			"max_priority_fee_per_gas": t.MaxPriorityFeePerGas,
+			"max_fee_per_gas":          t.MaxFeePerGas,
+			"value":                    t.Value,
+			"y_parity":                 t.yParity(),
+			"r":                        t.R,
+			"s":                        t.S,
+		})
+		if err != nil {
+			return nil, err
+		}
+		authorizations, err := t.setCodeAuthorizations()
+		if err != nil {
+			return nil, err
+		}
+		inner = &types.SetCodeTx{
+			ChainID:    values["chain_id"],
+			Nonce:      *t.Nonce,
+			GasTipCap:  values["max_priority_fee_per_gas"],
+			GasFeeCap:  values["max_fee_per_gas"],
+			Gas:        *t.Gas,
+			To:         *t.To,
+			Value:      values["value"],
+			Data:       data,
+			AccessList: accessList,
+			AuthList:   authorizations,
+			V:          values["y_parity"],
+			R:          values["r"],
+			S:          values["s"],
+		}
+	}
+
+	return types.NewTx(inner), nil
+}
+
+// setCodeAuthorizations converts the EIP-7702 authorizations of the transaction into go-ethereum ones.
+func (t *Transaction) setCodeAuthorizations() ([]types.SetCodeAuthorization, error) {
+	toReturn := make([]types.SetCodeAuthorization, 0, len(*t.AuthorizationList))
+	for i, authorization := range *t.AuthorizationList {
+		values, err := toUint256(map[string]*big.Int{
+			"chain_id": authorization.ChainID,
+			"r":        authorization.R,
+			"s":        authorization.S,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid authorization %d", i)
+		}
+		toReturn = append(toReturn, types.SetCodeAuthorization{
+			ChainID: *values["chain_id"],
+			Address: authorization.Address,
+			Nonce:   authorization.Nonce,
+			V:       authorization.YParity,
+			R:       *values["r"],
+			S:       *values["s"],
+		})
+	}
+	return toReturn, nil
+}
+
+// ToReceipt assembles the go-ethereum receipt of the transaction from its receipt fields and logs. The logs
+// of the transaction are picked out of logs by transaction hash or, when the log hash was not selected, by
+// block number and transaction index, and are sorted by log index. The logs bloom of the transaction is used
+// when selected and computed from the logs otherwise, and so is the blob gas used, computed from the blob
+// versioned hashes. The type, hash, gas used, cumulative gas used and
+// either the status or the post-state root must have been selected, otherwise a *MissingFieldsError is returned.
+func (t *Transaction) ToReceipt(logs []Log) (*types.Receipt, error) {
+	missing := &MissingFieldsError{Entity: "transaction receipt"}
+	missing.require("type", t.Kind != nil)
+	missing.require("hash", t.Hash != nil)
+	missing.require("gas_used", t.GasUsed != nil)
+	missing.require("cumulative_gas_used", t.CumulativeGasUsed != nil)
+	missing.require("status", t.Status != nil || t.Root != nil)
+	if err := missing.orNil(); err != nil {
+		return nil, err
+	}
+
+	receipt := &types.Receipt{
+		Type:              *t.Kind,
+		CumulativeGasUsed: *t.CumulativeGasUsed,
+		TxHash:            *t.Hash,
+		GasUsed:           *t.GasUsed,
+		Logs:              make([]*types.Log, 0),
+	}
+
+	if t.Status != nil {
+		receipt.Status = uint64(*t.Status)
+	}
+	if t.Root != nil {
+		receipt.PostState = t.Root.Bytes()
+	}
+	if t.ContractAddress != nil {
+		receipt.ContractAddress = *t.ContractAddress
+	}
+	if t.EffectiveGasPrice != nil {
+		receipt.EffectiveGasPrice = new(big.Int).Set(t.EffectiveGasPrice)
+	}
+	switch {
+	case t.BlobGasUsed != nil:
+		receipt.BlobGasUsed = *t.BlobGasUsed
+	case t.BlobVersionedHashes != nil:
+		receipt.BlobGasUsed = uint64(len(*t.BlobVersionedHashes)) * params.BlobTxBlobGasPerBlob
+	}
+	if t.BlockHash != nil {
+		receipt.BlockHash = *t.BlockHash
+	}
+	if t.BlockNumber != nil {
+		receipt.BlockNumber = new(big.Int).Set(t.BlockNumber)
+	}
+	if t.TransactionIndex != nil {
+		receipt.TransactionIndex = uint(*t.TransactionIndex)
+	}
+
+	for i := range logs {
+		if !t.emitted(&logs[i]) {
+			continue
+		}
+		log, err := logs[i].ToGeth()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to convert log %d of transaction %s", logs[i].Index(), t.Hash.Hex())
+		}
+		receipt.Logs = append(receipt.Logs, log)
+	}
+	sort.SliceStable(receipt.Logs, func(i, j int) bool {
+		return receipt.Logs[i].Index < receipt.Logs[j].Index
+	})
+
+	if t.LogsBloom != nil {
+		receipt.Bloom = types.BytesToBloom([]byte(*t.LogsBloom))
+	} else {
+		receipt.Bloom = types.CreateBloom(receipt)
+	}
+
+	return receipt, nil
+}
+
+// emitted reports whether the log was emitted by the transaction.
+func (t *Transaction) emitted(log *Log) bool {
+	if log.TransactionHash != nil {
+		return *log.TransactionHash == *t.Hash
+	}
+	if log.BlockNumber == nil || log.TransactionIndex == nil || t.BlockNumber == nil || t.TransactionIndex == nil {
+		return false
+	}
+	return log.BlockNumber.Cmp(t.BlockNumber) == 0 && *log.TransactionIndex == *t.TransactionIndex
+}
+
+// yParity returns the signature parity of a typed transaction, falling back to v which carries the parity
+// for typed transactions.
+func (t *Transaction) yParity() *big.Int {
+	if t.YParity != nil {
+		return t.YParity
+	}
+	return t.V
+}
+
+// NewTransactionFromGeth converts a go-ethereum transaction included at the given block and index into a
+// transaction holding the fields HyperSync returns for it, the inverse of ToGeth. The sender is recovered
+// with the signer. Receipt fields are left unset.
+func NewTransactionFromGeth(tx *types.Transaction, signer types.Signer, blockNumber uint64, index uint64) (*Transaction, error) {
+	from, err := types.Sender(signer, tx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to recover transaction sender")
+	}
+
+	hash := tx.Hash()
+	kind := tx.Type()
+	nonce := tx.Nonce()
+	gas := tx.Gas()
+	input := tx.Data()
+	v, r, s := tx.RawSignatureValues()
+
+	toReturn := &Transaction{
+		BlockNumber:      new(big.Int).SetUint64(blockNumber),
+		TransactionIndex: &index,
+		Hash:             &hash,
+		From:             &from,
+		Kind:             &kind,
+		Nonce:            &nonce,
+		Gas:              &gas,
+		To:               copyAddress(tx.To()),
+		Value:            tx.Value(),
+		Input:            &input,
+		V:                v,
+		R:                r,
+		S:                s,
+	}
+
+	if kind != types.LegacyTxType {
+		accessList := tx.AccessList()
+		toReturn.ChainID = tx.ChainId()
+		toReturn.AccessList = &accessList
+		toReturn.YParity = new(big.Int).Set(v)
+	}
+	switch kind {
+	case types.LegacyTxType, types.AccessListTxType:
+		toReturn.GasPrice = tx.GasPrice()
+	case types.BlobTxType:
+		hashes := tx.BlobHashes()
+		toReturn.MaxFeePerBlobGas = tx.BlobGasFeeCap()
+		toReturn.BlobVersionedHashes = &hashes
+		fallthrough
+	default:
+		toReturn.MaxPriorityFeePerGas = tx.GasTipCap()
+		toReturn.MaxFeePerGas = tx.GasFeeCap()
+	}
+	if kind == types.SetCodeTxType {
+		authorizations := make([]Authorization, 0, len(tx.SetCodeAuthorizations()))
+		for _, authorization := range tx.SetCodeAuthorizations() {
+			authorizations = append(authorizations, Authorization{
+				ChainID: authorization.ChainID.ToBig(),
+				Address: authorization.Address,
+				Nonce:   authorization.Nonce,
+				YParity: authorization.V,
+				R:       authorization.R.ToBig(),
+				S:       authorization.S.ToBig(),
+			})
+		}
+		toReturn.AuthorizationList = &authorizations
+	}
+
+	return toReturn, nil
+}
+
+// copyAddress returns a copy of the address, or nil for contract creations.
+func copyAddress(addr *common.Address) *common.Address {
+	if addr == nil {
+		return nil
+	}
+	toReturn := *addr
+	return &toReturn
+}
+
+// toUint256 converts the named values into 256-bit integers, failing on negative or overflowing values.
+func toUint256(values map[string]*big.Int) (map[string]*uint256.Int, error) {
+	toReturn := make(map[string]*uint256.Int, len(values))
+	for name, value := range values {
+		converted, overflow := uint256.FromBig(value)
+		if overflow || value.Sign() < 0 {
+			return nil, fmt.Errorf("transaction field %s does not fit into 256 bits: %s", name, value)
+		}
+		toReturn[name] = converted
+	}
+	return toReturn, nil
+}
+
+// NewTransactionFromRecord decodes the first row of the record into a transaction. It decodes in lenient mode, skipping
+// columns of an unexpected type or with an unknown name; use NewTransactionFromRecordWithMode to decode strictly.
 func NewTransactionFromRecord(schema *arrow.Schema, record arrow.Record) (*Transaction, error) {
+	return NewTransactionFromRecordWithMode(schema, record, DecodeLenient)
+}
+
+// NewTransactionFromRecordWithMode decodes the first row of the record into a transaction in the given mode. Use a Decoder
+// to read the warnings of lenient decoding.
+func NewTransactionFromRecordWithMode(schema *arrow.Schema, record arrow.Record, mode DecodeMode) (*Transaction, error) {
+	return NewDecoder(mode).Transaction(schema, record)
+}
+
+// Transaction decodes the first row of the record into a transaction according to the decoder mode.
+func (d *Decoder) Transaction(schema *arrow.Schema, record arrow.Record) (*Transaction, error) {
 	if record.NumCols() != int64(len(schema.Fields())) {
 		return nil, errors.New("number of columns in record does not match schema")
 	}
@@ -109,190 +566,111 @@ func NewTransactionFromRecord(schema *arrow.Schema, record arrow.Record) (*Trans
 			continue
 		}
 
+		var err error
 		switch field.Name {
 		case "block_hash":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				blockHash := common.BytesToHash(val)
-				toReturn.BlockHash = &blockHash
-			}
+			toReturn.BlockHash, err = hashFromColumn(col, 0)
 		case "block_number":
-			if fCol, ok := col.(*array.Uint64); ok {
-				val := fCol.Value(0)
-				toReturn.BlockNumber = big.NewInt(0).SetUint64(val)
-			}
+			toReturn.BlockNumber, err = bigIntFromColumn(col, 0)
 		case "from":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				from := common.BytesToAddress(val)
-				toReturn.From = &from
-			}
+			toReturn.From, err = addressFromColumn(col, 0)
 		case "gas":
-			if fCol, ok := col.(*array.Uint64); ok {
-				val := fCol.Value(0)
-				toReturn.Gas = &val
-			}
+			toReturn.Gas, err = uint64FromColumn(col, 0)
 		case "gas_price":
-			if fCol, ok := col.(*array.Uint64); ok {
-				val := fCol.Value(0)
-				toReturn.GasPrice = big.NewInt(0).SetUint64(val)
-			}
+			toReturn.GasPrice, err = bigIntFromColumn(col, 0)
 		case "sighash":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				hash := common.BytesToHash(val)
-				toReturn.SigHash = &hash
-			}
+			toReturn.SigHash, err = hashFromColumn(col, 0)
 		case "hash":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				hash := common.BytesToHash(val)
-				toReturn.Hash = &hash
-			}
+			toReturn.Hash, err = hashFromColumn(col, 0)
 		case "input":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				toReturn.Input = &val
-			}
+			toReturn.Input, err = bytesFromColumn(col, 0)
 		case "nonce":
-			if fCol, ok := col.(*array.Uint64); ok {
-				val := fCol.Value(0)
-				toReturn.Nonce = &val
-			}
+			toReturn.Nonce, err = uint64FromColumn(col, 0)
 		case "to":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				to := common.BytesToAddress(val)
-				toReturn.To = &to
-			}
+			toReturn.To, err = addressFromColumn(col, 0)
 		case "transaction_index":
-			if fCol, ok := col.(*array.Uint64); ok {
-				val := fCol.Value(0)
-				toReturn.TransactionIndex = &val
-			}
+			toReturn.TransactionIndex, err = uint64FromColumn(col, 0)
 		case "value":
-			if fCol, ok := col.(*array.Uint64); ok {
-				val := fCol.Value(0)
-				toReturn.Value = big.NewInt(0).SetUint64(val)
-			}
+			toReturn.Value, err = bigIntFromColumn(col, 0)
 		case "v":
-			if fCol, ok := col.(*array.Uint64); ok {
-				val := fCol.Value(0)
-				toReturn.V = big.NewInt(0).SetUint64(val)
-			}
+			toReturn.V, err = bigIntFromColumn(col, 0)
 		case "r":
-			if fCol, ok := col.(*array.Uint64); ok {
-				val := fCol.Value(0)
-				toReturn.R = big.NewInt(0).SetUint64(val)
-			}
+			toReturn.R, err = bigIntFromColumn(col, 0)
 		case "s":
-			if fCol, ok := col.(*array.Uint64); ok {
-				val := fCol.Value(0)
-				toReturn.S = big.NewInt(0).SetUint64(val)
-			}
+			toReturn.S, err = bigIntFromColumn(col, 0)
 		case "y_parity":
-			if fCol, ok := col.(*array.Uint64); ok {
-				val := fCol.Value(0)
-				toReturn.YParity = big.NewInt(0).SetUint64(val)
-			}
+			toReturn.YParity, err = bigIntFromColumn(col, 0)
 		case "max_priority_fee_per_gas":
-			if fCol, ok := col.(*array.Uint64); ok {
-				val := fCol.Value(0)
-				toReturn.MaxPriorityFeePerGas = big.NewInt(0).SetUint64(val)
-			}
+			toReturn.MaxPriorityFeePerGas, err = bigIntFromColumn(col, 0)
 		case "max_fee_per_gas":
-			if fCol, ok := col.(*array.Uint64); ok {
-				val := fCol.Value(0)
-				toReturn.MaxFeePerGas = big.NewInt(0).SetUint64(val)
-			}
+			toReturn.MaxFeePerGas, err = bigIntFromColumn(col, 0)
 		case "chain_id":
-			if fCol, ok := col.(*array.Uint64); ok {
-				val := fCol.Value(0)
-				toReturn.ChainID = big.NewInt(0).SetUint64(val)
-			}
+			toReturn.ChainID, err = bigIntFromColumn(col, 0)
 		case "access_list":
-			if fCol, ok := col.(*array.List); ok {
-				_ = fCol
-			}
+			toReturn.AccessList, err = accessListFromColumn(col, 0)
 		case "max_fee_per_blob_gas":
-			if fCol, ok := col.(*array.Uint64); ok {
-				val := fCol.Value(0)
-				toReturn.MaxFeePerBlobGas = big.NewInt(0).SetUint64(val)
-			}
+			toReturn.MaxFeePerBlobGas, err = bigIntFromColumn(col, 0)
 		case "blob_versioned_hashes":
-			if fCol, ok := col.(*array.List); ok {
-				_ = fCol
-			}
+			toReturn.BlobVersionedHashes, err = hashesFromColumn(col, 0)
 		case "cumulative_gas_used":
-			if fCol, ok := col.(*array.Uint64); ok {
-				val := fCol.Value(0)
-				toReturn.CumulativeGasUsed = &val
-			}
+			toReturn.CumulativeGasUsed, err = uint64FromColumn(col, 0)
 		case "effective_gas_price":
-			if fCol, ok := col.(*array.Uint64); ok {
-				val := fCol.Value(0)
-				toReturn.EffectiveGasPrice = big.NewInt(0).SetUint64(val)
-			}
+			toReturn.EffectiveGasPrice, err = bigIntFromColumn(col, 0)
 		case "gas_used":
-			if fCol, ok := col.(*array.Uint64); ok {
-				val := fCol.Value(0)
-				toReturn.GasUsed = &val
-			}
+			toReturn.GasUsed, err = uint64FromColumn(col, 0)
 		case "contract_address":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				contractAddress := common.BytesToAddress(val)
-				toReturn.ContractAddress = &contractAddress
-			}
+			toReturn.ContractAddress, err = addressFromColumn(col, 0)
 		case "logs_bloom":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				logsBloom := BloomFilter(val)
+			var val *[]byte
+			if val, err = bytesFromColumn(col, 0); err == nil && val != nil {
+				logsBloom := BloomFilter(*val)
 				toReturn.LogsBloom = &logsBloom
 			}
 		case "type":
-			if fCol, ok := col.(*array.Uint8); ok {
-				val := fCol.Value(0)
-				toReturn.Kind = &val
-			}
+			toReturn.Kind, err = uint8FromColumn(col, 0)
 		case "root":
-			if fCol, ok := col.(*array.Binary); ok {
-				val := fCol.Value(0)
-				root := common.BytesToHash(val)
-				toReturn.Root = &root
-			}
+			toReturn.Root, err = hashFromColumn(col, 0)
 		case "status":
-			if fCol, ok := col.(*array.Uint8); ok {
-				val := fCol.Value(0)
-				toReturn.Status = &val
-			}
+			toReturn.Status, err = uint8FromColumn(col, 0)
 		case "l1_fee":
-			if fCol, ok := col.(*array.Uint64); ok {
-				val := fCol.Value(0)
-				toReturn.L1Fee = big.NewInt(0).SetUint64(val)
-			}
+			toReturn.L1Fee, err = bigIntFromColumn(col, 0)
 		case "l1_gas_price":
-			if fCol, ok := col.(*array.Uint64); ok {
-				val := fCol.Value(0)
-				toReturn.L1GasPrice = big.NewInt(0).SetUint64(val)
-			}
+			toReturn.L1GasPrice, err = bigIntFromColumn(col, 0)
 		case "l1_gas_used":
-			if fCol, ok := col.(*array.Uint64); ok {
-				val := fCol.Value(0)
-				toReturn.L1GasUsed = &val
-			}
+			toReturn.L1GasUsed, err = uint64FromColumn(col, 0)
 		case "l1_fee_scalar":
-			if fCol, ok := col.(*array.Float64); ok {
-				val := fCol.Value(0)
-				toReturn.L1FeeScalar = &val
-			}
+			toReturn.L1FeeScalar, err = float64FromColumn(col, 0)
 		case "gas_used_for_l1":
-			if fCol, ok := col.(*array.Uint64); ok {
-				val := fCol.Value(0)
-				toReturn.GasUsedForL1 = &val
-			}
+			toReturn.GasUsedForL1, err = uint64FromColumn(col, 0)
+		case "authorization_list":
+			toReturn.AuthorizationList, err = authorizationListFromColumn(col, 0)
+		case "blob_gas_used":
+			toReturn.BlobGasUsed, err = uint64FromColumn(col, 0)
+		case "blob_gas_price":
+			toReturn.BlobGasPrice, err = bigIntFromColumn(col, 0)
+		case "source_hash":
+			toReturn.SourceHash, err = hashFromColumn(col, 0)
+		case "mint":
+			toReturn.Mint, err = bigIntFromColumn(col, 0)
+		case "is_system_tx":
+			toReturn.IsSystemTx, err = boolFromColumn(col, 0)
+		case "deposit_nonce":
+			toReturn.DepositNonce, err = uint64FromColumn(col, 0)
+		case "deposit_receipt_version":
+			toReturn.DepositReceiptVersion, err = uint64FromColumn(col, 0)
+		case "l1_base_fee_scalar":
+			toReturn.L1BaseFeeScalar, err = uint64FromColumn(col, 0)
+		case "l1_blob_base_fee":
+			toReturn.L1BlobBaseFee, err = bigIntFromColumn(col, 0)
+		case "l1_blob_base_fee_scalar":
+			toReturn.L1BlobBaseFeeScalar, err = uint64FromColumn(col, 0)
+		case "l1_block_number":
+			toReturn.L1BlockNumber, err = bigIntFromColumn(col, 0)
 		default:
-			return nil, errors.New("unsupported field: " + field.Name)
+			err = &UnknownColumnError{Name: field.Name}
+		}
+		if err = d.check("transaction", field, err); err != nil {
+			return nil, err
 		}
 	}
 
@@ -0,0 +1,270 @@
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/apache/arrow/go/v10/arrow"
+	"github.com/apache/arrow/go/v10/arrow/array"
+	"github.com/apache/arrow/go/v10/arrow/memory"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionToGeth(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+
+	chainID := big.NewInt(1)
+	to := common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7")
+	accessList := types.AccessList{
+		{Address: to, StorageKeys: []common.Hash{common.HexToHash("0x01"), common.HexToHash("0x02")}},
+	}
+
+	tests := []struct {
+		name string
+		tx   types.TxData
+	}{
+		{
+			name: "Legacy",
+			tx:   &types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(20e9), Gas: 21000, To: &to, Value: big.NewInt(1e18)},
+		},
+		{
+			name: "Legacy contract creation",
+			tx:   &types.LegacyTx{Nonce: 2, GasPrice: big.NewInt(20e9), Gas: 500000, Value: big.NewInt(0), Data: []byte{0x60, 0x80}},
+		},
+		{
+			name: "EIP-2930",
+			tx:   &types.AccessListTx{ChainID: chainID, Nonce: 3, GasPrice: big.NewInt(20e9), Gas: 50000, To: &to, Value: big.NewInt(0), Data: []byte{0xa9, 0x05, 0x9c, 0xbb}, AccessList: accessList},
+		},
+		{
+			name: "EIP-1559",
+			tx:   &types.DynamicFeeTx{ChainID: chainID, Nonce: 4, GasTipCap: big.NewInt(1e9), GasFeeCap: big.NewInt(30e9), Gas: 50000, To: &to, Value: big.NewInt(5), AccessList: accessList},
+		},
+		{
+			name: "EIP-4844",
+			tx: &types.BlobTx{
+				ChainID:    uint256.MustFromBig(chainID),
+				Nonce:      5,
+				GasTipCap:  uint256.NewInt(1e9),
+				GasFeeCap:  uint256.NewInt(30e9),
+				Gas:        21000,
+				To:         to,
+				Value:      uint256.NewInt(0),
+				BlobFeeCap: uint256.NewInt(1e9),
+				BlobHashes: []common.Hash{common.HexToHash("0x01aa"), common.HexToHash("0x01bb")},
+			},
+		},
+		{
+			name: "EIP-7702",
+			tx: &types.SetCodeTx{
+				ChainID:   uint256.MustFromBig(chainID),
+				Nonce:     6,
+				GasTipCap: uint256.NewInt(1e9),
+				GasFeeCap: uint256.NewInt(30e9),
+				Gas:       60000,
+				To:        sender,
+				Value:     uint256.NewInt(0),
+				AuthList: []types.SetCodeAuthorization{
+					{ChainID: *uint256.NewInt(1), Address: to, Nonce: 7, V: 1, R: *uint256.NewInt(8), S: *uint256.NewInt(9)},
+				},
+			},
+		},
+	}
+
+	signer := types.LatestSignerForChainID(chainID)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signed, sErr := types.SignNewTx(key, signer, tt.tx)
+			require.NoError(t, sErr)
+
+			tx, tErr := NewTransactionFromGeth(signed, signer, 1, 0)
+			require.NoError(t, tErr)
+			require.Equal(t, sender, *tx.From)
+
+			converted, cErr := tx.ToGeth()
+			require.NoError(t, cErr)
+			require.Equal(t, signed.Type(), converted.Type())
+			require.Equal(t, signed.Hash(), converted.Hash())
+
+			from, fErr := types.Sender(signer, converted)
+			require.NoError(t, fErr)
+			require.Equal(t, sender, from)
+		})
+	}
+}
+
+func TestTransactionToGethMissingFields(t *testing.T) {
+	var missing *MissingFieldsError
+
+	_, err := (&Transaction{}).ToGeth()
+	require.ErrorAs(t, err, &missing)
+	require.Equal(t, []string{"type"}, missing.Fields)
+
+	kind := uint8(types.DynamicFeeTxType)
+	nonce := uint64(1)
+	_, err = (&Transaction{Kind: &kind, Nonce: &nonce}).ToGeth()
+	require.ErrorAs(t, err, &missing)
+	require.Equal(t, "transaction", missing.Entity)
+	require.Contains(t, missing.Fields, "max_fee_per_gas")
+	require.Contains(t, missing.Fields, "chain_id")
+	require.NotContains(t, missing.Fields, "nonce")
+	require.NotContains(t, missing.Fields, "gas_price")
+
+	kind = uint8(0x7f)
+	_, err = (&Transaction{Kind: &kind}).ToGeth()
+	require.EqualError(t, err, "unsupported transaction type: 127")
+}
+
+func TestTransactionToReceipt(t *testing.T) {
+	txHash := common.HexToHash("0xabc1")
+	otherHash := common.HexToHash("0xabc2")
+	blockHash := common.HexToHash("0xb10c")
+	addr := common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7")
+	kind := uint8(types.DynamicFeeTxType)
+	status := uint8(1)
+	gasUsed, cumulativeGasUsed, txIndex := uint64(46109), uint64(146109), uint64(3)
+
+	tx := Transaction{
+		Kind:              &kind,
+		Hash:              &txHash,
+		BlockHash:         &blockHash,
+		BlockNumber:       big.NewInt(100),
+		TransactionIndex:  &txIndex,
+		Status:            &status,
+		GasUsed:           &gasUsed,
+		CumulativeGasUsed: &cumulativeGasUsed,
+		EffectiveGasPrice: big.NewInt(12e9),
+	}
+
+	newLog := func(hash *common.Hash, index uint64, txIndex uint64) Log {
+		topic := common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+		data := []byte{byte(index)}
+		return Log{
+			Address:          &addr,
+			Topic0:           &topic,
+			Data:             &data,
+			TransactionHash:  hash,
+			BlockNumber:      big.NewInt(100),
+			TransactionIndex: &txIndex,
+			LogIndex:         &index,
+		}
+	}
+
+	logs := []Log{
+		newLog(&txHash, 11, 3),
+		newLog(&otherHash, 10, 2),
+		newLog(&txHash, 9, 3),
+		newLog(nil, 12, 3),
+		newLog(nil, 13, 4),
+	}
+
+	receipt, err := tx.ToReceipt(logs)
+	require.NoError(t, err)
+	require.Equal(t, uint8(types.DynamicFeeTxType), receipt.Type)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	require.Equal(t, txHash, receipt.TxHash)
+	require.Equal(t, blockHash, receipt.BlockHash)
+	require.Equal(t, uint(3), receipt.TransactionIndex)
+	require.Equal(t, gasUsed, receipt.GasUsed)
+	require.Equal(t, cumulativeGasUsed, receipt.CumulativeGasUsed)
+
+	require.Len(t, receipt.Logs, 3)
+	for i, index := range []uint{9, 11, 12} {
+		require.Equal(t, index, receipt.Logs[i].Index)
+	}
+	require.Equal(t, types.CreateBloom(receipt), receipt.Bloom)
+	require.True(t, receipt.Bloom.Test(addr.Bytes()))
+
+	// The blob gas used is taken as selected and derived from the blob hashes otherwise.
+	blobKind := uint8(types.BlobTxType)
+	hashes := []common.Hash{common.HexToHash("0x01aa"), common.HexToHash("0x01bb")}
+	blobTx := tx
+	blobTx.Kind, blobTx.BlobVersionedHashes = &blobKind, &hashes
+	receipt, err = blobTx.ToReceipt(nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2*params.BlobTxBlobGasPerBlob), receipt.BlobGasUsed)
+
+	blobGasUsed := uint64(params.BlobTxBlobGasPerBlob)
+	blobTx.BlobGasUsed = &blobGasUsed
+	receipt, err = blobTx.ToReceipt(nil)
+	require.NoError(t, err)
+	require.Equal(t, blobGasUsed, receipt.BlobGasUsed)
+
+	var missing *MissingFieldsError
+	_, err = (&Transaction{Kind: &kind}).ToReceipt(logs)
+	require.ErrorAs(t, err, &missing)
+	require.Equal(t, []string{"hash", "gas_used", "cumulative_gas_used", "status"}, missing.Fields)
+}
+
+func TestNewTransactionFromRecordL2Fields(t *testing.T) {
+	sourceHash := common.HexToHash("0x3c4b4b4c7b9d7e5d1c4a4e4f2f1b5d6f0a9b8c7d6e5f4a3b2c1d0e9f8a7b6c5d")
+	mint, _ := new(big.Int).SetString("100000000000000000000", 10)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "type", Type: arrow.PrimitiveTypes.Uint8},
+		{Name: "source_hash", Type: arrow.BinaryTypes.Binary},
+		{Name: "mint", Type: arrow.BinaryTypes.Binary},
+		{Name: "is_system_tx", Type: arrow.FixedWidthTypes.Boolean},
+		{Name: "deposit_nonce", Type: arrow.BinaryTypes.Binary},
+		{Name: "deposit_receipt_version", Type: arrow.BinaryTypes.Binary},
+		{Name: "l1_blob_base_fee", Type: arrow.BinaryTypes.Binary},
+		{Name: "l1_block_number", Type: arrow.PrimitiveTypes.Uint64},
+		{Name: "authorization_list", Type: arrow.BinaryTypes.Binary},
+	}, nil)
+
+	mem := memory.NewGoAllocator()
+	kind := array.NewUint8Builder(mem)
+	kind.Append(DepositTxType)
+	system := array.NewBooleanBuilder(mem)
+	system.Append(false)
+	l1BlockNumber := array.NewUint64Builder(mem)
+	l1BlockNumber.Append(19000000)
+
+	columns := []arrow.Array{
+		kind.NewArray(),
+		newBinaryColumn(sourceHash.Bytes()),
+		newBinaryColumn(mint.Bytes()),
+		system.NewArray(),
+		newBinaryColumn([]byte{0x01, 0x02}),
+		newBinaryColumn([]byte{0x01}),
+		newBinaryColumn([]byte{0x05}),
+		l1BlockNumber.NewArray(),
+		newBinaryColumn(nil),
+	}
+	record := array.NewRecord(schema, columns, 1)
+	defer record.Release()
+
+	tx, err := NewTransactionFromRecord(schema, record)
+	require.NoError(t, err)
+	require.Equal(t, DepositTxType, *tx.Kind)
+	require.Equal(t, sourceHash, *tx.SourceHash)
+	require.Equal(t, mint, tx.Mint)
+	require.False(t, *tx.IsSystemTx)
+	require.Equal(t, uint64(258), *tx.DepositNonce)
+	require.Equal(t, uint64(1), *tx.DepositReceiptVersion)
+	require.Equal(t, big.NewInt(5), tx.L1BlobBaseFee)
+	require.Equal(t, big.NewInt(19000000), tx.L1BlockNumber)
+	require.Nil(t, tx.AuthorizationList)
+
+	_, err = tx.ToGeth()
+	require.EqualError(t, err, "unsupported transaction type: 126")
+}
+
+func TestTransactionSelectionAuthorizationList(t *testing.T) {
+	selection := TransactionSelection{
+		AuthorizationList: []AuthorizationSelection{
+			{ChainID: []uint64{1}, Address: []common.Address{common.HexToAddress("0x63c0c19a282a1B52b07dD5a65b58948A07DAE32B")}},
+		},
+	}
+
+	encoded, err := json.Marshal(selection)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"authorization_list":[{"chain_id":[1],"address":["0x63c0c19a282a1b52b07dd5a65b58948a07dae32b"]}]}`, string(encoded))
+}
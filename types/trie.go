@@ -0,0 +1,152 @@
+package types
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// TrieHasher computes the root of the Merkle Patricia trie of a block list, such as the transactions,
+// receipts or withdrawals root, for use with types.DeriveSha of go-ethereum. It keeps the entries in memory
+// and hashes them at once, which is all block lists need, so the trie package of go-ethereum and the
+// database dependencies it carries are not required.
+//
+// Example:
+//
+//	root := gethtypes.DeriveSha(gethtypes.Transactions(txs), types.NewTrieHasher())
+type TrieHasher struct {
+	entries map[string][]byte
+}
+
+// NewTrieHasher returns an empty trie hasher.
+func NewTrieHasher() *TrieHasher {
+	return &TrieHasher{entries: make(map[string][]byte)}
+}
+
+// Reset removes every entry of the trie.
+func (h *TrieHasher) Reset() {
+	h.entries = make(map[string][]byte)
+}
+
+// Update sets the value of the key, removing the key when the value is empty.
+func (h *TrieHasher) Update(key []byte, value []byte) error {
+	if len(value) == 0 {
+		delete(h.entries, string(key))
+		return nil
+	}
+	h.entries[string(key)] = append([]byte{}, value...)
+	return nil
+}
+
+// Hash returns the root hash of the trie.
+func (h *TrieHasher) Hash() common.Hash {
+	if len(h.entries) == 0 {
+		return types.EmptyRootHash
+	}
+
+	entries := make([]trieEntry, 0, len(h.entries))
+	for key, value := range h.entries {
+		entries = append(entries, trieEntry{path: keyToNibbles([]byte(key)), value: value})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return bytes.Compare(entries[i].path, entries[j].path) < 0
+	})
+
+	return crypto.Keccak256Hash(encodeTrieNode(entries, 0))
+}
+
+// trieEntry is a value of the trie along with the nibbles of its key.
+type trieEntry struct {
+	path  []byte
+	value []byte
+}
+
+// encodeTrieNode returns the RLP encoding of the node holding the entries, which are sorted by path and
+// share their first depth nibbles.
+func encodeTrieNode(entries []trieEntry, depth int) []byte {
+	if len(entries) == 1 {
+		return mustEncodeRLP([]any{compactPath(entries[0].path[depth:], true), entries[0].value})
+	}
+
+	// Sorted entries share the prefix the first and the last entry share.
+	first, last := entries[0].path, entries[len(entries)-1].path
+	shared := depth
+	for shared < len(first) && shared < len(last) && first[shared] == last[shared] {
+		shared++
+	}
+	if shared > depth {
+		return mustEncodeRLP([]any{compactPath(first[depth:shared], false), trieReference(encodeTrieNode(entries, shared))})
+	}
+
+	branch := make([]any, 17)
+	branch[16] = []byte{}
+	for len(entries) > 0 && len(entries[0].path) == depth {
+		branch[16] = entries[0].value
+		entries = entries[1:]
+	}
+	for nibble := byte(0); nibble < 16; nibble++ {
+		end := 0
+		for end < len(entries) && entries[end].path[depth] == nibble {
+			end++
+		}
+		if end == 0 {
+			branch[nibble] = []byte{}
+			continue
+		}
+		branch[nibble] = trieReference(encodeTrieNode(entries[:end], depth+1))
+		entries = entries[end:]
+	}
+	return mustEncodeRLP(branch)
+}
+
+// trieReference returns how a parent refers to a child node: nodes shorter than a hash are embedded, others
+// are referred to by their hash.
+func trieReference(encoded []byte) any {
+	if len(encoded) < common.HashLength {
+		return rlp.RawValue(encoded)
+	}
+	return crypto.Keccak256(encoded)
+}
+
+// keyToNibbles splits the key into its 4-bit nibbles.
+func keyToNibbles(key []byte) []byte {
+	nibbles := make([]byte, 0, len(key)*2)
+	for _, b := range key {
+		nibbles = append(nibbles, b>>4, b&0x0f)
+	}
+	return nibbles
+}
+
+// compactPath returns the hex-prefix encoding of the nibbles, flagging leaves and odd lengths in the first
+// nibble.
+func compactPath(nibbles []byte, leaf bool) []byte {
+	flag := byte(0)
+	if leaf {
+		flag = 2
+	}
+
+	compact := make([]byte, 0, len(nibbles)/2+1)
+	if len(nibbles)%2 == 1 {
+		compact = append(compact, (flag+1)<<4|nibbles[0])
+		nibbles = nibbles[1:]
+	} else {
+		compact = append(compact, flag<<4)
+	}
+	for i := 0; i < len(nibbles); i += 2 {
+		compact = append(compact, nibbles[i]<<4|nibbles[i+1])
+	}
+	return compact
+}
+
+// mustEncodeRLP encodes trie nodes, which only hold byte strings and raw values and cannot fail to encode.
+func mustEncodeRLP(node []any) []byte {
+	encoded, err := rlp.EncodeToBytes(node)
+	if err != nil {
+		panic(err)
+	}
+	return encoded
+}
@@ -0,0 +1,48 @@
+package types
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+// rawList is a derivable list of already encoded items.
+type rawList [][]byte
+
+func (l rawList) Len() int {
+	return len(l)
+}
+
+func (l rawList) EncodeIndex(i int, w *bytes.Buffer) {
+	w.Write(l[i])
+}
+
+func TestTrieHasher(t *testing.T) {
+	tests := []struct {
+		name     string
+		count    int
+		expected common.Hash
+	}{
+		{name: "Empty", count: 0, expected: types.EmptyRootHash},
+		{name: "Single embedded leaf", count: 1, expected: common.HexToHash("0x7da536f7df63a0dfb481590e53be0e3063d9b798925cc3d479a3eb3155d0b394")},
+		{name: "Branch of leaves", count: 3, expected: common.HexToHash("0xc144af7216ec5666b4cca99ed2d1cd454983d1c13c8e3ac8d7b638a6bc1c0206")},
+		{name: "Multi-byte keys", count: 200, expected: common.HexToHash("0x09f3ef3772261d6fa788bf21d351a88d96c9ca902badf5a0931af21df2bb16cd")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Roots computed with the stack trie of go-ethereum.
+			list := make(rawList, tt.count)
+			for i := range list {
+				list[i] = bytes.Repeat([]byte{byte(i)}, 1+i%40)
+			}
+
+			hasher := NewTrieHasher()
+			require.Equal(t, tt.expected, types.DeriveSha(list, hasher))
+			require.Equal(t, tt.expected, types.DeriveSha(list, hasher), "DeriveSha should reset the hasher")
+		})
+	}
+}
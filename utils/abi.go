@@ -18,13 +18,15 @@ type MethodABI struct {
 	StateMutability string        `json:"stateMutability,omitempty"`
 	Constant        bool          `json:"constant,omitempty"`
 	Payable         bool          `json:"payable,omitempty"`
+	Anonymous       bool          `json:"anonymous,omitempty"`
 }
 
 // ArgumentABI represents the JSON structure of a method's argument.
 type ArgumentABI struct {
-	Name    string `json:"name"`
-	Type    string `json:"type"`
-	Indexed bool   `json:"indexed,omitempty"`
+	Name       string        `json:"name"`
+	Type       string        `json:"type"`
+	Indexed    bool          `json:"indexed,omitempty"`
+	Components []ArgumentABI `json:"components,omitempty"`
 }
 
 // MethodToABI converts a Method object to its JSON ABI representation.
@@ -0,0 +1,433 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/enviodev/hypersync-client-go/types"
+	abi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/pkg/errors"
+)
+
+// identifierRegexp matches valid Solidity identifiers used for fragment and argument names.
+var identifierRegexp = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// fragmentKinds lists the fragment keywords understood by the human-readable ABI parser.
+var fragmentKinds = map[string]struct{}{
+	"event":       {},
+	"function":    {},
+	"error":       {},
+	"constructor": {},
+	"fallback":    {},
+	"receive":     {},
+}
+
+// ParseHumanABI parses human-readable ABI fragments into a go-ethereum ABI object.
+//
+// Example:
+//
+//	parsed, err := utils.ParseHumanABI(
+//	    "event Transfer(address indexed from, address indexed to, uint256 value)",
+//	    "function transfer(address to, uint256 amount) returns (bool)",
+//	)
+//	if err != nil {
+//	    log.Fatalf("Failed to parse ABI: %v", err)
+//	}
+func ParseHumanABI(fragments ...string) (*abi.ABI, error) {
+	rawAbi, err := HumanABIToJSON(fragments...)
+	if err != nil {
+		return nil, err
+	}
+
+	return ToABIFromString(rawAbi)
+}
+
+// HumanABIToJSON converts human-readable ABI fragments into their JSON ABI representation.
+func HumanABIToJSON(fragments ...string) (string, error) {
+	if len(fragments) == 0 {
+		return "", errors.New("no abi fragments provided")
+	}
+
+	entries := make([]MethodABI, 0, len(fragments))
+	for i, fragment := range fragments {
+		entry, err := ParseHumanFragment(fragment)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to parse abi fragment [%d]", i)
+		}
+		entries = append(entries, *entry)
+	}
+
+	abiJSON, err := json.Marshal(entries)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal ABI: %s", err)
+	}
+
+	return string(abiJSON), nil
+}
+
+// ParseHumanFragment parses a single human-readable fragment, such as
+// "function balanceOf(address owner) view returns (uint256)", into its JSON ABI entry.
+// The fragment must start with one of the event, function, error, constructor, fallback or receive keywords.
+func ParseHumanFragment(fragment string) (*MethodABI, error) {
+	fragment = strings.TrimSuffix(strings.TrimSpace(fragment), ";")
+	if fragment == "" {
+		return nil, errors.New("fragment is empty")
+	}
+
+	kindEnd := strings.IndexAny(fragment, " \t\n(")
+	if kindEnd <= 0 {
+		return nil, fmt.Errorf("invalid fragment: %q", fragment)
+	}
+
+	kind := fragment[:kindEnd]
+	if _, ok := fragmentKinds[kind]; !ok {
+		return nil, fmt.Errorf("unsupported fragment kind %q in: %q", kind, fragment)
+	}
+
+	rest := strings.TrimSpace(fragment[kindEnd:])
+	entry := &MethodABI{Type: kind}
+
+	switch kind {
+	case "event", "function", "error":
+		nameEnd := strings.Index(rest, "(")
+		if nameEnd < 0 {
+			return nil, fmt.Errorf("missing parameter list in: %q", fragment)
+		}
+		entry.Name = strings.TrimSpace(rest[:nameEnd])
+		if !identifierRegexp.MatchString(entry.Name) {
+			return nil, fmt.Errorf("invalid %s name %q in: %q", kind, entry.Name, fragment)
+		}
+		rest = rest[nameEnd:]
+	}
+
+	if !strings.HasPrefix(rest, "(") {
+		return nil, fmt.Errorf("missing parameter list in: %q", fragment)
+	}
+
+	closing := matchingParen(rest, 0)
+	if closing < 0 {
+		return nil, fmt.Errorf("unbalanced parentheses in: %q", fragment)
+	}
+
+	inputs, err := parseHumanParams(rest[1:closing], kind == "event")
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid parameters in: %q", fragment)
+	}
+	entry.Inputs = inputs
+
+	if kind == "function" || kind == "constructor" || kind == "fallback" || kind == "receive" {
+		entry.StateMutability = "nonpayable"
+	}
+	if kind == "receive" {
+		entry.StateMutability = "payable"
+		entry.Payable = true
+	}
+
+	modifiers := strings.TrimSpace(rest[closing+1:])
+	for modifiers != "" {
+		word := modifiers
+		if idx := strings.IndexAny(modifiers, " \t\n("); idx >= 0 {
+			word = modifiers[:idx]
+		}
+		modifiers = strings.TrimSpace(modifiers[len(word):])
+
+		switch word {
+		case "external", "public", "internal", "private", "virtual", "override":
+		case "view", "pure", "payable", "nonpayable":
+			if kind == "event" || kind == "error" {
+				return nil, fmt.Errorf("unexpected modifier %q in: %q", word, fragment)
+			}
+			entry.StateMutability = word
+			entry.Payable = word == "payable"
+			entry.Constant = word == "view" || word == "pure"
+		case "anonymous":
+			if kind != "event" {
+				return nil, fmt.Errorf("unexpected modifier %q in: %q", word, fragment)
+			}
+			entry.Anonymous = true
+		case "returns":
+			if kind != "function" || !strings.HasPrefix(modifiers, "(") {
+				return nil, fmt.Errorf("unexpected returns clause in: %q", fragment)
+			}
+			outClosing := matchingParen(modifiers, 0)
+			if outClosing < 0 {
+				return nil, fmt.Errorf("unbalanced parentheses in: %q", fragment)
+			}
+			outputs, oErr := parseHumanParams(modifiers[1:outClosing], false)
+			if oErr != nil {
+				return nil, errors.Wrapf(oErr, "invalid return parameters in: %q", fragment)
+			}
+			entry.Outputs = outputs
+			modifiers = strings.TrimSpace(modifiers[outClosing+1:])
+		default:
+			return nil, fmt.Errorf("unexpected token %q in: %q", word, fragment)
+		}
+	}
+
+	return entry, nil
+}
+
+// ParseEventSignature parses an event signature into a go-ethereum event. Both the
+// full "event Transfer(address indexed from, ...)" form and the bare "Transfer(address,address,uint256)"
+// form are accepted.
+func ParseEventSignature(signature string) (*abi.Event, error) {
+	parsed, err := ParseHumanABI(withFragmentKind(signature, "event"))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, event := range parsed.Events {
+		return &event, nil
+	}
+
+	return nil, fmt.Errorf("signature is not an event: %q", signature)
+}
+
+// ParseMethodSignature parses a function signature into a go-ethereum method. Both the
+// full "function transfer(address to, uint256 amount)" form and the bare "transfer(address,uint256)"
+// form are accepted.
+func ParseMethodSignature(signature string) (*abi.Method, error) {
+	parsed, err := ParseHumanABI(withFragmentKind(signature, "function"))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, method := range parsed.Methods {
+		return &method, nil
+	}
+
+	return nil, fmt.Errorf("signature is not a function: %q", signature)
+}
+
+// SigHashFromSignature computes the 4-byte function selector for the given function signature,
+// ready to be used in types.TransactionSelection.SigHash.
+//
+// Example:
+//
+//	sigHash, err := utils.SigHashFromSignature("function transfer(address to, uint256 amount)")
+//	if err != nil {
+//	    log.Fatalf("Failed to compute sighash: %v", err)
+//	}
+func SigHashFromSignature(signature string) (types.SigHash, error) {
+	method, err := ParseMethodSignature(signature)
+	if err != nil {
+		return types.SigHash{}, errors.Wrap(err, "failed to parse function signature")
+	}
+
+	var toReturn types.SigHash
+	copy(toReturn[:], method.ID)
+	return toReturn, nil
+}
+
+// Topic0FromSignature computes the topic0 hash for the given event signature,
+// ready to be used in types.LogSelection.Topics.
+//
+// Example:
+//
+//	topic0, err := utils.Topic0FromSignature("event Transfer(address indexed from, address indexed to, uint256 value)")
+//	if err != nil {
+//	    log.Fatalf("Failed to compute topic0: %v", err)
+//	}
+func Topic0FromSignature(signature string) (common.Hash, error) {
+	event, err := ParseEventSignature(signature)
+	if err != nil {
+		return common.Hash{}, errors.Wrap(err, "failed to parse event signature")
+	}
+
+	if event.Anonymous {
+		return common.Hash{}, fmt.Errorf("anonymous event %s has no topic0", event.Name)
+	}
+
+	return event.ID, nil
+}
+
+// withFragmentKind prefixes bare signatures with the provided fragment keyword.
+func withFragmentKind(signature string, kind string) string {
+	signature = strings.TrimSpace(signature)
+	if idx := strings.IndexAny(signature, " \t\n("); idx > 0 {
+		if _, ok := fragmentKinds[signature[:idx]]; ok {
+			return signature
+		}
+	}
+	return kind + " " + signature
+}
+
+// parseHumanParams parses a comma separated parameter list, excluding the surrounding parentheses.
+func parseHumanParams(params string, allowIndexed bool) ([]ArgumentABI, error) {
+	params = strings.TrimSpace(params)
+	if params == "" {
+		return []ArgumentABI{}, nil
+	}
+
+	parts, err := splitTopLevel(params)
+	if err != nil {
+		return nil, err
+	}
+
+	toReturn := make([]ArgumentABI, 0, len(parts))
+	for _, part := range parts {
+		arg, pErr := parseHumanParam(part, allowIndexed)
+		if pErr != nil {
+			return nil, pErr
+		}
+		toReturn = append(toReturn, *arg)
+	}
+
+	return toReturn, nil
+}
+
+// parseHumanParam parses a single parameter such as "address indexed from" or "(uint256 a, bool b)[] items".
+func parseHumanParam(param string, allowIndexed bool) (*ArgumentABI, error) {
+	param = strings.TrimSpace(param)
+	if param == "" {
+		return nil, errors.New("empty parameter")
+	}
+
+	arg := &ArgumentABI{}
+	var rest string
+
+	if strings.HasPrefix(param, "tuple(") {
+		param = strings.TrimPrefix(param, "tuple")
+	}
+
+	if strings.HasPrefix(param, "(") {
+		closing := matchingParen(param, 0)
+		if closing < 0 {
+			return nil, fmt.Errorf("unbalanced parentheses in parameter: %q", param)
+		}
+
+		components, err := parseHumanParams(param[1:closing], false)
+		if err != nil {
+			return nil, err
+		}
+		nameComponents(components)
+		arg.Components = components
+
+		rest = param[closing+1:]
+		suffix := ""
+		for strings.HasPrefix(strings.TrimLeft(rest, " \t"), "[") {
+			rest = strings.TrimLeft(rest, " \t")
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("unbalanced brackets in parameter: %q", param)
+			}
+			suffix += rest[:end+1]
+			rest = rest[end+1:]
+		}
+		arg.Type = "tuple" + suffix
+	} else {
+		fields := strings.Fields(param)
+		arg.Type = normalizeHumanType(fields[0])
+		rest = strings.Join(fields[1:], " ")
+	}
+
+	for _, word := range strings.Fields(rest) {
+		switch {
+		case word == "indexed":
+			if !allowIndexed {
+				return nil, fmt.Errorf("indexed is only allowed on event parameters: %q", param)
+			}
+			arg.Indexed = true
+		case word == "memory" || word == "calldata" || word == "storage":
+		case word == "payable" && arg.Type == "address":
+		case arg.Name == "" && identifierRegexp.MatchString(word):
+			arg.Name = word
+		default:
+			return nil, fmt.Errorf("unexpected token %q in parameter: %q", word, param)
+		}
+	}
+
+	return arg, nil
+}
+
+// nameComponents names the unnamed components of a tuple as field0, field1 and so on, since go-ethereum
+// rejects tuples with anonymous components. Names already taken by sibling components are skipped.
+func nameComponents(components []ArgumentABI) {
+	taken := make(map[string]struct{}, len(components))
+	for _, component := range components {
+		taken[component.Name] = struct{}{}
+	}
+
+	next := 0
+	for i := range components {
+		if components[i].Name != "" {
+			continue
+		}
+		name := fmt.Sprintf("field%d", next)
+		for _, ok := taken[name]; ok; _, ok = taken[name] {
+			next++
+			name = fmt.Sprintf("field%d", next)
+		}
+		next++
+		taken[name] = struct{}{}
+		components[i].Name = name
+	}
+}
+
+// normalizeHumanType expands Solidity type aliases (uint, int) into their canonical ABI form.
+func normalizeHumanType(typ string) string {
+	base, suffix := typ, ""
+	if idx := strings.Index(typ, "["); idx >= 0 {
+		base, suffix = typ[:idx], typ[idx:]
+	}
+
+	switch base {
+	case "uint":
+		base = "uint256"
+	case "int":
+		base = "int256"
+	case "byte":
+		base = "bytes1"
+	}
+
+	return base + suffix
+}
+
+// splitTopLevel splits the input on commas that are not nested within parentheses or brackets.
+func splitTopLevel(input string) ([]string, error) {
+	toReturn := make([]string, 0)
+	depth, start := 0, 0
+
+	for i, r := range input {
+		switch r {
+		case '(', '[':
+			depth++
+		case ')', ']':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced parentheses in: %q", input)
+			}
+		case ',':
+			if depth == 0 {
+				toReturn = append(toReturn, input[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced parentheses in: %q", input)
+	}
+
+	return append(toReturn, input[start:]), nil
+}
+
+// matchingParen returns the index of the parenthesis closing the one opened at the given index, or -1.
+func matchingParen(input string, open int) int {
+	depth := 0
+	for i := open; i < len(input); i++ {
+		switch input[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
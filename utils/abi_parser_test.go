@@ -0,0 +1,137 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/enviodev/hypersync-client-go/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHumanABI(t *testing.T) {
+	parsed, err := ParseHumanABI(
+		"event Transfer(address indexed from, address indexed to, uint256 value)",
+		"event Approval(address indexed owner, address indexed spender, uint value);",
+		"function transfer(address to, uint amount) external returns (bool)",
+		"function balanceOf(address owner) view returns (uint256)",
+		"function swap((address tokenIn, address tokenOut, uint24 fee)[] calldata paths, bytes memory data) payable",
+		"error InsufficientBalance(uint256 available, uint256 required)",
+		"constructor(string name, string symbol)",
+		"receive() external payable",
+	)
+	require.NoError(t, err)
+	require.NotNil(t, parsed)
+
+	transfer, ok := parsed.Events["Transfer"]
+	require.True(t, ok)
+	require.Equal(t, common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"), transfer.ID)
+	require.True(t, transfer.Inputs[0].Indexed)
+	require.False(t, transfer.Inputs[2].Indexed)
+
+	approval, ok := parsed.Events["Approval"]
+	require.True(t, ok)
+	require.Equal(t, "uint256", approval.Inputs[2].Type.String())
+
+	transferMethod, ok := parsed.Methods["transfer"]
+	require.True(t, ok)
+	require.Equal(t, []byte{0xa9, 0x05, 0x9c, 0xbb}, transferMethod.ID)
+	require.Len(t, transferMethod.Outputs, 1)
+
+	balanceOf, ok := parsed.Methods["balanceOf"]
+	require.True(t, ok)
+	require.Equal(t, "view", balanceOf.StateMutability)
+
+	swap, ok := parsed.Methods["swap"]
+	require.True(t, ok)
+	require.Equal(t, "(address,address,uint24)[]", swap.Inputs[0].Type.String())
+	require.Equal(t, "payable", swap.StateMutability)
+
+	_, ok = parsed.Errors["InsufficientBalance"]
+	require.True(t, ok)
+	require.Len(t, parsed.Constructor.Inputs, 2)
+	require.True(t, parsed.HasReceive())
+}
+
+func TestParseHumanABIUnnamedComponents(t *testing.T) {
+	parsed, err := ParseHumanABI("event Foo((uint256,bool field0,(address,bytes32)[]) x)")
+	require.NoError(t, err)
+
+	foo, ok := parsed.Events["Foo"]
+	require.True(t, ok)
+	require.Equal(t, "(uint256,bool,(address,bytes32)[])", foo.Inputs[0].Type.String())
+	require.Equal(t, []string{"field1", "field0", "field2"}, foo.Inputs[0].Type.TupleRawNames)
+	require.Equal(t, []string{"field0", "field1"}, foo.Inputs[0].Type.TupleElems[2].Elem.TupleRawNames)
+}
+
+func TestParseHumanABIErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		fragment string
+	}{
+		{name: "Missing keyword", fragment: "Transfer(address,address,uint256)"},
+		{name: "Unknown keyword", fragment: "modifier onlyOwner()"},
+		{name: "Unbalanced parentheses", fragment: "event Transfer(address indexed from"},
+		{name: "Indexed function parameter", fragment: "function transfer(address indexed to)"},
+		{name: "Invalid type", fragment: "event Transfer(adress indexed from)"},
+		{name: "Duplicate names", fragment: "event Transfer(address from to)"},
+		{name: "Returns on event", fragment: "event Transfer(address from) returns (bool)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseHumanABI(tt.fragment)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestTopic0FromAnonymousEvent(t *testing.T) {
+	_, err := Topic0FromSignature("event Deposit(address indexed from, uint256 value) anonymous")
+	require.EqualError(t, err, "anonymous event Deposit has no topic0")
+}
+
+func TestSignatureHashes(t *testing.T) {
+	tests := []struct {
+		name      string
+		signature string
+		sigHash   types.SigHash
+		topic0    common.Hash
+	}{
+		{
+			name:      "Bare signature",
+			signature: "transfer(address,uint256)",
+			sigHash:   types.NewSigHashFromHex("a9059cbb"),
+		},
+		{
+			name:      "Named function fragment",
+			signature: "function transfer(address to, uint256 amount) returns (bool)",
+			sigHash:   types.NewSigHashFromHex("a9059cbb"),
+		},
+		{
+			name:      "Bare event signature",
+			signature: "Transfer(address,address,uint256)",
+			topic0:    common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"),
+		},
+		{
+			name:      "Named event fragment",
+			signature: "event Transfer(address indexed from, address indexed to, uint256 value)",
+			topic0:    common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.sigHash != (types.SigHash{}) {
+				sigHash, err := SigHashFromSignature(tt.signature)
+				require.NoError(t, err)
+				require.Equal(t, tt.sigHash, sigHash)
+			}
+
+			if tt.topic0 != (common.Hash{}) {
+				topic0, err := Topic0FromSignature(tt.signature)
+				require.NoError(t, err)
+				require.Equal(t, tt.topic0, topic0)
+			}
+		})
+	}
+}
@@ -0,0 +1,252 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Currency describes the native currency of a network.
+type Currency struct {
+	Name     string `json:"name" yaml:"name"`
+	Symbol   string `json:"symbol" yaml:"symbol"`
+	Decimals uint8  `json:"decimals" yaml:"decimals"`
+}
+
+// NetworkInfo describes a network served by HyperSync.
+type NetworkInfo struct {
+	// ID is the chain id of the network.
+	ID NetworkID `json:"id" yaml:"id"`
+
+	// Name is the name of the network.
+	Name Network `json:"name" yaml:"name"`
+
+	// Aliases are other names the network is looked up by, such as eth for ethereum.
+	Aliases []string `json:"aliases,omitempty" yaml:"aliases,omitempty"`
+
+	// HyperSyncURL is the HyperSync endpoint of the network.
+	HyperSyncURL string `json:"hypersyncUrl" yaml:"hypersyncUrl"`
+
+	// HyperRPCURL is the HyperRPC endpoint of the network.
+	HyperRPCURL string `json:"hyperrpcUrl" yaml:"hyperrpcUrl"`
+
+	// NativeCurrency is the currency gas is paid in.
+	NativeCurrency Currency `json:"nativeCurrency" yaml:"nativeCurrency"`
+
+	// BlockTime is the average time between blocks.
+	BlockTime time.Duration `json:"blockTime" yaml:"blockTime"`
+
+	// FinalityDepth is the recommended number of blocks after which a block is unlikely to be reorganized.
+	FinalityDepth uint64 `json:"finalityDepth" yaml:"finalityDepth"`
+}
+
+var (
+	ether    = Currency{Name: "Ether", Symbol: "ETH", Decimals: 18}
+	xdai     = Currency{Name: "xDAI", Symbol: "XDAI", Decimals: 18}
+	pol      = Currency{Name: "POL", Symbol: "POL", Decimals: 18}
+	okb      = Currency{Name: "OKB", Symbol: "OKB", Decimals: 18}
+	harmony  = Currency{Name: "ONE", Symbol: "ONE", Decimals: 18}
+	registry = newNetworkRegistry()
+)
+
+// networkRegistry holds the known networks, by chain id and by lowercase name and alias.
+type networkRegistry struct {
+	mu     sync.RWMutex
+	byID   map[NetworkID]NetworkInfo
+	byName map[string]NetworkID
+}
+
+func newNetworkRegistry() *networkRegistry {
+	r := &networkRegistry{byID: make(map[NetworkID]NetworkInfo), byName: make(map[string]NetworkID)}
+	for _, info := range []NetworkInfo{
+		{ID: EthereumNetworkID, Name: EthereumNetwork, Aliases: []string{"eth", "mainnet"}, NativeCurrency: ether, BlockTime: 12 * time.Second, FinalityDepth: 64},
+		{ID: GoerliNetworkID, Name: GoerliNetwork, NativeCurrency: ether, BlockTime: 12 * time.Second, FinalityDepth: 64},
+		{ID: OptimismNetworkID, Name: OptimismNetwork, NativeCurrency: ether, BlockTime: 2 * time.Second, FinalityDepth: 300},
+		{ID: FlareNetworkID, Name: FlareNetwork, NativeCurrency: Currency{Name: "Flare", Symbol: "FLR", Decimals: 18}, BlockTime: 1800 * time.Millisecond, FinalityDepth: 10},
+		{ID: RootstockNetworkID, Name: RootstockNetwork, NativeCurrency: Currency{Name: "Smart Bitcoin", Symbol: "RBTC", Decimals: 18}, BlockTime: 30 * time.Second, FinalityDepth: 12},
+		{ID: LuksoNetworkID, Name: LuksoNetwork, NativeCurrency: Currency{Name: "LUKSO", Symbol: "LYX", Decimals: 18}, BlockTime: 12 * time.Second, FinalityDepth: 64},
+		{ID: CrabNetworkID, Name: CrabNetwork, NativeCurrency: Currency{Name: "Crab", Symbol: "CRAB", Decimals: 18}, BlockTime: 6 * time.Second, FinalityDepth: 20},
+		{ID: DarwiniaNetworkID, Name: DarwiniaNetwork, NativeCurrency: Currency{Name: "Ring", Symbol: "RING", Decimals: 18}, BlockTime: 6 * time.Second, FinalityDepth: 20},
+		{ID: BscNetworkID, Name: BscNetwork, Aliases: []string{"bnb"}, NativeCurrency: Currency{Name: "BNB", Symbol: "BNB", Decimals: 18}, BlockTime: 3 * time.Second, FinalityDepth: 15},
+		{ID: GnosisNetworkID, Name: GnosisNetwork, Aliases: []string{"xdai"}, NativeCurrency: xdai, BlockTime: 5 * time.Second, FinalityDepth: 32},
+		{ID: PolygonNetworkID, Name: PolygonNetwork, Aliases: []string{"matic"}, NativeCurrency: pol, BlockTime: 2 * time.Second, FinalityDepth: 128},
+		{ID: ShimmerEVMNetworkID, Name: ShimmerEVMNetwork, NativeCurrency: Currency{Name: "Shimmer", Symbol: "SMR", Decimals: 18}, BlockTime: time.Second, FinalityDepth: 10},
+		{ID: MantaNetworkID, Name: MantaNetwork, NativeCurrency: ether, BlockTime: 2 * time.Second, FinalityDepth: 300},
+		{ID: XLayerTestnetNetworkID, Name: XLayerTestnetNetwork, NativeCurrency: okb, BlockTime: 3 * time.Second, FinalityDepth: 64},
+		{ID: XLayerNetworkID, Name: XLayerNetwork, NativeCurrency: okb, BlockTime: 3 * time.Second, FinalityDepth: 64},
+		{ID: FantomNetworkID, Name: FantomNetwork, NativeCurrency: Currency{Name: "Fantom", Symbol: "FTM", Decimals: 18}, BlockTime: time.Second, FinalityDepth: 5},
+		{ID: KromaNetworkID, Name: KromaNetwork, NativeCurrency: ether, BlockTime: 2 * time.Second, FinalityDepth: 300},
+		{ID: BobaNetworkID, Name: BobaNetwork, NativeCurrency: ether, BlockTime: 2 * time.Second, FinalityDepth: 300},
+		{ID: ZksyncEraNetworkID, Name: ZksyncEraNetwork, Aliases: []string{"zksync"}, NativeCurrency: ether, BlockTime: time.Second, FinalityDepth: 300},
+		{ID: PublicGoodsNetworkID, Name: PublicGoodsNetwork, NativeCurrency: ether, BlockTime: 2 * time.Second, FinalityDepth: 300},
+		{ID: MetisNetworkID, Name: MetisNetwork, NativeCurrency: Currency{Name: "Metis", Symbol: "METIS", Decimals: 18}, BlockTime: 4 * time.Second, FinalityDepth: 30},
+		{ID: PolygonzkEVMNetworkID, Name: PolygonzkEVMNetwork, NativeCurrency: ether, BlockTime: 3 * time.Second, FinalityDepth: 64},
+		{ID: MoonbeamNetworkID, Name: MoonbeamNetwork, NativeCurrency: Currency{Name: "Glimmer", Symbol: "GLMR", Decimals: 18}, BlockTime: 6 * time.Second, FinalityDepth: 20},
+		{ID: C1MilkomedaNetworkID, Name: C1MilkomedaNetwork, NativeCurrency: Currency{Name: "milkADA", Symbol: "mADA", Decimals: 18}, BlockTime: 4 * time.Second, FinalityDepth: 20},
+		{ID: MantleNetworkID, Name: MantleNetwork, NativeCurrency: Currency{Name: "Mantle", Symbol: "MNT", Decimals: 18}, BlockTime: 2 * time.Second, FinalityDepth: 300},
+		{ID: ZetaNetworkID, Name: ZetaNetwork, NativeCurrency: Currency{Name: "Zeta", Symbol: "ZETA", Decimals: 18}, BlockTime: 6 * time.Second, FinalityDepth: 10},
+		{ID: CyberNetworkID, Name: CyberNetwork, NativeCurrency: ether, BlockTime: 2 * time.Second, FinalityDepth: 300},
+		{ID: BaseNetworkID, Name: BaseNetwork, NativeCurrency: ether, BlockTime: 2 * time.Second, FinalityDepth: 300},
+		{ID: GnosisChiadoNetworkID, Name: GnosisChiadoNetwork, NativeCurrency: xdai, BlockTime: 5 * time.Second, FinalityDepth: 32},
+		{ID: HoleskyNetworkID, Name: HoleskyNetwork, NativeCurrency: ether, BlockTime: 12 * time.Second, FinalityDepth: 64},
+		{ID: ArbitrumOneNetworkID, Name: ArbitrumOneNetwork, Aliases: []string{"arbitrum"}, NativeCurrency: ether, BlockTime: 250 * time.Millisecond, FinalityDepth: 1200},
+		{ID: ArbitrumNovaNetworkID, Name: ArbitrumNovaNetwork, NativeCurrency: ether, BlockTime: time.Second, FinalityDepth: 300},
+		{ID: CeloNetworkID, Name: CeloNetwork, NativeCurrency: Currency{Name: "Celo", Symbol: "CELO", Decimals: 18}, BlockTime: 5 * time.Second, FinalityDepth: 20},
+		{ID: AvalancheNetworkID, Name: AvalancheNetwork, NativeCurrency: Currency{Name: "Avalanche", Symbol: "AVAX", Decimals: 18}, BlockTime: 2 * time.Second, FinalityDepth: 10},
+		{ID: LineaNetworkID, Name: LineaNetwork, NativeCurrency: ether, BlockTime: 2 * time.Second, FinalityDepth: 300},
+		{ID: AmoyNetworkID, Name: AmoyNetwork, NativeCurrency: pol, BlockTime: 2 * time.Second, FinalityDepth: 128},
+		{ID: BlastNetworkID, Name: BlastNetwork, NativeCurrency: ether, BlockTime: 2 * time.Second, FinalityDepth: 300},
+		{ID: BaseSepoliaNetworkID, Name: BaseSepoliaNetwork, NativeCurrency: ether, BlockTime: 2 * time.Second, FinalityDepth: 300},
+		{ID: TaikoJolnrNetworkID, Name: TaikoJolnrNetwork, NativeCurrency: ether, BlockTime: 12 * time.Second, FinalityDepth: 64},
+		{ID: ArbitrumSepoliaNetworkID, Name: ArbitrumSepoliaNetwork, NativeCurrency: ether, BlockTime: 250 * time.Millisecond, FinalityDepth: 1200},
+		{ID: ScrollNetworkID, Name: ScrollNetwork, NativeCurrency: ether, BlockTime: 3 * time.Second, FinalityDepth: 300},
+		{ID: ZoraNetworkID, Name: ZoraNetwork, NativeCurrency: ether, BlockTime: 2 * time.Second, FinalityDepth: 300},
+		{ID: SepoliaNetworkID, Name: SepoliaNetwork, NativeCurrency: ether, BlockTime: 12 * time.Second, FinalityDepth: 64},
+		{ID: OptimismSepoliaNetworkID, Name: OptimismSepoliaNetwork, NativeCurrency: ether, BlockTime: 2 * time.Second, FinalityDepth: 300},
+		{ID: BlastSepoliaNetworkID, Name: BlastSepoliaNetwork, NativeCurrency: ether, BlockTime: 2 * time.Second, FinalityDepth: 300},
+		{ID: NeonEVMNetworkID, Name: NeonEVMNetwork, NativeCurrency: Currency{Name: "Neon", Symbol: "NEON", Decimals: 18}, BlockTime: 400 * time.Millisecond, FinalityDepth: 32},
+		{ID: AuroraNetworkID, Name: AuroraNetwork, NativeCurrency: ether, BlockTime: time.Second, FinalityDepth: 10},
+		{ID: HarmonyShard0NetworkID, Name: HarmonyShard0Network, NativeCurrency: harmony, BlockTime: 2 * time.Second, FinalityDepth: 10},
+		{ID: HarmonyShard1NetworkID, Name: HarmonyShard1Network, NativeCurrency: harmony, BlockTime: 2 * time.Second, FinalityDepth: 10},
+	} {
+		r.register(info)
+	}
+	return r
+}
+
+// register adds the network, or merges it into the registered one: fields left empty keep their value.
+// Missing URLs default to those of the chain id.
+func (r *networkRegistry) register(info NetworkInfo) NetworkInfo {
+	if existing, ok := r.byID[info.ID]; ok {
+		if info.Name == "" {
+			info.Name = existing.Name
+		}
+		if info.HyperSyncURL == "" {
+			info.HyperSyncURL = existing.HyperSyncURL
+		}
+		if info.HyperRPCURL == "" {
+			info.HyperRPCURL = existing.HyperRPCURL
+		}
+		if info.NativeCurrency == (Currency{}) {
+			info.NativeCurrency = existing.NativeCurrency
+		}
+		if info.BlockTime == 0 {
+			info.BlockTime = existing.BlockTime
+		}
+		if info.FinalityDepth == 0 {
+			info.FinalityDepth = existing.FinalityDepth
+		}
+		info.Aliases = append(append([]string(nil), existing.Aliases...), info.Aliases...)
+		for name, id := range r.byName {
+			if id == info.ID {
+				delete(r.byName, name)
+			}
+		}
+	}
+	if info.Name == "" {
+		info.Name = Network(info.ID.String())
+	}
+	if info.HyperSyncURL == "" {
+		info.HyperSyncURL = fmt.Sprintf("https://%d.hypersync.xyz", info.ID)
+	}
+	if info.HyperRPCURL == "" {
+		info.HyperRPCURL = fmt.Sprintf("https://%d.rpc.hypersync.xyz", info.ID)
+	}
+
+	r.byID[info.ID] = info
+	r.byName[normalizeNetworkName(string(info.Name))] = info.ID
+	for _, alias := range info.Aliases {
+		r.byName[normalizeNetworkName(alias)] = info.ID
+	}
+	return info
+}
+
+// normalizeNetworkName lowercases the name and drops separators, so "Arbitrum One", "arbitrum-one" and
+// "arbitrumone" are the same name.
+func normalizeNetworkName(name string) string {
+	return strings.NewReplacer("-", "", "_", "", " ", "").Replace(strings.ToLower(strings.TrimSpace(name)))
+}
+
+// RegisterNetwork adds a network to the registry or overrides a registered one, such as to point a network
+// at a private HyperSync deployment. Fields left empty keep the registered values; the URLs of a new
+// network default to https://{chainId}.hypersync.xyz and https://{chainId}.rpc.hypersync.xyz.
+// It returns the registered network.
+//
+// Example:
+//
+//	utils.RegisterNetwork(utils.NetworkInfo{
+//		ID:           utils.EthereumNetworkID,
+//		HyperSyncURL: "https://hypersync.internal.example.com",
+//	})
+func RegisterNetwork(info NetworkInfo) (NetworkInfo, error) {
+	if !info.ID.IsValid() {
+		return NetworkInfo{}, fmt.Errorf("network id is required")
+	}
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	return registry.register(info), nil
+}
+
+// GetNetworkInfo returns the registered network of the chain id.
+func GetNetworkInfo(id NetworkID) (NetworkInfo, bool) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	info, ok := registry.byID[id]
+	return info, ok
+}
+
+// GetNetworkInfoByName returns the registered network of the name or alias. Names are matched regardless
+// of case and of the separators "-", "_" and " ".
+func GetNetworkInfoByName(name string) (NetworkInfo, bool) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	id, ok := registry.byName[normalizeNetworkName(name)]
+	if !ok {
+		return NetworkInfo{}, false
+	}
+	return registry.byID[id], true
+}
+
+// Networks returns the registered networks ordered by chain id.
+func Networks() []NetworkInfo {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	networks := make([]NetworkInfo, 0, len(registry.byID))
+	for _, info := range registry.byID {
+		networks = append(networks, info)
+	}
+	sort.Slice(networks, func(i, j int) bool { return networks[i].ID < networks[j].ID })
+	return networks
+}
+
+// ParseNetworkID parses a chain id, or the name or alias of a registered network.
+func ParseNetworkID(s string) (NetworkID, error) {
+	if id, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return NetworkID(id), nil
+	}
+	if info, ok := GetNetworkInfoByName(s); ok {
+		return info.ID, nil
+	}
+	return 0, fmt.Errorf("unknown network: %s, use a chain id or a registered network name", s)
+}
+
+// HyperSyncURL returns the HyperSync endpoint of the chain id: the registered one, or
+// https://{chainId}.hypersync.xyz for networks that are not registered.
+func HyperSyncURL(id NetworkID) string {
+	if info, ok := GetNetworkInfo(id); ok {
+		return info.HyperSyncURL
+	}
+	return fmt.Sprintf("https://%d.hypersync.xyz", id)
+}
+
+// HyperRPCURL returns the HyperRPC endpoint of the chain id: the registered one, or
+// https://{chainId}.rpc.hypersync.xyz for networks that are not registered.
+func HyperRPCURL(id NetworkID) string {
+	if info, ok := GetNetworkInfo(id); ok {
+		return info.HyperRPCURL
+	}
+	return fmt.Sprintf("https://%d.rpc.hypersync.xyz", id)
+}
@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkRegistry(t *testing.T) {
+	info, ok := GetNetworkInfo(EthereumNetworkID)
+	require.True(t, ok)
+	require.Equal(t, EthereumNetwork, info.Name)
+	require.Equal(t, "https://1.hypersync.xyz", info.HyperSyncURL)
+	require.Equal(t, "https://1.rpc.hypersync.xyz", info.HyperRPCURL)
+	require.Equal(t, "ETH", info.NativeCurrency.Symbol)
+	require.Equal(t, 12*time.Second, info.BlockTime)
+	require.Equal(t, uint64(64), info.FinalityDepth)
+
+	for _, name := range []string{"base-sepolia", "Base Sepolia", "BASESEPOLIA", "base_sepolia"} {
+		info, ok = GetNetworkInfoByName(name)
+		require.True(t, ok, name)
+		require.Equal(t, BaseSepoliaNetworkID, info.ID)
+	}
+
+	info, ok = GetNetworkInfoByName("eth")
+	require.True(t, ok)
+	require.Equal(t, EthereumNetworkID, info.ID)
+
+	_, ok = GetNetworkInfo(NetworkID(999999999))
+	require.False(t, ok)
+	require.Equal(t, "https://999999999.hypersync.xyz", HyperSyncURL(999999999))
+	require.Equal(t, "https://999999999.rpc.hypersync.xyz", HyperRPCURL(999999999))
+
+	networks := Networks()
+	require.Equal(t, EthereumNetworkID, networks[0].ID)
+	for i := 1; i < len(networks); i++ {
+		require.Less(t, networks[i-1].ID, networks[i].ID)
+	}
+}
+
+func TestParseNetworkID(t *testing.T) {
+	id, err := ParseNetworkID("8453")
+	require.NoError(t, err)
+	require.Equal(t, BaseNetworkID, id)
+
+	id, err = ParseNetworkID("arbitrum")
+	require.NoError(t, err)
+	require.Equal(t, ArbitrumOneNetworkID, id)
+
+	_, err = ParseNetworkID("nowhere")
+	require.EqualError(t, err, "unknown network: nowhere, use a chain id or a registered network name")
+}
+
+func TestRegisterNetwork(t *testing.T) {
+	original, _ := GetNetworkInfo(GnosisChiadoNetworkID)
+	t.Cleanup(func() {
+		registry.mu.Lock()
+		defer registry.mu.Unlock()
+		registry.byID[GnosisChiadoNetworkID] = original
+		delete(registry.byName, "chiado")
+	})
+
+	info, err := RegisterNetwork(NetworkInfo{ID: GnosisChiadoNetworkID, HyperSyncURL: "https://hypersync.internal", Aliases: []string{"chiado"}})
+	require.NoError(t, err)
+	require.Equal(t, "https://hypersync.internal", info.HyperSyncURL)
+	require.Equal(t, original.HyperRPCURL, info.HyperRPCURL)
+	require.Equal(t, original.NativeCurrency, info.NativeCurrency)
+	require.Equal(t, "https://hypersync.internal", HyperSyncURL(GnosisChiadoNetworkID))
+
+	byAlias, ok := GetNetworkInfoByName("chiado")
+	require.True(t, ok)
+	require.Equal(t, info, byAlias)
+
+	custom, err := RegisterNetwork(NetworkInfo{ID: 123456789, Name: "devnet"})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		registry.mu.Lock()
+		defer registry.mu.Unlock()
+		delete(registry.byID, custom.ID)
+		delete(registry.byName, "devnet")
+	})
+	require.Equal(t, "https://123456789.hypersync.xyz", custom.HyperSyncURL)
+	require.Equal(t, "https://123456789.rpc.hypersync.xyz", custom.HyperRPCURL)
+
+	_, err = RegisterNetwork(NetworkInfo{Name: "nameless"})
+	require.EqualError(t, err, "network id is required")
+}
@@ -1,5 +1,11 @@
 package utils
 
+import (
+	"fmt"
+	"strings"
+)
+
+// Standard represents a contract standard such as ERC-20 or ERC-721.
 type Standard int
 
 const (
@@ -7,4 +13,59 @@ const (
 	Erc20
 	Erc721
 	Erc1155
+	// Custom represents a contract that does not implement any of the known standards.
+	Custom
+	// Erc4626 represents tokenized vaults.
+	Erc4626
+	// Erc777 represents tokens with operators and send/mint/burn hooks.
+	Erc777
+	// Erc2981 represents the NFT royalty standard.
+	Erc2981
+	// Weth represents wrapped native currency contracts such as WETH9.
+	Weth
+	// UniswapV2Pair represents Uniswap V2 compatible liquidity pairs.
+	UniswapV2Pair
+	// UniswapV2Factory represents Uniswap V2 compatible pair factories.
+	UniswapV2Factory
+	// UniswapV3Pool represents Uniswap V3 compatible liquidity pools.
+	UniswapV3Pool
+	// UniswapV3Factory represents Uniswap V3 compatible pool factories.
+	UniswapV3Factory
 )
+
+// standardNames maps each standard to its canonical, lowercase name.
+var standardNames = map[Standard]string{
+	Erc20:   "erc20",
+	Erc721:  "erc721",
+	Erc1155: "erc1155",
+	Custom:  "custom",
+	Erc4626: "erc4626",
+	Erc777:  "erc777",
+	Erc2981: "erc2981",
+	Weth:    "weth",
+
+	UniswapV2Pair:    "uniswapv2pair",
+	UniswapV2Factory: "uniswapv2factory",
+	UniswapV3Pool:    "uniswapv3pool",
+	UniswapV3Factory: "uniswapv3factory",
+}
+
+// String returns the canonical name of the standard, such as "erc20".
+func (s Standard) String() string {
+	if name, ok := standardNames[s]; ok {
+		return name
+	}
+	return "none"
+}
+
+// ParseStandard converts a standard name into a Standard. Names are case-insensitive and may
+// contain dashes or underscores, so "ERC-20", "erc_20" and "erc20" are equivalent.
+func ParseStandard(name string) (Standard, error) {
+	normalized := strings.NewReplacer("-", "", "_", "", " ", "").Replace(strings.ToLower(strings.TrimSpace(name)))
+	for standard, standardName := range standardNames {
+		if standardName == normalized {
+			return standard, nil
+		}
+	}
+	return NoStandard, fmt.Errorf("unknown contract standard: %q", name)
+}
@@ -0,0 +1,226 @@
+package verify
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/enviodev/hypersync-client-go/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ViolationKind identifies a chain continuity violation.
+type ViolationKind string
+
+const (
+	// ParentHashMismatch is reported when a block parent hash is not the hash of the previous block.
+	ParentHashMismatch ViolationKind = "parent_hash_mismatch"
+	// BlockGap is reported when blocks are missing from a stream including all blocks.
+	BlockGap ViolationKind = "block_gap"
+	// BlockOutOfOrder is reported when a block number does not increase.
+	BlockOutOfOrder ViolationKind = "block_out_of_order"
+	// LogOutOfOrder is reported when a log (block_number, log_index) does not increase.
+	LogOutOfOrder ViolationKind = "log_out_of_order"
+	// DuplicateLog is reported when a log (block_number, log_index) was already received.
+	DuplicateLog ViolationKind = "duplicate_log"
+)
+
+// Violation describes a break of chain continuity.
+type Violation struct {
+	// Kind is the violated rule.
+	Kind ViolationKind
+	// BlockNumber is the block the violation was found at. For gaps, it is the first missing block.
+	BlockNumber uint64
+	// LogIndex is the index of the offending log, or nil for block violations.
+	LogIndex *uint64
+	// Expected is the expected value, such as the previous block hash or the next block number.
+	Expected string
+	// Actual is the received value.
+	Actual string
+}
+
+// String returns a human-readable description of the violation.
+func (v Violation) String() string {
+	if v.LogIndex != nil {
+		return fmt.Sprintf("block %d log %d: %s (expected: %s, actual: %s)", v.BlockNumber, *v.LogIndex, v.Kind, v.Expected, v.Actual)
+	}
+	return fmt.Sprintf("block %d: %s (expected: %s, actual: %s)", v.BlockNumber, v.Kind, v.Expected, v.Actual)
+}
+
+// ContinuityError is returned when a response breaks chain continuity.
+type ContinuityError struct {
+	Violations []Violation
+}
+
+// Error lists the violations.
+func (e *ContinuityError) Error() string {
+	descriptions := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		descriptions = append(descriptions, violation.String())
+	}
+	return fmt.Sprintf("chain continuity violated: %s", strings.Join(descriptions, "; "))
+}
+
+// Continuity validates that consecutive responses of a stream form a continuous chain: each block parent hash
+// is the hash of the previous block, block numbers increase and, when every block is included, are contiguous,
+// and logs come in strictly increasing (block_number, log_index) order without duplicates, across pages.
+// Responses must be checked in stream order.
+type Continuity struct {
+	mu         sync.Mutex
+	contiguous bool
+	next       uint64
+	lastNumber *uint64
+	lastHash   common.Hash
+	lastLog    *logPosition
+}
+
+// logPosition is the position of a log in the chain.
+type logPosition struct {
+	block uint64
+	index uint64
+}
+
+// NewContinuity creates a validator for a stream starting at fromBlock. When contiguous is set, as for queries
+// including all blocks, every block from fromBlock up to the next block of each response must be present.
+//
+// Example:
+//
+//	continuity := verify.NewContinuity(query.FromBlock.Uint64(), query.IncludeAllBlocks)
+//	for response := range stream.Channel() {
+//	    if err := continuity.Check(response); err != nil {
+//	        log.Printf("Stream is not continuous: %v", err)
+//	    }
+//	}
+func NewContinuity(fromBlock uint64, contiguous bool) *Continuity {
+	return &Continuity{
+		contiguous: contiguous,
+		next:       fromBlock,
+	}
+}
+
+// Check validates the response against the previously checked ones. It returns a *ContinuityError listing the
+// violations, or a *types.MissingFieldsError when the fields needed to validate were not selected: number,
+// hash and parent_hash for blocks, block_number and log_index for logs. A response missing fields leaves the
+// state of the validator unchanged.
+func (c *Continuity) Check(response *types.QueryResponse) error {
+	if err := requireFields(response); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	violations := make([]Violation, 0)
+
+	for _, block := range response.Data.Blocks {
+		number := block.Number.Uint64()
+		switch {
+		case c.lastNumber != nil && number <= *c.lastNumber:
+			violations = append(violations, Violation{
+				Kind:        BlockOutOfOrder,
+				BlockNumber: number,
+				Expected:    fmt.Sprintf("block after %d", *c.lastNumber),
+				Actual:      fmt.Sprint(number),
+			})
+			continue
+		case c.contiguous && number > c.next:
+			violations = append(violations, gap(c.next, number))
+		case c.lastNumber != nil && number == *c.lastNumber+1 && *block.ParentHash != c.lastHash:
+			violations = append(violations, Violation{
+				Kind:        ParentHashMismatch,
+				BlockNumber: number,
+				Expected:    c.lastHash.Hex(),
+				Actual:      block.ParentHash.Hex(),
+			})
+		}
+
+		c.lastNumber = &number
+		c.lastHash = *block.Hash
+		c.next = number + 1
+	}
+
+	// Every block below the next block of the response was covered by it.
+	if c.contiguous && len(response.Data.Blocks) > 0 && response.NextBlock != nil && response.NextBlock.Cmp(new(big.Int).SetUint64(c.next)) > 0 {
+		violations = append(violations, gap(c.next, response.NextBlock.Uint64()))
+		c.next = response.NextBlock.Uint64()
+	}
+
+	for _, log := range response.Data.Logs {
+		position := &logPosition{block: log.BlockNumber.Uint64(), index: *log.LogIndex}
+		if c.lastLog != nil {
+			index := position.index
+			switch {
+			case position.block == c.lastLog.block && position.index == c.lastLog.index:
+				violations = append(violations, Violation{
+					Kind:        DuplicateLog,
+					BlockNumber: position.block,
+					LogIndex:    &index,
+					Expected:    fmt.Sprintf("log after (%d, %d)", c.lastLog.block, c.lastLog.index),
+					Actual:      fmt.Sprintf("(%d, %d)", position.block, position.index),
+				})
+				continue
+			case position.block < c.lastLog.block || (position.block == c.lastLog.block && position.index < c.lastLog.index):
+				violations = append(violations, Violation{
+					Kind:        LogOutOfOrder,
+					BlockNumber: position.block,
+					LogIndex:    &index,
+					Expected:    fmt.Sprintf("log after (%d, %d)", c.lastLog.block, c.lastLog.index),
+					Actual:      fmt.Sprintf("(%d, %d)", position.block, position.index),
+				})
+				continue
+			}
+		}
+		c.lastLog = position
+	}
+
+	if len(violations) > 0 {
+		return &ContinuityError{Violations: violations}
+	}
+	return nil
+}
+
+// requireFields returns a *types.MissingFieldsError when a block or a log of the response lacks a field the
+// validation needs.
+func requireFields(response *types.QueryResponse) error {
+	for _, block := range response.Data.Blocks {
+		missing := &types.MissingFieldsError{Entity: "block"}
+		if block.Number == nil {
+			missing.Fields = append(missing.Fields, "number")
+		}
+		if block.Hash == nil {
+			missing.Fields = append(missing.Fields, "hash")
+		}
+		if block.ParentHash == nil {
+			missing.Fields = append(missing.Fields, "parent_hash")
+		}
+		if len(missing.Fields) > 0 {
+			return missing
+		}
+	}
+
+	for _, log := range response.Data.Logs {
+		missing := &types.MissingFieldsError{Entity: "log"}
+		if log.BlockNumber == nil {
+			missing.Fields = append(missing.Fields, "block_number")
+		}
+		if log.LogIndex == nil {
+			missing.Fields = append(missing.Fields, "log_index")
+		}
+		if len(missing.Fields) > 0 {
+			return missing
+		}
+	}
+
+	return nil
+}
+
+// gap returns the violation for the missing blocks [from, to).
+func gap(from uint64, to uint64) Violation {
+	return Violation{
+		Kind:        BlockGap,
+		BlockNumber: from,
+		Expected:    fmt.Sprint(from),
+		Actual:      fmt.Sprintf("%d (blocks %d to %d missing)", to, from, to-1),
+	}
+}
@@ -0,0 +1,167 @@
+package verify
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/enviodev/hypersync-client-go/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContinuityBlocks(t *testing.T) {
+	tests := []struct {
+		name       string
+		contiguous bool
+		pages      [][]types.Block
+		expected   []Violation
+	}{
+		{
+			name:       "Contiguous across pages",
+			contiguous: true,
+			pages:      [][]types.Block{chain(100, 3), chain(103, 2)},
+		},
+		{
+			name:       "Gap across pages",
+			contiguous: true,
+			pages:      [][]types.Block{chain(100, 3), chain(105, 2)},
+			expected:   []Violation{{Kind: BlockGap, BlockNumber: 103}},
+		},
+		{
+			name:       "Gap at stream start",
+			contiguous: true,
+			pages:      [][]types.Block{chain(101, 2)},
+			expected:   []Violation{{Kind: BlockGap, BlockNumber: 100}},
+		},
+		{
+			name:  "Sparse blocks are not gaps",
+			pages: [][]types.Block{chain(100, 1), chain(150, 2)},
+		},
+		{
+			name:       "Parent hash mismatch across pages",
+			contiguous: true,
+			pages:      [][]types.Block{chain(100, 3), withParent(chain(103, 2), common.HexToHash("0xbad"))},
+			expected:   []Violation{{Kind: ParentHashMismatch, BlockNumber: 103}},
+		},
+		{
+			name:     "Duplicate block",
+			pages:    [][]types.Block{chain(100, 3), chain(102, 2)},
+			expected: []Violation{{Kind: BlockOutOfOrder, BlockNumber: 102}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			continuity := NewContinuity(100, tt.contiguous)
+
+			violations := make([]Violation, 0)
+			for _, page := range tt.pages {
+				last := page[len(page)-1].Number.Uint64()
+				err := continuity.Check(&types.QueryResponse{
+					NextBlock: new(big.Int).SetUint64(last + 1),
+					Data:      types.DataResponse{Blocks: page},
+				})
+				if err != nil {
+					var continuityErr *ContinuityError
+					require.ErrorAs(t, err, &continuityErr)
+					violations = append(violations, continuityErr.Violations...)
+				}
+			}
+
+			require.Len(t, violations, len(tt.expected))
+			for i, expected := range tt.expected {
+				require.Equal(t, expected.Kind, violations[i].Kind)
+				require.Equal(t, expected.BlockNumber, violations[i].BlockNumber)
+			}
+		})
+	}
+}
+
+func TestContinuityTrailingGap(t *testing.T) {
+	continuity := NewContinuity(100, true)
+
+	err := continuity.Check(&types.QueryResponse{
+		NextBlock: big.NewInt(110),
+		Data:      types.DataResponse{Blocks: chain(100, 5)},
+	})
+
+	var continuityErr *ContinuityError
+	require.ErrorAs(t, err, &continuityErr)
+	require.Len(t, continuityErr.Violations, 1)
+	require.Equal(t, BlockGap, continuityErr.Violations[0].Kind)
+	require.Equal(t, uint64(105), continuityErr.Violations[0].BlockNumber)
+	require.Contains(t, err.Error(), "blocks 105 to 109 missing")
+}
+
+func TestContinuityLogs(t *testing.T) {
+	continuity := NewContinuity(100, false)
+
+	require.NoError(t, continuity.Check(&types.QueryResponse{
+		Data: types.DataResponse{Logs: []types.Log{logAt(100, 0), logAt(100, 4), logAt(101, 1)}},
+	}))
+	require.NoError(t, continuity.Check(&types.QueryResponse{
+		Data: types.DataResponse{Logs: []types.Log{logAt(101, 2), logAt(105, 0)}},
+	}))
+
+	err := continuity.Check(&types.QueryResponse{
+		Data: types.DataResponse{Logs: []types.Log{logAt(105, 0), logAt(104, 7), logAt(105, 1)}},
+	})
+
+	var continuityErr *ContinuityError
+	require.ErrorAs(t, err, &continuityErr)
+	require.Len(t, continuityErr.Violations, 2)
+	require.Equal(t, DuplicateLog, continuityErr.Violations[0].Kind)
+	require.Equal(t, uint64(105), continuityErr.Violations[0].BlockNumber)
+	require.Equal(t, uint64(0), *continuityErr.Violations[0].LogIndex)
+	require.Equal(t, LogOutOfOrder, continuityErr.Violations[1].Kind)
+	require.Equal(t, uint64(104), continuityErr.Violations[1].BlockNumber)
+	require.Equal(t, uint64(7), *continuityErr.Violations[1].LogIndex)
+
+	var missing *types.MissingFieldsError
+	err = continuity.Check(&types.QueryResponse{Data: types.DataResponse{Logs: []types.Log{{}}}})
+	require.ErrorAs(t, err, &missing)
+	require.Equal(t, []string{"block_number", "log_index"}, missing.Fields)
+}
+
+func TestContinuityMissingFieldsKeepState(t *testing.T) {
+	continuity := NewContinuity(100, true)
+	require.NoError(t, continuity.Check(&types.QueryResponse{NextBlock: big.NewInt(102), Data: types.DataResponse{Blocks: chain(100, 2)}}))
+
+	// The blocks are valid, but the log lacks its index: nothing of the response may be recorded.
+	var missing *types.MissingFieldsError
+	err := continuity.Check(&types.QueryResponse{
+		NextBlock: big.NewInt(104),
+		Data:      types.DataResponse{Blocks: chain(102, 2), Logs: []types.Log{{BlockNumber: big.NewInt(102)}}},
+	})
+	require.ErrorAs(t, err, &missing)
+	require.Equal(t, []string{"log_index"}, missing.Fields)
+
+	require.NoError(t, continuity.Check(&types.QueryResponse{NextBlock: big.NewInt(104), Data: types.DataResponse{Blocks: chain(102, 2)}}))
+}
+
+// chain returns count linked blocks starting at number. Block hashes are derived from block numbers so
+// separately built chains link up.
+func chain(number uint64, count int) []types.Block {
+	blocks := make([]types.Block, 0, count)
+	for i := 0; i < count; i++ {
+		n := number + uint64(i)
+		hash := common.BigToHash(new(big.Int).SetUint64(n))
+		parent := common.BigToHash(new(big.Int).SetUint64(n - 1))
+		blocks = append(blocks, types.Block{
+			Number:     new(big.Int).SetUint64(n),
+			Hash:       &hash,
+			ParentHash: &parent,
+		})
+	}
+	return blocks
+}
+
+// withParent replaces the parent hash of the first block.
+func withParent(blocks []types.Block, parent common.Hash) []types.Block {
+	blocks[0].ParentHash = &parent
+	return blocks
+}
+
+func logAt(number uint64, index uint64) types.Log {
+	return types.Log{BlockNumber: new(big.Int).SetUint64(number), LogIndex: &index}
+}
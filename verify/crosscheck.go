@@ -0,0 +1,572 @@
+package verify
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/enviodev/hypersync-client-go/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+// HyperSource serves HyperSync queries. It is implemented by the HyperSync client.
+type HyperSource interface {
+	Get(ctx context.Context, query *types.Query) (*types.QueryResponse, error)
+}
+
+// RPCSource serves blocks and receipts from a node. It is implemented by *ethclient.Client, which decodes
+// the transaction types go-ethereum supports, up to EIP-7702, and fails on blocks holding others, such as
+// OP-stack deposits.
+type RPCSource interface {
+	BlockByNumber(ctx context.Context, number *big.Int) (*gethtypes.Block, error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*gethtypes.Receipt, error)
+}
+
+// Entity identifies the kind of object a Difference was found in.
+type Entity string
+
+const (
+	BlockEntity       Entity = "block"
+	TransactionEntity Entity = "transaction"
+	ReceiptEntity     Entity = "receipt"
+	LogEntity         Entity = "log"
+)
+
+// missingValue is the value reported for a field one of the sources does not return.
+const missingValue = "<missing>"
+
+// CrossCheckOptions configures CrossCheck.
+type CrossCheckOptions struct {
+	// FromBlock is the first block of the range.
+	FromBlock uint64
+	// ToBlock is the block to stop at (exclusive).
+	ToBlock uint64
+	// SampleSize, when positive, is the number of blocks of the range to verify, picked at random.
+	// Every block of the range is verified otherwise.
+	SampleSize int
+	// Seed seeds the block sampling, so a sample can be reproduced.
+	Seed int64
+	// Receipts compares the receipt fields and logs of every transaction, fetching each receipt over RPC.
+	Receipts bool
+}
+
+// Difference describes a field whose HyperSync value does not match the node value.
+type Difference struct {
+	// Entity is the kind of object the field belongs to.
+	Entity Entity `json:"entity"`
+	// BlockNumber is the block the difference was found in.
+	BlockNumber uint64 `json:"block_number"`
+	// TransactionHash is the transaction the difference was found in, or nil for block fields.
+	TransactionHash *common.Hash `json:"transaction_hash,omitempty"`
+	// LogIndex is the index of the log the difference was found in, or nil for other entities.
+	LogIndex *uint64 `json:"log_index,omitempty"`
+	// Field is the HyperSync name of the field.
+	Field string `json:"field"`
+	// HyperSync is the value returned by HyperSync.
+	HyperSync string `json:"hypersync"`
+	// RPC is the value returned by the node.
+	RPC string `json:"rpc"`
+}
+
+// String returns a human-readable description of the difference.
+func (d Difference) String() string {
+	location := fmt.Sprintf("block %d", d.BlockNumber)
+	if d.TransactionHash != nil {
+		location += " transaction " + d.TransactionHash.Hex()
+	}
+	if d.LogIndex != nil {
+		location += fmt.Sprintf(" log %d", *d.LogIndex)
+	}
+	return fmt.Sprintf("%s: %s %s differs (hypersync: %s, rpc: %s)", location, d.Entity, d.Field, d.HyperSync, d.RPC)
+}
+
+// CrossReport holds the outcome of a cross-verification.
+type CrossReport struct {
+	// Blocks are the verified block numbers, in increasing order.
+	Blocks []uint64 `json:"blocks"`
+	// Differences are the differences found, ordered by block.
+	Differences []Difference `json:"differences"`
+	// CheckedTransactions is the number of verified transactions.
+	CheckedTransactions int `json:"checked_transactions"`
+	// CheckedReceipts is the number of verified receipts.
+	CheckedReceipts int `json:"checked_receipts"`
+	// CheckedLogs is the number of verified logs.
+	CheckedLogs int `json:"checked_logs"`
+}
+
+// Ok reports whether no difference was found.
+func (r *CrossReport) Ok() bool {
+	return len(r.Differences) == 0
+}
+
+// Err returns a *DifferenceError when differences were found and nil otherwise.
+func (r *CrossReport) Err() error {
+	if r.Ok() {
+		return nil
+	}
+	return &DifferenceError{Report: r}
+}
+
+// DifferenceError is returned when HyperSync data does not match the node.
+type DifferenceError struct {
+	Report *CrossReport
+}
+
+// Error lists the differences of the report.
+func (e *DifferenceError) Error() string {
+	descriptions := make([]string, 0, len(e.Report.Differences))
+	for _, difference := range e.Report.Differences {
+		descriptions = append(descriptions, difference.String())
+	}
+	return fmt.Sprintf("cross-verification found %d differences: %s", len(e.Report.Differences), strings.Join(descriptions, "; "))
+}
+
+// CrossCheck compares the blocks, transactions and, optionally, receipts and logs HyperSync returns for the
+// blocks of a range with the ones returned by a node, field by field. Fields missing from one source are
+// reported as differences too.
+//
+// Example:
+//
+//	rpcClient, _ := client.GetRPC()
+//	report, err := verify.CrossCheck(ctx, client, rpcClient, &verify.CrossCheckOptions{
+//	    FromBlock:  18000000,
+//	    ToBlock:    18100000,
+//	    SampleSize: 50,
+//	    Receipts:   true,
+//	})
+//	if err != nil {
+//	    log.Fatalf("Failed to cross-verify: %v", err)
+//	}
+//	for _, difference := range report.Differences {
+//	    fmt.Println(difference)
+//	}
+func CrossCheck(ctx context.Context, hyper HyperSource, node RPCSource, opts *CrossCheckOptions) (*CrossReport, error) {
+	if opts == nil {
+		return nil, errors.New("cross-check options are nil")
+	}
+	if opts.ToBlock <= opts.FromBlock {
+		return nil, fmt.Errorf("invalid block range: to block %d must be after from block %d", opts.ToBlock, opts.FromBlock)
+	}
+
+	c := &crossChecker{hyper: hyper, node: node, opts: opts, report: &CrossReport{Blocks: opts.blocks()}}
+
+	if opts.SampleSize > 0 && opts.SampleSize < int(opts.ToBlock-opts.FromBlock) {
+		for _, number := range c.report.Blocks {
+			if err := c.checkRange(ctx, number, number+1); err != nil {
+				return nil, err
+			}
+		}
+	} else if err := c.checkRange(ctx, opts.FromBlock, opts.ToBlock); err != nil {
+		return nil, err
+	}
+
+	return c.report, nil
+}
+
+// blocks returns the block numbers to verify, in increasing order.
+func (o *CrossCheckOptions) blocks() []uint64 {
+	size := o.ToBlock - o.FromBlock
+	if o.SampleSize <= 0 || uint64(o.SampleSize) >= size {
+		toReturn := make([]uint64, 0, size)
+		for number := o.FromBlock; number < o.ToBlock; number++ {
+			toReturn = append(toReturn, number)
+		}
+		return toReturn
+	}
+
+	random := rand.New(rand.NewSource(o.Seed))
+	picked := make(map[uint64]bool, o.SampleSize)
+	toReturn := make([]uint64, 0, o.SampleSize)
+	for len(toReturn) < o.SampleSize {
+		number := o.FromBlock + uint64(random.Int63n(int64(size)))
+		if !picked[number] {
+			picked[number] = true
+			toReturn = append(toReturn, number)
+		}
+	}
+	sort.Slice(toReturn, func(i, j int) bool { return toReturn[i] < toReturn[j] })
+	return toReturn
+}
+
+// hyperBlock is the HyperSync data of a block.
+type hyperBlock struct {
+	block        *types.Block
+	transactions map[common.Hash]*types.Transaction
+	logs         map[common.Hash][]types.Log
+	count        int
+}
+
+type crossChecker struct {
+	hyper  HyperSource
+	node   RPCSource
+	opts   *CrossCheckOptions
+	report *CrossReport
+}
+
+// checkRange compares every block between from and to (exclusive) with the node, page by page: the blocks of
+// each HyperSync page are checked before the next page is fetched. Blocks above the archive height are
+// reported as missing.
+func (c *crossChecker) checkRange(ctx context.Context, from uint64, to uint64) error {
+	query := &types.Query{
+		FromBlock:        new(big.Int).SetUint64(from),
+		ToBlock:          new(big.Int).SetUint64(to),
+		IncludeAllBlocks: true,
+		Transactions:     []types.TransactionSelection{{}},
+		FieldSelection: types.FieldSelection{
+			Block:       types.BlockSchemaFieldsAsString(),
+			Transaction: types.TransactionSchemaFieldsAsString(),
+		},
+	}
+	if c.opts.Receipts {
+		query.Logs = []types.LogSelection{{}}
+		query.FieldSelection.Log = types.LogSchemaFieldsAsString()
+	}
+
+	next := from
+	_, err := types.Paginate(ctx, query, c.hyper.Get, func(response *types.QueryResponse) (bool, error) {
+		end := to
+		if response.HasNextBlock() && response.NextBlock.IsUint64() && response.NextBlock.Uint64() < to {
+			end = response.NextBlock.Uint64()
+		}
+		if err := c.checkBlocks(ctx, next, end, group(response)); err != nil {
+			return false, err
+		}
+		next = end
+		return false, nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to get hypersync data")
+	}
+
+	return c.checkBlocks(ctx, next, to, nil)
+}
+
+// checkBlocks compares the blocks between from and to (exclusive) with the node.
+func (c *crossChecker) checkBlocks(ctx context.Context, from uint64, to uint64, blocks map[uint64]*hyperBlock) error {
+	for number := from; number < to; number++ {
+		rpcBlock, err := c.node.BlockByNumber(ctx, new(big.Int).SetUint64(number))
+		if err != nil {
+			return errors.Wrapf(err, "failed to get block %d over rpc", number)
+		}
+
+		if cErr := c.checkBlock(ctx, number, blocks[number], rpcBlock); cErr != nil {
+			return cErr
+		}
+	}
+	return nil
+}
+
+// group returns the HyperSync data of a page by block number.
+func group(response *types.QueryResponse) map[uint64]*hyperBlock {
+	blocks := make(map[uint64]*hyperBlock)
+	blockOf := func(number *big.Int) *hyperBlock {
+		key := number.Uint64()
+		if blocks[key] == nil {
+			blocks[key] = &hyperBlock{
+				transactions: make(map[common.Hash]*types.Transaction),
+				logs:         make(map[common.Hash][]types.Log),
+			}
+		}
+		return blocks[key]
+	}
+
+	for i := range response.Data.Blocks {
+		if block := &response.Data.Blocks[i]; block.Number != nil {
+			blockOf(block.Number).block = block
+		}
+	}
+	for i := range response.Data.Transactions {
+		if tx := &response.Data.Transactions[i]; tx.BlockNumber != nil && tx.Hash != nil {
+			hb := blockOf(tx.BlockNumber)
+			hb.transactions[*tx.Hash] = tx
+			hb.count++
+		}
+	}
+	for _, log := range response.Data.Logs {
+		if log.BlockNumber != nil && log.TransactionHash != nil {
+			hb := blockOf(log.BlockNumber)
+			hb.logs[*log.TransactionHash] = append(hb.logs[*log.TransactionHash], log)
+		}
+	}
+	return blocks
+}
+
+// checkBlock compares the block, its transactions and, when enabled, their receipts.
+func (c *crossChecker) checkBlock(ctx context.Context, number uint64, hb *hyperBlock, rpcBlock *gethtypes.Block) error {
+	d := &differ{report: c.report, base: Difference{Entity: BlockEntity, BlockNumber: number}}
+	if hb == nil || hb.block == nil {
+		d.add("block", missingValue, rpcBlock.Hash().Hex())
+		return nil
+	}
+
+	b, h := hb.block, rpcBlock.Header()
+	d.compare("hash", b.Hash, h.Hash())
+	d.compare("parent_hash", b.ParentHash, h.ParentHash)
+	d.compare("sha3_uncles", b.Sha3Uncles, h.UncleHash)
+	d.compare("miner", b.Miner, h.Coinbase)
+	d.compare("state_root", b.StateRoot, h.Root)
+	d.compare("transactions_root", b.TransactionsRoot, h.TxHash)
+	d.compare("receipts_root", b.ReceiptsRoot, h.ReceiptHash)
+	d.compare("logs_bloom", b.LogsBloom, h.Bloom)
+	d.compare("difficulty", b.Difficulty, h.Difficulty)
+	d.compare("gas_limit", b.GasLimit, h.GasLimit)
+	d.compare("gas_used", b.GasUsed, h.GasUsed)
+	d.compare("extra_data", b.ExtraData, h.Extra)
+	d.compare("mix_hash", b.MixHash, h.MixDigest)
+	d.compare("nonce", b.Nonce, h.Nonce)
+	d.compare("base_fee_per_gas", b.BaseFeePerGas, h.BaseFee)
+	d.compare("withdrawals_root", b.WithdrawalsRoot, h.WithdrawalsHash)
+	d.compare("blob_gas_used", b.BlobGasUsed, h.BlobGasUsed)
+	d.compare("excess_blob_gas", b.ExcessBlobGas, h.ExcessBlobGas)
+	d.compare("parent_beacon_block_root", b.ParentBeaconBlockRoot, h.ParentBeaconRoot)
+	d.compare("requests_hash", b.RequestsHash, h.RequestsHash)
+	if b.Timestamp != nil {
+		d.compare("timestamp", uint64(b.Timestamp.Unix()), h.Time)
+	} else {
+		d.compare("timestamp", nil, h.Time)
+	}
+	d.compare("transaction_count", hb.count, len(rpcBlock.Transactions()))
+
+	for i, rpcTx := range rpcBlock.Transactions() {
+		hash := rpcTx.Hash()
+		td := d.with(TransactionEntity, &hash, nil)
+		tx := hb.transactions[hash]
+		if tx == nil {
+			td.add("transaction", missingValue, hash.Hex())
+			continue
+		}
+
+		c.report.CheckedTransactions++
+		c.checkTransaction(td, tx, rpcTx, uint64(i), rpcBlock.Hash())
+
+		if c.opts.Receipts {
+			receipt, err := c.node.TransactionReceipt(ctx, hash)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get receipt of transaction %s over rpc", hash.Hex())
+			}
+			c.report.CheckedReceipts++
+			c.checkReceipt(td.with(ReceiptEntity, &hash, nil), tx, hb.logs[hash], receipt)
+		}
+	}
+
+	return nil
+}
+
+// checkTransaction compares the transaction with the node transaction at index of the block.
+func (c *crossChecker) checkTransaction(d *differ, tx *types.Transaction, rpcTx *gethtypes.Transaction, index uint64, blockHash common.Hash) {
+	d.compare("block_hash", tx.BlockHash, blockHash)
+	d.compare("transaction_index", tx.TransactionIndex, index)
+	d.compare("nonce", tx.Nonce, rpcTx.Nonce())
+	d.compare("gas", tx.Gas, rpcTx.Gas())
+	d.compare("value", tx.Value, rpcTx.Value())
+	d.compare("input", tx.Input, rpcTx.Data())
+	d.compare("to", tx.To, rpcTx.To())
+	d.compare("type", tx.Kind, rpcTx.Type())
+
+	v, r, s := rpcTx.RawSignatureValues()
+	d.compare("v", tx.V, v)
+	d.compare("r", tx.R, r)
+	d.compare("s", tx.S, s)
+
+	if from, err := gethtypes.Sender(signerFor(rpcTx), rpcTx); err == nil {
+		d.compare("from", tx.From, from)
+	}
+
+	switch rpcTx.Type() {
+	case gethtypes.LegacyTxType, gethtypes.AccessListTxType:
+		d.compare("gas_price", tx.GasPrice, rpcTx.GasPrice())
+	default:
+		d.compare("max_fee_per_gas", tx.MaxFeePerGas, rpcTx.GasFeeCap())
+		d.compare("max_priority_fee_per_gas", tx.MaxPriorityFeePerGas, rpcTx.GasTipCap())
+	}
+	if rpcTx.Type() != gethtypes.LegacyTxType {
+		d.compare("chain_id", tx.ChainID, rpcTx.ChainId())
+	}
+	if rpcTx.Type() == gethtypes.BlobTxType {
+		d.compare("max_fee_per_blob_gas", tx.MaxFeePerBlobGas, rpcTx.BlobGasFeeCap())
+		d.compare("blob_versioned_hashes", tx.BlobVersionedHashes, rpcTx.BlobHashes())
+	}
+	if rpcTx.Type() == gethtypes.SetCodeTxType {
+		d.compare("authorization_list", tx.AuthorizationList, rpcTx.SetCodeAuthorizations())
+	}
+}
+
+// checkReceipt compares the receipt fields of the transaction and its logs with the node receipt.
+func (c *crossChecker) checkReceipt(d *differ, tx *types.Transaction, logs []types.Log, receipt *gethtypes.Receipt) {
+	d.compare("cumulative_gas_used", tx.CumulativeGasUsed, receipt.CumulativeGasUsed)
+	d.compare("gas_used", tx.GasUsed, receipt.GasUsed)
+	d.compare("effective_gas_price", tx.EffectiveGasPrice, receipt.EffectiveGasPrice)
+	if len(receipt.PostState) > 0 {
+		d.compare("root", tx.Root, common.BytesToHash(receipt.PostState))
+	} else {
+		d.compare("status", tx.Status, receipt.Status)
+	}
+
+	var contractAddress any
+	if receipt.ContractAddress != (common.Address{}) {
+		contractAddress = receipt.ContractAddress
+	}
+	d.compare("contract_address", tx.ContractAddress, contractAddress)
+
+	var bloom any
+	if tx.LogsBloom != nil {
+		bloom = []byte(*tx.LogsBloom)
+	}
+	d.compare("logs_bloom", bloom, receipt.Bloom)
+
+	sort.SliceStable(logs, func(i, j int) bool { return logs[i].Index() < logs[j].Index() })
+	d.compare("log_count", len(logs), len(receipt.Logs))
+
+	for i, rpcLog := range receipt.Logs {
+		if i >= len(logs) {
+			break
+		}
+		log := logs[i]
+		index := uint64(rpcLog.Index)
+		ld := d.with(LogEntity, d.base.TransactionHash, &index)
+		c.report.CheckedLogs++
+
+		ld.compare("log_index", log.LogIndex, index)
+		ld.compare("address", log.Address, rpcLog.Address)
+		ld.compare("data", log.Data, rpcLog.Data)
+		ld.compare("topics", log.Topics(), rpcLog.Topics)
+		ld.compare("removed", log.Removed, rpcLog.Removed)
+	}
+}
+
+// differ records the differences of the fields of an object.
+type differ struct {
+	report *CrossReport
+	base   Difference
+}
+
+// with returns a differ for a nested object.
+func (d *differ) with(entity Entity, txHash *common.Hash, logIndex *uint64) *differ {
+	base := d.base
+	base.Entity = entity
+	base.TransactionHash = txHash
+	base.LogIndex = logIndex
+	return &differ{report: d.report, base: base}
+}
+
+// compare records a difference when the formatted values differ.
+func (d *differ) compare(field string, hyper any, rpc any) {
+	hyperValue, rpcValue := formatValue(hyper), formatValue(rpc)
+	if hyperValue != rpcValue {
+		d.add(field, hyperValue, rpcValue)
+	}
+}
+
+func (d *differ) add(field string, hyper string, rpc string) {
+	difference := d.base
+	difference.Field = field
+	difference.HyperSync = hyper
+	difference.RPC = rpc
+	d.report.Differences = append(d.report.Differences, difference)
+}
+
+// formatValue formats a field value for comparison. Nil values are formatted as missingValue.
+func formatValue(value any) string {
+	switch val := value.(type) {
+	case nil:
+		return missingValue
+	case *big.Int:
+		if val == nil {
+			return missingValue
+		}
+		return val.String()
+	case *common.Hash:
+		if val == nil {
+			return missingValue
+		}
+		return val.Hex()
+	case common.Hash:
+		return val.Hex()
+	case *common.Address:
+		if val == nil {
+			return missingValue
+		}
+		return val.Hex()
+	case common.Address:
+		return val.Hex()
+	case *uint64:
+		if val == nil {
+			return missingValue
+		}
+		return strconv.FormatUint(*val, 10)
+	case uint64:
+		return strconv.FormatUint(val, 10)
+	case *uint8:
+		if val == nil {
+			return missingValue
+		}
+		return strconv.FormatUint(uint64(*val), 10)
+	case uint8:
+		return strconv.FormatUint(uint64(val), 10)
+	case int:
+		return strconv.Itoa(val)
+	case *bool:
+		if val == nil {
+			return missingValue
+		}
+		return strconv.FormatBool(*val)
+	case bool:
+		return strconv.FormatBool(val)
+	case *[]byte:
+		if val == nil {
+			return missingValue
+		}
+		return hexutil.Encode(*val)
+	case []byte:
+		return hexutil.Encode(val)
+	case *gethtypes.Bloom:
+		if val == nil {
+			return missingValue
+		}
+		return hexutil.Encode(val.Bytes())
+	case gethtypes.Bloom:
+		return hexutil.Encode(val.Bytes())
+	case *gethtypes.BlockNonce:
+		if val == nil {
+			return missingValue
+		}
+		return hexutil.Encode(val[:])
+	case gethtypes.BlockNonce:
+		return hexutil.Encode(val[:])
+	case *[]common.Hash:
+		if val == nil {
+			return missingValue
+		}
+		return formatValue(*val)
+	case []common.Hash:
+		hashes := make([]string, len(val))
+		for i, hash := range val {
+			hashes[i] = hash.Hex()
+		}
+		return "[" + strings.Join(hashes, ",") + "]"
+	case *[]types.Authorization:
+		if val == nil {
+			return missingValue
+		}
+		authorizations := make([]string, len(*val))
+		for i, a := range *val {
+			authorizations[i] = fmt.Sprintf("{%s %s %d %d %s %s}", a.ChainID, a.Address.Hex(), a.Nonce, a.YParity, a.R, a.S)
+		}
+		return "[" + strings.Join(authorizations, ",") + "]"
+	case []gethtypes.SetCodeAuthorization:
+		authorizations := make([]string, len(val))
+		for i, a := range val {
+			authorizations[i] = fmt.Sprintf("{%s %s %d %d %s %s}", a.ChainID.Dec(), a.Address.Hex(), a.Nonce, a.V, a.R.Dec(), a.S.Dec())
+		}
+		return "[" + strings.Join(authorizations, ",") + "]"
+	default:
+		return fmt.Sprint(val)
+	}
+}
@@ -0,0 +1,431 @@
+package verify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/enviodev/hypersync-client-go/types"
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCrossCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		tamper   func(hyper *fakeHyper)
+		expected []Difference
+	}{
+		{
+			name:   "Untampered",
+			tamper: func(hyper *fakeHyper) {},
+		},
+		{
+			name: "Block field",
+			tamper: func(hyper *fakeHyper) {
+				gasLimit := uint64(1)
+				hyper.data.Blocks[1].GasLimit = &gasLimit
+			},
+			expected: []Difference{{Entity: BlockEntity, BlockNumber: 11, Field: "gas_limit", HyperSync: "1", RPC: "30000000"}},
+		},
+		{
+			name: "Transaction field",
+			tamper: func(hyper *fakeHyper) {
+				hyper.data.Transactions[0].Value = big.NewInt(5)
+			},
+			expected: []Difference{{Entity: TransactionEntity, BlockNumber: 10, Field: "value", HyperSync: "5", RPC: "10"}},
+		},
+		{
+			name: "Receipt field",
+			tamper: func(hyper *fakeHyper) {
+				status := uint8(0)
+				hyper.data.Transactions[3].Status = &status
+			},
+			expected: []Difference{{Entity: ReceiptEntity, BlockNumber: 11, Field: "status", HyperSync: "0", RPC: "1"}},
+		},
+		{
+			name: "Log field",
+			tamper: func(hyper *fakeHyper) {
+				topic := common.HexToHash("0xbb")
+				hyper.data.Logs[2].Topic0 = &topic
+			},
+			expected: []Difference{{
+				Entity:      LogEntity,
+				BlockNumber: 12,
+				Field:       "topics",
+				HyperSync:   "[0x00000000000000000000000000000000000000000000000000000000000000bb]",
+				RPC:         "[0x00000000000000000000000000000000000000000000000000000000000000aa]",
+			}},
+		},
+		{
+			name: "Missing field",
+			tamper: func(hyper *fakeHyper) {
+				hyper.data.Blocks[0].MixHash = nil
+			},
+			expected: []Difference{{
+				Entity:      BlockEntity,
+				BlockNumber: 10,
+				Field:       "mix_hash",
+				HyperSync:   missingValue,
+				RPC:         "0x0000000000000000000000000000000000000000000000000000000000000000",
+			}},
+		},
+		{
+			name: "Missing transaction",
+			tamper: func(hyper *fakeHyper) {
+				hyper.data.Transactions = hyper.data.Transactions[1:]
+			},
+			expected: []Difference{
+				{Entity: BlockEntity, BlockNumber: 10, Field: "transaction_count", HyperSync: "1", RPC: "2"},
+				{Entity: TransactionEntity, BlockNumber: 10, Field: "transaction", HyperSync: missingValue},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hyper, node := newTestChain(t, 10, 3)
+			tt.tamper(hyper)
+
+			report, err := CrossCheck(context.Background(), hyper, node, &CrossCheckOptions{FromBlock: 10, ToBlock: 13, Receipts: true})
+			require.NoError(t, err)
+			require.Equal(t, []uint64{10, 11, 12}, report.Blocks)
+			require.Len(t, hyper.queries, 3)
+
+			if len(tt.expected) == 0 {
+				require.True(t, report.Ok())
+				require.NoError(t, report.Err())
+				require.Equal(t, 6, report.CheckedTransactions)
+				require.Equal(t, 6, report.CheckedReceipts)
+				require.Equal(t, 3, report.CheckedLogs)
+				return
+			}
+
+			require.Len(t, report.Differences, len(tt.expected))
+			for i, expected := range tt.expected {
+				actual := report.Differences[i]
+				require.Equal(t, expected.Entity, actual.Entity)
+				require.Equal(t, expected.BlockNumber, actual.BlockNumber)
+				require.Equal(t, expected.Field, actual.Field)
+				require.Equal(t, expected.HyperSync, actual.HyperSync)
+				if expected.RPC != "" {
+					require.Equal(t, expected.RPC, actual.RPC)
+				}
+			}
+
+			var differenceErr *DifferenceError
+			require.ErrorAs(t, report.Err(), &differenceErr)
+			require.Contains(t, differenceErr.Error(), tt.expected[0].Field)
+		})
+	}
+}
+
+func TestCrossCheckSample(t *testing.T) {
+	hyper, node := newTestChain(t, 10, 10)
+
+	opts := &CrossCheckOptions{FromBlock: 10, ToBlock: 20, SampleSize: 4, Seed: 7}
+	report, err := CrossCheck(context.Background(), hyper, node, opts)
+	require.NoError(t, err)
+	require.True(t, report.Ok())
+	require.Len(t, report.Blocks, 4)
+	require.Equal(t, 8, report.CheckedTransactions)
+	require.Zero(t, report.CheckedReceipts)
+	require.IsIncreasing(t, report.Blocks)
+
+	require.Len(t, hyper.queries, 4)
+	for i, query := range hyper.queries {
+		require.Equal(t, report.Blocks[i], query.FromBlock.Uint64())
+		require.Equal(t, report.Blocks[i]+1, query.ToBlock.Uint64())
+		require.Empty(t, query.Logs)
+	}
+
+	again, err := CrossCheck(context.Background(), hyper, node, opts)
+	require.NoError(t, err)
+	require.Equal(t, report.Blocks, again.Blocks)
+
+	_, err = CrossCheck(context.Background(), hyper, node, &CrossCheckOptions{FromBlock: 20, ToBlock: 10})
+	require.EqualError(t, err, "invalid block range: to block 10 must be after from block 20")
+}
+
+func TestCrossCheckPageByPage(t *testing.T) {
+	hyper, node := newTestChain(t, 10, 3)
+	calls := make([]string, 0)
+	hyper.calls, node.calls = &calls, &calls
+
+	report, err := CrossCheck(context.Background(), hyper, node, &CrossCheckOptions{FromBlock: 10, ToBlock: 13})
+	require.NoError(t, err)
+	require.True(t, report.Ok())
+
+	// Each page is compared before the next one is fetched.
+	require.Equal(t, []string{"hypersync 10", "rpc 10", "hypersync 11", "rpc 11", "hypersync 12", "rpc 12"}, calls)
+}
+
+func TestCrossCheckSetCodeTransactionOverRPC(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chainID := big.NewInt(1)
+	signer := gethtypes.LatestSignerForChainID(chainID)
+
+	tx, err := gethtypes.SignNewTx(key, signer, &gethtypes.SetCodeTx{
+		ChainID:   uint256.MustFromBig(chainID),
+		GasTipCap: uint256.NewInt(1e9),
+		GasFeeCap: uint256.NewInt(30e9),
+		Gas:       60000,
+		To:        crypto.PubkeyToAddress(key.PublicKey),
+		Value:     uint256.NewInt(0),
+		AuthList: []gethtypes.SetCodeAuthorization{
+			{ChainID: *uint256.NewInt(1), Address: common.HexToAddress("0x7702"), Nonce: 1, V: 1, R: *uint256.NewInt(2), S: *uint256.NewInt(3)},
+		},
+	})
+	require.NoError(t, err)
+
+	beaconRoot, requestsHash := common.HexToHash("0xbeac"), common.HexToHash("0x7685")
+	blobGasUsed, excessBlobGas := uint64(0), uint64(0)
+	header := &gethtypes.Header{
+		ParentHash:       common.HexToHash("0x01"),
+		Difficulty:       big.NewInt(0),
+		Number:           big.NewInt(22431084),
+		GasLimit:         36000000,
+		GasUsed:          60000,
+		Time:             1746612311,
+		Extra:            []byte{},
+		BaseFee:          big.NewInt(7e9),
+		BlobGasUsed:      &blobGasUsed,
+		ExcessBlobGas:    &excessBlobGas,
+		ParentBeaconRoot: &beaconRoot,
+		RequestsHash:     &requestsHash,
+	}
+	block := gethtypes.NewBlock(header, &gethtypes.Body{Transactions: gethtypes.Transactions{tx}, Withdrawals: []*gethtypes.Withdrawal{}}, nil, types.NewTrieHasher())
+
+	hyperTx, err := types.NewTransactionFromGeth(tx, signer, 22431084, 0)
+	require.NoError(t, err)
+	blockHash := block.Hash()
+	hyperTx.BlockHash = &blockHash
+	hyper := &fakeHyper{data: types.DataResponse{
+		Blocks:       []types.Block{*types.NewBlockFromHeader(block.Header())},
+		Transactions: []types.Transaction{*hyperTx},
+	}}
+
+	server := rpc.NewServer()
+	defer server.Stop()
+	require.NoError(t, server.RegisterName("eth", &blockService{block: block}))
+	node := ethclient.NewClient(rpc.DialInProc(server))
+	defer node.Close()
+
+	report, err := CrossCheck(context.Background(), hyper, node, &CrossCheckOptions{FromBlock: 22431084, ToBlock: 22431085})
+	require.NoError(t, err)
+	require.True(t, report.Ok(), report.Differences)
+	require.Equal(t, 1, report.CheckedTransactions)
+
+	authorizations := append([]types.Authorization{}, *hyperTx.AuthorizationList...)
+	authorizations[0].Nonce = 2
+	hyper.data.Transactions[0].AuthorizationList = &authorizations
+	report, err = CrossCheck(context.Background(), hyper, node, &CrossCheckOptions{FromBlock: 22431084, ToBlock: 22431085})
+	require.NoError(t, err)
+	require.Len(t, report.Differences, 1)
+	require.Equal(t, "authorization_list", report.Differences[0].Field)
+}
+
+// blockService serves eth_getBlockByNumber for a single block with full transactions, as a node does.
+type blockService struct {
+	block *gethtypes.Block
+}
+
+func (s *blockService) GetBlockByNumber(number rpc.BlockNumber, fullTx bool) (json.RawMessage, error) {
+	if number.Int64() != s.block.Number().Int64() {
+		return json.RawMessage("null"), nil
+	}
+
+	encoded, err := json.Marshal(s.block.Header())
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]any)
+	if err = json.Unmarshal(encoded, &fields); err != nil {
+		return nil, err
+	}
+
+	transactions := make([]json.RawMessage, 0, len(s.block.Transactions()))
+	for _, tx := range s.block.Transactions() {
+		encodedTx, tErr := json.Marshal(tx)
+		if tErr != nil {
+			return nil, tErr
+		}
+		transactions = append(transactions, encodedTx)
+	}
+	fields["transactions"] = transactions
+	fields["uncles"] = []common.Hash{}
+	fields["withdrawals"] = s.block.Withdrawals()
+	return json.Marshal(fields)
+}
+
+// fakeHyper serves the HyperSync data of a chain, one block per page.
+type fakeHyper struct {
+	data    types.DataResponse
+	queries []*types.Query
+	calls   *[]string
+}
+
+func (f *fakeHyper) Get(ctx context.Context, query *types.Query) (*types.QueryResponse, error) {
+	captured := *query
+	captured.FromBlock = new(big.Int).Set(query.FromBlock)
+	f.queries = append(f.queries, &captured)
+	if f.calls != nil {
+		*f.calls = append(*f.calls, fmt.Sprintf("hypersync %s", query.FromBlock))
+	}
+
+	number := query.FromBlock
+	response := &types.QueryResponse{NextBlock: new(big.Int).Add(number, big.NewInt(1))}
+	for _, block := range f.data.Blocks {
+		if block.Number.Cmp(number) == 0 {
+			response.Data.Blocks = append(response.Data.Blocks, block)
+		}
+	}
+	for _, tx := range f.data.Transactions {
+		if tx.BlockNumber.Cmp(number) == 0 {
+			response.Data.Transactions = append(response.Data.Transactions, tx)
+		}
+	}
+	if len(query.Logs) > 0 {
+		for _, log := range f.data.Logs {
+			if log.BlockNumber.Cmp(number) == 0 {
+				response.Data.Logs = append(response.Data.Logs, log)
+			}
+		}
+	}
+	return response, nil
+}
+
+// fakeNode serves the blocks and receipts of a chain.
+type fakeNode struct {
+	blocks   map[uint64]*gethtypes.Block
+	receipts map[common.Hash]*gethtypes.Receipt
+	calls    *[]string
+}
+
+func (f *fakeNode) BlockByNumber(ctx context.Context, number *big.Int) (*gethtypes.Block, error) {
+	if f.calls != nil {
+		*f.calls = append(*f.calls, fmt.Sprintf("rpc %s", number))
+	}
+	return f.blocks[number.Uint64()], nil
+}
+
+func (f *fakeNode) TransactionReceipt(ctx context.Context, txHash common.Hash) (*gethtypes.Receipt, error) {
+	return f.receipts[txHash], nil
+}
+
+// newTestChain builds count blocks starting at from, each holding a legacy transfer and a dynamic fee
+// contract call emitting a log, and returns matching HyperSync and node sources.
+func newTestChain(t *testing.T, from uint64, count int) (*fakeHyper, *fakeNode) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	chainID := big.NewInt(1)
+	signer := gethtypes.LatestSignerForChainID(chainID)
+	recipient := common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7")
+	contract := common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+	topic := common.HexToHash("0xaa")
+
+	hyper := &fakeHyper{}
+	node := &fakeNode{blocks: make(map[uint64]*gethtypes.Block), receipts: make(map[common.Hash]*gethtypes.Receipt)}
+	parent := common.HexToHash("0x01")
+	nonce := uint64(0)
+	logIndex := uint(0)
+
+	for number := from; number < from+uint64(count); number++ {
+		legacy, sErr := gethtypes.SignNewTx(key, signer, &gethtypes.LegacyTx{
+			Nonce:    nonce,
+			GasPrice: big.NewInt(10e9),
+			Gas:      21000,
+			To:       &recipient,
+			Value:    new(big.Int).SetUint64(number),
+		})
+		require.NoError(t, sErr)
+		call, sErr := gethtypes.SignNewTx(key, signer, &gethtypes.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     nonce + 1,
+			GasTipCap: big.NewInt(1e9),
+			GasFeeCap: big.NewInt(30e9),
+			Gas:       50000,
+			To:        &contract,
+			Data:      []byte{0x01},
+		})
+		require.NoError(t, sErr)
+		nonce += 2
+		txs := gethtypes.Transactions{legacy, call}
+
+		receipts := gethtypes.Receipts{
+			{Type: legacy.Type(), Status: gethtypes.ReceiptStatusSuccessful, CumulativeGasUsed: 21000, GasUsed: 21000},
+			{
+				Type:              call.Type(),
+				Status:            gethtypes.ReceiptStatusSuccessful,
+				CumulativeGasUsed: 45000,
+				GasUsed:           24000,
+				Logs:              []*gethtypes.Log{{Address: contract, Topics: []common.Hash{topic}, Data: []byte{byte(number)}}},
+			},
+		}
+		for _, receipt := range receipts {
+			receipt.Bloom = gethtypes.CreateBloom(receipt)
+		}
+
+		header := &gethtypes.Header{
+			ParentHash: parent,
+			Coinbase:   common.HexToAddress("0x02"),
+			Root:       common.HexToHash("0x03"),
+			Bloom:      gethtypes.MergeBloom(receipts),
+			Difficulty: big.NewInt(0),
+			Number:     new(big.Int).SetUint64(number),
+			GasLimit:   30000000,
+			GasUsed:    45000,
+			Time:       1700000000 + number*12,
+			Extra:      []byte{},
+			BaseFee:    big.NewInt(7e9),
+		}
+		block := gethtypes.NewBlock(header, &gethtypes.Body{Transactions: txs}, receipts, types.NewTrieHasher())
+		node.blocks[number] = block
+		parent = block.Hash()
+		hyper.data.Blocks = append(hyper.data.Blocks, *types.NewBlockFromHeader(block.Header()))
+
+		for i, tx := range txs {
+			receipt := receipts[i]
+			receipt.TxHash = tx.Hash()
+			receipt.BlockHash = block.Hash()
+			receipt.BlockNumber = block.Number()
+			receipt.TransactionIndex = uint(i)
+			receipt.EffectiveGasPrice, _ = tx.EffectiveGasTip(header.BaseFee)
+			receipt.EffectiveGasPrice.Add(receipt.EffectiveGasPrice, header.BaseFee)
+			for _, log := range receipt.Logs {
+				log.TxHash, log.TxIndex, log.BlockHash, log.BlockNumber, log.Index = tx.Hash(), uint(i), block.Hash(), number, logIndex
+				logIndex++
+				hyper.data.Logs = append(hyper.data.Logs, *types.NewLogFromGeth(log))
+			}
+			node.receipts[tx.Hash()] = receipt
+			hyper.data.Transactions = append(hyper.data.Transactions, newTestTransactionWithReceipt(t, signer, tx, receipt))
+		}
+	}
+
+	return hyper, node
+}
+
+func newTestTransactionWithReceipt(t *testing.T, signer gethtypes.Signer, tx *gethtypes.Transaction, receipt *gethtypes.Receipt) types.Transaction {
+	toReturn, err := types.NewTransactionFromGeth(tx, signer, receipt.BlockNumber.Uint64(), uint64(receipt.TransactionIndex))
+	require.NoError(t, err)
+
+	status := uint8(receipt.Status)
+	bloom := types.BloomFilter(receipt.Bloom.Bytes())
+	toReturn.BlockHash = &receipt.BlockHash
+	toReturn.GasPrice = tx.GasPrice()
+	toReturn.Status = &status
+	toReturn.CumulativeGasUsed = &receipt.CumulativeGasUsed
+	toReturn.GasUsed = &receipt.GasUsed
+	toReturn.EffectiveGasPrice = receipt.EffectiveGasPrice
+	toReturn.LogsBloom = &bloom
+	return *toReturn
+}
@@ -0,0 +1,5 @@
+// Package verify checks the integrity of HyperSync query responses by recomputing block header hashes,
+// transactions roots, transaction hashes and transaction senders, and reporting mismatches per block.
+// CrossCheck additionally compares HyperSync blocks, transactions, receipts and logs with the ones served
+// by a node over RPC, reporting the differences field by field.
+package verify
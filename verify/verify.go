@@ -0,0 +1,365 @@
+package verify
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	errorshs "github.com/enviodev/hypersync-client-go/errors"
+	"github.com/enviodev/hypersync-client-go/options"
+	"github.com/enviodev/hypersync-client-go/types"
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+// Check identifies an integrity check.
+type Check string
+
+const (
+	// HeaderHash compares the recomputed header hash to the block hash.
+	HeaderHash Check = "header_hash"
+	// TransactionsRoot compares the recomputed transactions root to the block transactions root.
+	TransactionsRoot Check = "transactions_root"
+	// TransactionHash compares the hash of the RLP encoded transaction to the transaction hash.
+	TransactionHash Check = "transaction_hash"
+	// Sender compares the address recovered from the transaction signature to the from address.
+	Sender Check = "sender"
+)
+
+// Mismatch describes a value returned by HyperSync that does not match the value computed from the data.
+type Mismatch struct {
+	// Check is the failed check.
+	Check Check
+	// BlockNumber is the block the mismatch was found in.
+	BlockNumber uint64
+	// TransactionIndex is the index of the mismatching transaction, or nil for block checks.
+	TransactionIndex *uint64
+	// Expected is the value returned by HyperSync.
+	Expected string
+	// Computed is the value computed from the data.
+	Computed string
+}
+
+// String returns a human-readable description of the mismatch.
+func (m Mismatch) String() string {
+	if m.TransactionIndex != nil {
+		return fmt.Sprintf("block %d transaction %d: %s mismatch (expected: %s, computed: %s)", m.BlockNumber, *m.TransactionIndex, m.Check, m.Expected, m.Computed)
+	}
+	return fmt.Sprintf("block %d: %s mismatch (expected: %s, computed: %s)", m.BlockNumber, m.Check, m.Expected, m.Computed)
+}
+
+// Skip describes a check that could not be run, such as the checks of a transaction of a type go-ethereum
+// does not support.
+type Skip struct {
+	// Check is the skipped check.
+	Check Check
+	// BlockNumber is the block of the skipped check.
+	BlockNumber uint64
+	// TransactionIndex is the index of the transaction of the skipped check, or nil for block checks.
+	TransactionIndex *uint64
+	// Reason is why the check was skipped.
+	Reason string
+}
+
+// String returns a human-readable description of the skipped check.
+func (s Skip) String() string {
+	if s.TransactionIndex != nil {
+		return fmt.Sprintf("block %d transaction %d: %s skipped (%s)", s.BlockNumber, *s.TransactionIndex, s.Check, s.Reason)
+	}
+	return fmt.Sprintf("block %d: %s skipped (%s)", s.BlockNumber, s.Check, s.Reason)
+}
+
+// BlockReport holds the mismatches found in a block.
+type BlockReport struct {
+	// Number is the block number.
+	Number uint64
+	// Mismatches are the mismatches found in the block and its transactions.
+	Mismatches []Mismatch
+}
+
+// Report holds the outcome of the verification of a response.
+type Report struct {
+	// Blocks are the blocks with at least one mismatch, sorted by block number.
+	Blocks []BlockReport
+	// CheckedBlocks is the number of verified blocks.
+	CheckedBlocks int
+	// CheckedTransactions is the number of verified transactions.
+	CheckedTransactions int
+	// Skipped are the checks that could not be run, sorted by block number. Skipped checks are not
+	// mismatches and do not fail the verification.
+	Skipped []Skip
+}
+
+// Ok reports whether no mismatch was found.
+func (r *Report) Ok() bool {
+	return len(r.Blocks) == 0
+}
+
+// Mismatches returns every mismatch of the report, ordered by block.
+func (r *Report) Mismatches() []Mismatch {
+	toReturn := make([]Mismatch, 0)
+	for _, block := range r.Blocks {
+		toReturn = append(toReturn, block.Mismatches...)
+	}
+	return toReturn
+}
+
+// Err returns a *MismatchError when mismatches were found and nil otherwise.
+func (r *Report) Err() error {
+	if r.Ok() {
+		return nil
+	}
+	return &MismatchError{Report: r}
+}
+
+// MismatchError is returned when a response fails verification.
+type MismatchError struct {
+	Report *Report
+}
+
+// Error lists the mismatches of the report.
+func (e *MismatchError) Error() string {
+	mismatches := e.Report.Mismatches()
+	descriptions := make([]string, 0, len(mismatches))
+	for _, mismatch := range mismatches {
+		descriptions = append(descriptions, mismatch.String())
+	}
+	return fmt.Sprintf("integrity verification failed in %d blocks: %s", len(e.Report.Blocks), strings.Join(descriptions, "; "))
+}
+
+// Response runs the checks enabled in opts on the response and reports the mismatches per block.
+//
+// An error is returned, rather than a mismatch, when the fields a check needs were not selected. The
+// transactions root check assumes the response holds every transaction of its blocks, so the query must
+// select all of them, for example with an empty transaction selection and JoinAll.
+//
+// Transactions of types go-ethereum does not support, such as OP-stack deposits, cannot be
+// encoded, so their checks and the transactions root check of their blocks are reported in Report.Skipped.
+//
+// Example:
+//
+//	report, err := verify.Response(response, options.DefaultVerifyOptions())
+//	if err != nil {
+//	    log.Fatalf("Failed to verify response: %v", err)
+//	}
+//	for _, block := range report.Blocks {
+//	    fmt.Printf("Block %d failed verification: %v\n", block.Number, block.Mismatches)
+//	}
+func Response(response *types.QueryResponse, opts *options.VerifyOptions) (*Report, error) {
+	if response == nil {
+		return nil, errors.New("response is nil")
+	}
+	if opts == nil {
+		opts = options.DefaultVerifyOptions()
+	}
+
+	v := &verifier{opts: opts, blocks: make(map[uint64]*BlockReport), unsupported: make(map[uint64]bool)}
+
+	transactions, err := v.transactions(response.Data.Transactions)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.HeaderHash || opts.TransactionsRoot {
+		for i := range response.Data.Blocks {
+			if bErr := v.block(&response.Data.Blocks[i], transactions, len(response.Data.Transactions) > 0); bErr != nil {
+				return nil, bErr
+			}
+		}
+	}
+
+	return v.report(), nil
+}
+
+// verifier accumulates the mismatches of a response.
+type verifier struct {
+	opts                *options.VerifyOptions
+	blocks              map[uint64]*BlockReport
+	skipped             []Skip
+	unsupported         map[uint64]bool
+	checkedBlocks       int
+	checkedTransactions int
+}
+
+// transaction is a converted transaction with its position.
+type transaction struct {
+	index uint64
+	tx    *gethtypes.Transaction
+}
+
+// transactions verifies the transactions and returns them converted, grouped by block number and sorted by
+// transaction index. Transactions are only converted when a transaction check is enabled.
+func (v *verifier) transactions(txs []types.Transaction) (map[uint64][]transaction, error) {
+	grouped := make(map[uint64][]transaction)
+	if !v.opts.TransactionHash && !v.opts.Sender && !v.opts.TransactionsRoot {
+		return grouped, nil
+	}
+
+	for i := range txs {
+		tx := &txs[i]
+
+		missing := &types.MissingFieldsError{Entity: "transaction"}
+		if tx.BlockNumber == nil {
+			missing.Fields = append(missing.Fields, "block_number")
+		}
+		if tx.TransactionIndex == nil {
+			missing.Fields = append(missing.Fields, "transaction_index")
+		}
+		if v.opts.TransactionHash && tx.Hash == nil {
+			missing.Fields = append(missing.Fields, "hash")
+		}
+		if v.opts.Sender && tx.From == nil {
+			missing.Fields = append(missing.Fields, "from")
+		}
+		if len(missing.Fields) > 0 {
+			return nil, missing
+		}
+
+		number, index := tx.BlockNumber.Uint64(), *tx.TransactionIndex
+		converted, err := tx.ToGeth()
+		if errors.Is(err, errorshs.ErrUnsupportedTransactionType) {
+			v.unsupported[number] = true
+			if v.opts.TransactionHash {
+				v.skipped = append(v.skipped, Skip{Check: TransactionHash, BlockNumber: number, TransactionIndex: &index, Reason: err.Error()})
+			}
+			if v.opts.Sender {
+				v.skipped = append(v.skipped, Skip{Check: Sender, BlockNumber: number, TransactionIndex: &index, Reason: err.Error()})
+			}
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to convert transaction %d of block %d", index, number)
+		}
+		v.checkedTransactions++
+
+		if v.opts.TransactionHash && converted.Hash() != *tx.Hash {
+			v.add(Mismatch{Check: TransactionHash, BlockNumber: number, TransactionIndex: &index, Expected: tx.Hash.Hex(), Computed: converted.Hash().Hex()})
+		}
+
+		if v.opts.Sender {
+			from, sErr := gethtypes.Sender(signerFor(converted), converted)
+			if sErr != nil {
+				v.add(Mismatch{Check: Sender, BlockNumber: number, TransactionIndex: &index, Expected: tx.From.Hex(), Computed: sErr.Error()})
+			} else if from != *tx.From {
+				v.add(Mismatch{Check: Sender, BlockNumber: number, TransactionIndex: &index, Expected: tx.From.Hex(), Computed: from.Hex()})
+			}
+		}
+
+		grouped[number] = append(grouped[number], transaction{index: index, tx: converted})
+	}
+
+	for _, group := range grouped {
+		sort.SliceStable(group, func(i, j int) bool {
+			return group[i].index < group[j].index
+		})
+	}
+
+	return grouped, nil
+}
+
+// block verifies the header hash and the transactions root of the block. The transactions root is only
+// verified when the response holds transaction data.
+func (v *verifier) block(block *types.Block, transactions map[uint64][]transaction, hasTransactions bool) error {
+	if block.Number == nil {
+		return &types.MissingFieldsError{Entity: "block", Fields: []string{"number"}}
+	}
+	number := block.Number.Uint64()
+	v.checkedBlocks++
+
+	if v.opts.HeaderHash {
+		if block.Hash == nil {
+			return &types.MissingFieldsError{Entity: "block", Fields: []string{"hash"}}
+		}
+
+		header, err := block.ToHeader()
+		if err != nil {
+			return errors.Wrapf(err, "failed to convert header of block %d", number)
+		}
+
+		if computed := header.Hash(); computed != *block.Hash {
+			if !mayBePrague(block) {
+				v.add(Mismatch{Check: HeaderHash, BlockNumber: number, Expected: block.Hash.Hex(), Computed: computed.Hex()})
+			} else if !matchesWithoutRequests(header, *block.Hash) {
+				v.skipped = append(v.skipped, Skip{Check: HeaderHash, BlockNumber: number, Reason: "the requests hash of the block was not returned"})
+			}
+		}
+	}
+
+	if v.opts.TransactionsRoot && hasTransactions {
+		if block.TransactionsRoot == nil {
+			return &types.MissingFieldsError{Entity: "block", Fields: []string{"transactions_root"}}
+		}
+		if v.unsupported[number] {
+			v.skipped = append(v.skipped, Skip{Check: TransactionsRoot, BlockNumber: number, Reason: "the block holds transactions of unsupported types"})
+			return nil
+		}
+
+		txs := make(gethtypes.Transactions, 0, len(transactions[number]))
+		for _, tx := range transactions[number] {
+			txs = append(txs, tx.tx)
+		}
+
+		root := gethtypes.DeriveSha(txs, types.NewTrieHasher())
+		if root != *block.TransactionsRoot {
+			v.add(Mismatch{Check: TransactionsRoot, BlockNumber: number, Expected: block.TransactionsRoot.Hex(), Computed: root.Hex()})
+		}
+	}
+
+	return nil
+}
+
+// mayBePrague reports whether the block may be a Prague block whose requests hash was not returned: it carries
+// the Cancun fields but no requests hash, so its header hash cannot be recomputed.
+func mayBePrague(block *types.Block) bool {
+	return block.RequestsHash == nil && block.ParentBeaconBlockRoot != nil
+}
+
+// matchesWithoutRequests reports whether the header hashes to hash as a Prague header of a block without
+// execution layer requests, whose requests hash is the empty one.
+func matchesWithoutRequests(header *gethtypes.Header, hash common.Hash) bool {
+	requestsHash := gethtypes.EmptyRequestsHash
+	header.RequestsHash = &requestsHash
+	return header.Hash() == hash
+}
+
+// add records the mismatch in the report of its block.
+func (v *verifier) add(mismatch Mismatch) {
+	block, ok := v.blocks[mismatch.BlockNumber]
+	if !ok {
+		block = &BlockReport{Number: mismatch.BlockNumber}
+		v.blocks[mismatch.BlockNumber] = block
+	}
+	block.Mismatches = append(block.Mismatches, mismatch)
+}
+
+// report returns the accumulated report, with blocks sorted by number.
+func (v *verifier) report() *Report {
+	report := &Report{
+		Blocks:              make([]BlockReport, 0, len(v.blocks)),
+		CheckedBlocks:       v.checkedBlocks,
+		CheckedTransactions: v.checkedTransactions,
+		Skipped:             v.skipped,
+	}
+	for _, block := range v.blocks {
+		report.Blocks = append(report.Blocks, *block)
+	}
+	sort.Slice(report.Blocks, func(i, j int) bool {
+		return report.Blocks[i].Number < report.Blocks[j].Number
+	})
+	sort.SliceStable(report.Skipped, func(i, j int) bool {
+		return report.Skipped[i].BlockNumber < report.Skipped[j].BlockNumber
+	})
+	return report
+}
+
+// signerFor returns the signer recovering the sender of the transaction. Legacy transactions signed before
+// EIP-155 carry no chain id and are recovered with the homestead rules.
+func signerFor(tx *gethtypes.Transaction) gethtypes.Signer {
+	chainID := tx.ChainId()
+	if chainID == nil || chainID.Sign() == 0 {
+		return gethtypes.HomesteadSigner{}
+	}
+	return gethtypes.LatestSignerForChainID(new(big.Int).Set(chainID))
+}
@@ -0,0 +1,250 @@
+package verify
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/enviodev/hypersync-client-go/options"
+	"github.com/enviodev/hypersync-client-go/types"
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		tamper   func(response *types.QueryResponse)
+		expected []Mismatch
+	}{
+		{
+			name:   "Untampered",
+			tamper: func(response *types.QueryResponse) {},
+		},
+		{
+			name: "Header hash",
+			tamper: func(response *types.QueryResponse) {
+				gasUsed := uint64(1)
+				response.Data.Blocks[1].GasUsed = &gasUsed
+			},
+			expected: []Mismatch{{Check: HeaderHash, BlockNumber: 101}},
+		},
+		{
+			name: "Transaction hash and sender",
+			tamper: func(response *types.QueryResponse) {
+				nonce := uint64(99)
+				response.Data.Transactions[1].Nonce = &nonce
+			},
+			expected: []Mismatch{
+				{Check: TransactionHash, BlockNumber: 100, TransactionIndex: uint64Ptr(1)},
+				{Check: Sender, BlockNumber: 100, TransactionIndex: uint64Ptr(1)},
+				{Check: TransactionsRoot, BlockNumber: 100},
+			},
+		},
+		{
+			name: "Sender",
+			tamper: func(response *types.QueryResponse) {
+				from := common.HexToAddress("0x01")
+				response.Data.Transactions[2].From = &from
+			},
+			expected: []Mismatch{{Check: Sender, BlockNumber: 101, TransactionIndex: uint64Ptr(0)}},
+		},
+		{
+			name: "Missing transaction",
+			tamper: func(response *types.QueryResponse) {
+				response.Data.Transactions = response.Data.Transactions[1:]
+			},
+			expected: []Mismatch{{Check: TransactionsRoot, BlockNumber: 100}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := newTestResponse(t)
+			tt.tamper(response)
+
+			report, err := Response(response, options.DefaultVerifyOptions())
+			require.NoError(t, err)
+			require.Equal(t, 2, report.CheckedBlocks)
+
+			mismatches := report.Mismatches()
+			require.Len(t, mismatches, len(tt.expected))
+			for i, expected := range tt.expected {
+				require.Equal(t, expected.Check, mismatches[i].Check)
+				require.Equal(t, expected.BlockNumber, mismatches[i].BlockNumber)
+				require.Equal(t, expected.TransactionIndex, mismatches[i].TransactionIndex)
+				require.NotEqual(t, mismatches[i].Expected, mismatches[i].Computed)
+			}
+
+			if len(tt.expected) == 0 {
+				require.True(t, report.Ok())
+				require.NoError(t, report.Err())
+				return
+			}
+
+			var mismatchErr *MismatchError
+			require.ErrorAs(t, report.Err(), &mismatchErr)
+			require.Contains(t, mismatchErr.Error(), string(tt.expected[0].Check))
+		})
+	}
+}
+
+func TestResponseMissingFields(t *testing.T) {
+	response := newTestResponse(t)
+	response.Data.Transactions[0].From = nil
+
+	var missing *types.MissingFieldsError
+	_, err := Response(response, options.DefaultVerifyOptions())
+	require.ErrorAs(t, err, &missing)
+	require.Equal(t, []string{"from"}, missing.Fields)
+
+	report, err := Response(response, &options.VerifyOptions{HeaderHash: true, TransactionHash: true})
+	require.NoError(t, err)
+	require.True(t, report.Ok())
+	require.Equal(t, 3, report.CheckedTransactions)
+
+	response.Data.Blocks[0].MixHash = nil
+	_, err = Response(response, &options.VerifyOptions{HeaderHash: true})
+	require.ErrorAs(t, err, &missing)
+	require.Equal(t, []string{"mix_hash"}, missing.Fields)
+}
+
+func TestResponseUnsupportedTransactionTypes(t *testing.T) {
+	for _, kind := range []uint8{types.DepositTxType, 0x7f} {
+		response := newTestResponse(t)
+		response.Data.Transactions[2].Kind = &kind
+
+		report, err := Response(response, options.DefaultVerifyOptions())
+		require.NoError(t, err)
+		require.True(t, report.Ok(), "unsupported transactions should not fail the verification")
+		require.Equal(t, 2, report.CheckedBlocks)
+		require.Equal(t, 2, report.CheckedTransactions)
+
+		require.Len(t, report.Skipped, 3)
+		require.Equal(t, Skip{Check: TransactionHash, BlockNumber: 101, TransactionIndex: uint64Ptr(0), Reason: report.Skipped[0].Reason}, report.Skipped[0])
+		require.Equal(t, Sender, report.Skipped[1].Check)
+		require.Equal(t, Skip{Check: TransactionsRoot, BlockNumber: 101, Reason: "the block holds transactions of unsupported types"}, report.Skipped[2])
+		require.Contains(t, report.Skipped[0].String(), "unsupported transaction type")
+	}
+}
+
+func TestResponseRequestsHash(t *testing.T) {
+	beaconRoot, withdrawalsRoot := common.HexToHash("0xbeac"), common.HexToHash("0x5eed")
+	blobGasUsed, excessBlobGas := uint64(0), uint64(0)
+	newPragueBlock := func(requestsHash common.Hash) types.Block {
+		header := &gethtypes.Header{
+			ParentHash:       common.HexToHash("0x01"),
+			UncleHash:        gethtypes.EmptyUncleHash,
+			TxHash:           gethtypes.EmptyTxsHash,
+			ReceiptHash:      gethtypes.EmptyReceiptsHash,
+			Difficulty:       big.NewInt(0),
+			Number:           big.NewInt(22431084),
+			GasLimit:         36000000,
+			Time:             1746612311,
+			Extra:            []byte{},
+			BaseFee:          big.NewInt(7e9),
+			WithdrawalsHash:  &withdrawalsRoot,
+			BlobGasUsed:      &blobGasUsed,
+			ExcessBlobGas:    &excessBlobGas,
+			ParentBeaconRoot: &beaconRoot,
+			RequestsHash:     &requestsHash,
+		}
+		return *types.NewBlockFromHeader(header)
+	}
+	opts := &options.VerifyOptions{HeaderHash: true}
+
+	t.Run("Selected", func(t *testing.T) {
+		block := newPragueBlock(common.HexToHash("0x7685"))
+		report, err := Response(&types.QueryResponse{Data: types.DataResponse{Blocks: []types.Block{block}}}, opts)
+		require.NoError(t, err)
+		require.True(t, report.Ok())
+		require.Empty(t, report.Skipped)
+
+		gasUsed := uint64(1)
+		block.GasUsed = &gasUsed
+		report, err = Response(&types.QueryResponse{Data: types.DataResponse{Blocks: []types.Block{block}}}, opts)
+		require.NoError(t, err)
+		require.Len(t, report.Mismatches(), 1)
+		require.Equal(t, HeaderHash, report.Mismatches()[0].Check)
+	})
+
+	t.Run("Not selected", func(t *testing.T) {
+		block := newPragueBlock(common.HexToHash("0x7685"))
+		block.RequestsHash = nil
+		report, err := Response(&types.QueryResponse{Data: types.DataResponse{Blocks: []types.Block{block}}}, opts)
+		require.NoError(t, err)
+		require.True(t, report.Ok())
+		require.Equal(t, []Skip{{Check: HeaderHash, BlockNumber: 22431084, Reason: "the requests hash of the block was not returned"}}, report.Skipped)
+	})
+
+	t.Run("Not selected without requests", func(t *testing.T) {
+		block := newPragueBlock(gethtypes.EmptyRequestsHash)
+		block.RequestsHash = nil
+		report, err := Response(&types.QueryResponse{Data: types.DataResponse{Blocks: []types.Block{block}}}, opts)
+		require.NoError(t, err)
+		require.True(t, report.Ok())
+		require.Empty(t, report.Skipped)
+	})
+}
+
+// newTestResponse builds a response of two blocks holding signed transactions, with every field selected.
+func newTestResponse(t *testing.T) *types.QueryResponse {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	chainID := big.NewInt(1)
+	signer := gethtypes.LatestSignerForChainID(chainID)
+	to := common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7")
+
+	response := &types.QueryResponse{NextBlock: big.NewInt(102)}
+	nonce := uint64(0)
+	for _, block := range []struct {
+		number uint64
+		count  int
+	}{{100, 2}, {101, 1}} {
+		number, count := block.number, block.count
+		txs := make(gethtypes.Transactions, 0, count)
+		for i := 0; i < count; i++ {
+			tx, sErr := gethtypes.SignNewTx(key, signer, &gethtypes.DynamicFeeTx{
+				ChainID:   chainID,
+				Nonce:     nonce,
+				GasTipCap: big.NewInt(1e9),
+				GasFeeCap: big.NewInt(30e9),
+				Gas:       21000,
+				To:        &to,
+				Value:     big.NewInt(int64(number)),
+			})
+			require.NoError(t, sErr)
+			txs = append(txs, tx)
+			nonce++
+		}
+
+		header := &gethtypes.Header{
+			ParentHash:  common.BigToHash(big.NewInt(int64(number - 1))),
+			UncleHash:   gethtypes.EmptyUncleHash,
+			TxHash:      gethtypes.DeriveSha(txs, types.NewTrieHasher()),
+			ReceiptHash: gethtypes.EmptyReceiptsHash,
+			Difficulty:  big.NewInt(0),
+			Number:      new(big.Int).SetUint64(number),
+			GasLimit:    30000000,
+			GasUsed:     uint64(count) * 21000,
+			Time:        1700000000 + number*12,
+			Extra:       []byte{},
+			BaseFee:     big.NewInt(7e9),
+		}
+
+		response.Data.Blocks = append(response.Data.Blocks, *types.NewBlockFromHeader(header))
+		for i, tx := range txs {
+			converted, err := types.NewTransactionFromGeth(tx, signer, number, uint64(i))
+			require.NoError(t, err)
+			response.Data.Transactions = append(response.Data.Transactions, *converted)
+		}
+	}
+
+	return response
+}
+
+func uint64Ptr(v uint64) *uint64 {
+	return &v
+}